@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+
+	"task-cli/internal/model"
+	"task-cli/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// backupListFields は backup list の出力列
+var backupListFields = []string{"name", "created_at", "tasks", "size"}
+
+// currentDataLabel は backup diff で現在のデータを表す表示名
+const currentDataLabel = "current data"
+
+// newBackupCommand は backup サブコマンド群を作成する
+func newBackupCommand(config *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "List, create, compare and restore backups",
+	}
+
+	cmd.AddCommand(
+		newBackupListCommand(config),
+		newBackupCreateCommand(config),
+		newBackupDiffCommand(config),
+		newBackupRestoreCommand(config),
+	)
+
+	return cmd
+}
+
+// newBackupListCommand は backup list サブコマンドを作成する
+func newBackupListCommand(config *Config) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List backups, newest first",
+		Args:    cobra.NoArgs,
+		// データファイルが破損していても復元先を探せるようにする
+		Annotations: map[string]string{skipDataCheckAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := ParseOutputFormat(format)
+			if err != nil {
+				return err
+			}
+
+			taskService := newTaskService(config)
+			backups, err := taskService.ListBackups(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			set := &recordSet{fields: backupListFields, records: make([][]interface{}, 0, len(backups))}
+			for _, backup := range backups {
+				// 読み込めないバックアップもタスク数を空にして一覧に含める
+				var taskCount interface{}
+				if appData, _, err := taskService.LoadBackup(cmd.Context(), backup.Name); err == nil {
+					taskCount = len(appData.Tasks)
+				}
+				set.records = append(set.records, []interface{}{backup.Name, backup.CreatedAt, taskCount, backup.Size})
+			}
+			return set.write(cmd.OutOrStdout(), outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "output", "o", string(OutputTable), "Output format (table, json, yaml, csv)")
+
+	return cmd
+}
+
+// newBackupCreateCommand は backup create サブコマンドを作成する
+func newBackupCreateCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create",
+		Short: "Create a backup of the current data",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := newTaskService(config).CreateBackup(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created backup %s\n", filepath.Base(path))
+			return nil
+		},
+	}
+}
+
+// newBackupDiffCommand は backup diff サブコマンドを作成する
+func newBackupDiffCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <backup> [backup]",
+		Short: "Show tasks added, removed and changed between two backups",
+		Long: `Show tasks added, removed and changed between two backups.
+When only one backup is given it is compared with the current data.
+Backups can be named by file name, a unique prefix, or "latest".`,
+		Args:        cobra.RangeArgs(1, 2),
+		Annotations: map[string]string{skipDataCheckAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskService := newTaskService(config)
+			before, beforeInfo, err := taskService.LoadBackup(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			var (
+				after      *model.AppData
+				afterLabel string
+			)
+			if len(args) == 2 {
+				data, afterInfo, err := taskService.LoadBackup(cmd.Context(), args[1])
+				if err != nil {
+					return err
+				}
+				after, afterLabel = data, afterInfo.Name
+			} else {
+				if after, err = taskService.LoadCurrent(cmd.Context()); err != nil {
+					return err
+				}
+				afterLabel = currentDataLabel
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Comparing %s -> %s\n", beforeInfo.Name, afterLabel)
+			printSnapshotDiff(cmd.OutOrStdout(), service.DiffSnapshots(before, after))
+			return nil
+		},
+	}
+}
+
+// newBackupRestoreCommand は backup restore サブコマンドを作成する
+func newBackupRestoreCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <backup>",
+		Short: "Restore data from a backup, backing up the current data first",
+		Long: `Restore data from a backup, backing up the current data first.
+The backup can be named by file name, a unique prefix, or "latest".`,
+		Args: cobra.ExactArgs(1),
+		// 破損したデータファイルの復旧にも使用するため事前の検査を行わない
+		Annotations: map[string]string{skipDataCheckAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := newTaskService(config).RestoreBackup(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if result.SafetyPath != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Backed up current data to %s\n", filepath.Base(result.SafetyPath))
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored %d tasks from %s\n", result.TaskCount, result.Restored.Name)
+			return nil
+		},
+	}
+}
+
+// printSnapshotDiff はタスクの差分を人間向けに出力する
+func printSnapshotDiff(out io.Writer, diff service.SnapshotDiff) {
+	if diff.IsEmpty() {
+		fmt.Fprintln(out, "No differences")
+		return
+	}
+
+	for _, task := range diff.Added {
+		fmt.Fprintf(out, "+ %s %s\n", shortID(task.ID), task.Title)
+	}
+	for _, task := range diff.Removed {
+		fmt.Fprintf(out, "- %s %s\n", shortID(task.ID), task.Title)
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(out, "~ %s %s\n", shortID(change.After.ID), change.After.Title)
+		before := reflect.ValueOf(change.Before).Elem()
+		after := reflect.ValueOf(change.After).Elem()
+		for _, field := range change.Fields {
+			index := taskFieldIndex.indexes[field]
+			fmt.Fprintf(out, "    %s: %q -> %q\n", field,
+				formatCell(field, before.Field(index).Interface(), true),
+				formatCell(field, after.Field(index).Interface(), true))
+		}
+	}
+
+	fmt.Fprintf(out, "%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+}
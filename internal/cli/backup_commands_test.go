@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createBackup は backup create を実行し、作成されたバックアップ名を返す
+func createBackup(t *testing.T, dataDir string) string {
+	t.Helper()
+
+	output, err := executeCommand(t, dataDir, "backup", "create")
+	require.NoError(t, err)
+	return strings.TrimSpace(strings.TrimPrefix(output, "Created backup "))
+}
+
+func TestBackupListCommand_ShouldShowTaskCounts(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "First")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Second")
+	require.NoError(t, err)
+	name := createBackup(t, dataDir)
+
+	// When
+	output, err := executeCommand(t, dataDir, "backup", "list", "-o", "json")
+
+	// Then
+	require.NoError(t, err)
+	var backups []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &backups))
+	require.Len(t, backups, 1)
+	assert.Equal(t, name, backups[0]["name"])
+	assert.Equal(t, float64(2), backups[0]["tasks"])
+	assert.NotZero(t, backups[0]["size"])
+}
+
+func TestBackupDiffCommand_ShouldShowChangesSinceBackup(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Will change")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Will be removed")
+	require.NoError(t, err)
+	name := createBackup(t, dataDir)
+
+	tasks := loadTasks(t, dataDir)
+	_, err = executeCommand(t, dataDir, "done", tasks[0].ID)
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "rm", tasks[1].ID)
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Brand new")
+	require.NoError(t, err)
+
+	// When
+	output, err := executeCommand(t, dataDir, "backup", "diff", name)
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "+ ")
+	assert.Contains(t, output, "Brand new")
+	assert.Contains(t, output, "- "+shortID(tasks[1].ID)+" Will be removed")
+	assert.Contains(t, output, `status: "todo" -> "completed"`)
+	assert.Contains(t, output, "1 added, 1 removed, 1 changed")
+}
+
+func TestBackupDiffCommand_WithIdenticalBackups_ShouldReportNoDifferences(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Task")
+	require.NoError(t, err)
+	first := createBackup(t, dataDir)
+	second := createBackup(t, dataDir)
+
+	// When
+	output, err := executeCommand(t, dataDir, "backup", "diff", first, second)
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "No differences")
+}
+
+func TestBackupRestoreCommand_ShouldBackUpCurrentDataAndRestore(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Original")
+	require.NoError(t, err)
+	name := createBackup(t, dataDir)
+	_, err = executeCommand(t, dataDir, "add", "Added later")
+	require.NoError(t, err)
+
+	// When
+	output, err := executeCommand(t, dataDir, "backup", "restore", name)
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "Backed up current data to tasks_backup_")
+	assert.Contains(t, output, "Restored 1 tasks from "+name)
+	tasks := loadTasks(t, dataDir)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Original", tasks[0].Title)
+}
+
+func TestBackupRestoreCommand_WithCorruptData_ShouldRestoreWithoutPrompt(t *testing.T) {
+	// Given
+	dataDir := prepareCorruptDataDir(t)
+
+	// When
+	output, err := executeCommand(t, dataDir, "backup", "restore", "latest")
+
+	// Then
+	require.NoError(t, err)
+	assert.NotContains(t, output, "Backed up current data")
+	assert.Contains(t, output, "Restored 1 tasks")
+	assert.Equal(t, "Backed up task", loadTasks(t, dataDir)[0].Title)
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"task-cli/internal/model"
+
+	"github.com/spf13/cobra"
+)
+
+// depsFormat は deps コマンドの出力形式
+type depsFormat string
+
+const (
+	depsFormatText depsFormat = "text"
+	depsFormatDot  depsFormat = "dot"
+)
+
+// newDepsCommand は deps サブコマンドを作成する
+func newDepsCommand(config *Config) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "deps [id]",
+		Short: "Show the dependency graph of tasks",
+		Long: `Show the dependency graph of tasks.
+With an id, shows the tasks blocking it and the tasks it blocks, transitively.
+Without an id, shows every task that takes part in a dependency.
+Use --format dot to emit a Graphviz graph, e.g. task-cli deps --format dot | dot -Tsvg > deps.svg`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := depsFormat(strings.ToLower(format))
+			if outputFormat != depsFormatText && outputFormat != depsFormatDot {
+				return fmt.Errorf("invalid format %q: expected text or dot", format)
+			}
+
+			taskService := newTaskService(config)
+			appData, err := taskService.LoadCurrent(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			var root *model.Task
+			if len(args) == 1 {
+				if root, err = resolveTask(cmd.Context(), taskService, projectID, args[0]); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case outputFormat == depsFormatDot:
+				writeDependencyDot(out, appData, dependencyGraphTasks(appData, root, projectID), root)
+			case root != nil:
+				printDependencyTree(out, appData, root)
+			default:
+				printDependencyList(out, appData, projectID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", string(depsFormatText), "Output format (text, dot)")
+
+	return cmd
+}
+
+// dependencyGraphTasks はグラフに含めるタスクを返す
+// root が指定された場合はその推移的なブロック元とブロック先、それ以外は依存関係を持つ全てのタスク
+// projectID が空でない場合、root を指定しないグラフはそのプロジェクトのブロックされているタスクとそのブロック元だけを含める
+func dependencyGraphTasks(appData *model.AppData, root *model.Task, projectID string) []*model.Task {
+	included := make(map[string]bool)
+	if root == nil {
+		for _, task := range appData.Tasks {
+			if projectID != "" && task.ProjectID != projectID {
+				continue
+			}
+			for _, blocker := range appData.GetBlockers(task.ID) {
+				included[task.ID] = true
+				included[blocker.ID] = true
+			}
+		}
+	} else {
+		included[root.ID] = true
+		collectDependencies(root.ID, included, appData.GetBlockers)
+		collectDependencies(root.ID, included, appData.GetDependents)
+	}
+
+	// データ上の順序を保って出力を安定させる
+	var tasks []*model.Task
+	for _, task := range appData.Tasks {
+		if included[task.ID] {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// collectDependencies は next で辿れるタスクを推移的に visited に追加する
+func collectDependencies(id string, visited map[string]bool, next func(id string) []*model.Task) {
+	for _, task := range next(id) {
+		if visited[task.ID] {
+			continue
+		}
+		visited[task.ID] = true
+		collectDependencies(task.ID, visited, next)
+	}
+}
+
+// printDependencyTree はタスクのブロック元とブロック先を木構造で出力する
+func printDependencyTree(out io.Writer, appData *model.AppData, root *model.Task) {
+	fmt.Fprintln(out, formatDependencyTask(appData, root))
+
+	sections := []struct {
+		label string
+		next  func(id string) []*model.Task
+	}{
+		{"Blocked by", appData.GetBlockers},
+		{"Blocks", appData.GetDependents},
+	}
+	for _, section := range sections {
+		children := section.next(root.ID)
+		if len(children) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s:\n", section.label)
+		printDependencyBranch(out, appData, children, section.next, 1, map[string]bool{root.ID: true})
+	}
+
+	if len(appData.GetBlockers(root.ID)) == 0 && len(appData.GetDependents(root.ID)) == 0 {
+		fmt.Fprintln(out, "\nNo dependencies")
+	}
+}
+
+// printDependencyBranch は next で辿れるタスクを深さに応じて字下げして出力する
+// 一度出力したタスクは再度展開しない
+func printDependencyBranch(out io.Writer, appData *model.AppData, tasks []*model.Task, next func(id string) []*model.Task, depth int, printed map[string]bool) {
+	for _, task := range tasks {
+		line := strings.Repeat("  ", depth) + formatDependencyTask(appData, task)
+		if printed[task.ID] {
+			fmt.Fprintln(out, line+" (see above)")
+			continue
+		}
+		printed[task.ID] = true
+		fmt.Fprintln(out, line)
+		printDependencyBranch(out, appData, next(task.ID), next, depth+1, printed)
+	}
+}
+
+// printDependencyList はブロックされている全てのタスクとそのブロック元を出力する
+// projectID が空でない場合はそのプロジェクトのブロックされているタスクだけを出力する（ブロック元は他のプロジェクトのタスクも含める）
+func printDependencyList(out io.Writer, appData *model.AppData, projectID string) {
+	found := false
+	for _, task := range appData.Tasks {
+		if projectID != "" && task.ProjectID != projectID {
+			continue
+		}
+		blockers := appData.GetBlockers(task.ID)
+		if len(blockers) == 0 {
+			continue
+		}
+		found = true
+		fmt.Fprintln(out, formatDependencyTask(appData, task))
+		for _, blocker := range blockers {
+			fmt.Fprintf(out, "  <- %s\n", formatDependencyTask(appData, blocker))
+		}
+	}
+
+	if !found {
+		fmt.Fprintln(out, "No dependencies")
+	}
+}
+
+// formatDependencyTask はタスクをステータスとブロック状態を含む1行で表す
+func formatDependencyTask(appData *model.AppData, task *model.Task) string {
+	line := fmt.Sprintf("[%s] %s %s", task.Status, shortID(task.ID), task.Title)
+	if !task.IsCompleted() && appData.IsBlocked(task.ID) {
+		line += " (blocked)"
+	}
+	return line
+}
+
+// writeDependencyDot はタスクの依存関係をGraphvizのDOT形式で出力する
+// 辺はブロック元からブロック先へ向かう。完了済みは破線、ブロック中は赤で表示する
+func writeDependencyDot(out io.Writer, appData *model.AppData, tasks []*model.Task, root *model.Task) {
+	included := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		included[task.ID] = true
+	}
+
+	fmt.Fprintln(out, "digraph tasks {")
+	fmt.Fprintln(out, "  rankdir=LR;")
+	fmt.Fprintln(out, "  node [shape=box];")
+	for _, task := range tasks {
+		attributes := []string{"label=" + strconv.Quote(task.Title+"\n"+shortID(task.ID)+" "+string(task.Status))}
+		switch {
+		case task.IsCompleted():
+			attributes = append(attributes, "style=dashed", "fontcolor=gray")
+		case appData.IsBlocked(task.ID):
+			attributes = append(attributes, "color=red")
+		}
+		if root != nil && task.ID == root.ID {
+			attributes = append(attributes, "penwidth=2")
+		}
+		fmt.Fprintf(out, "  %s [%s];\n", strconv.Quote(task.ID), strings.Join(attributes, ", "))
+	}
+	for _, task := range tasks {
+		for _, blockerID := range task.BlockedBy {
+			if included[blockerID] {
+				fmt.Fprintf(out, "  %s -> %s;\n", strconv.Quote(blockerID), strconv.Quote(task.ID))
+			}
+		}
+	}
+	fmt.Fprintln(out, "}")
+}
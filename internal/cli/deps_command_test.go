@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"testing"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prepareDependencyChain は Design <- Build <- Release の順にブロックし合うタスクを作成する
+func prepareDependencyChain(t *testing.T) (dataDir string, design, build, release *model.Task) {
+	t.Helper()
+
+	dataDir = t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Design")
+	require.NoError(t, err)
+	design = loadTasks(t, dataDir)[0]
+	_, err = executeCommand(t, dataDir, "add", "Build", "--blocked-by", design.ID[:6])
+	require.NoError(t, err)
+	build = loadTasks(t, dataDir)[1]
+	_, err = executeCommand(t, dataDir, "add", "Release", "--blocked-by", build.ID)
+	require.NoError(t, err)
+	release = loadTasks(t, dataDir)[2]
+	return dataDir, design, build, release
+}
+
+func TestAddCommand_WithBlockedBy_ShouldRecordBlocker(t *testing.T) {
+	// Given/When
+	dataDir, design, build, _ := prepareDependencyChain(t)
+
+	// Then
+	assert.Equal(t, []string{design.ID}, build.BlockedBy)
+	output, err := executeCommand(t, dataDir, "show", build.ID)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Blocked by (1 open):")
+	assert.Contains(t, output, "Blocks:")
+}
+
+func TestEditCommand_WithDependencyCycle_ShouldReturnError(t *testing.T) {
+	// Given
+	dataDir, design, _, release := prepareDependencyChain(t)
+
+	// When
+	_, err := executeCommand(t, dataDir, "edit", design.ID, "--blocked-by", release.ID)
+
+	// Then
+	assert.ErrorIs(t, err, model.ErrDependencyCycle)
+	assert.Empty(t, loadTasks(t, dataDir)[0].BlockedBy)
+}
+
+func TestDoneCommand_WithOpenBlocker_ShouldRequireForce(t *testing.T) {
+	// Given
+	dataDir, _, build, _ := prepareDependencyChain(t)
+
+	// When
+	_, refusedErr := executeCommand(t, dataDir, "done", build.ID)
+	statusAfterRefusal := loadTasks(t, dataDir)[1].Status
+	_, forcedErr := executeCommand(t, dataDir, "done", build.ID, "--force")
+
+	// Then
+	require.Error(t, refusedErr)
+	assert.Contains(t, refusedErr.Error(), "--force")
+	assert.Equal(t, model.StatusTodo, statusAfterRefusal)
+	require.NoError(t, forcedErr)
+	assert.Equal(t, model.StatusCompleted, loadTasks(t, dataDir)[1].Status)
+}
+
+func TestDepsCommand_WithID_ShouldPrintBlockersAndDependents(t *testing.T) {
+	// Given
+	dataDir, design, build, release := prepareDependencyChain(t)
+
+	// When
+	output, err := executeCommand(t, dataDir, "deps", release.ID)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "[todo] "+shortID(release.ID)+" Release (blocked)\n"+
+		"\nBlocked by:\n"+
+		"  [todo] "+shortID(build.ID)+" Build (blocked)\n"+
+		"    [todo] "+shortID(design.ID)+" Design\n", output)
+}
+
+func TestDepsCommand_WithoutDependencies_ShouldSaySo(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Alone")
+	require.NoError(t, err)
+
+	// When
+	listOutput, listErr := executeCommand(t, dataDir, "deps")
+	treeOutput, treeErr := executeCommand(t, dataDir, "deps", loadTasks(t, dataDir)[0].ID)
+
+	// Then
+	require.NoError(t, listErr)
+	require.NoError(t, treeErr)
+	assert.Equal(t, "No dependencies\n", listOutput)
+	assert.Contains(t, treeOutput, "No dependencies")
+}
+
+func TestDepsCommand_WithDotFormat_ShouldEmitGraphviz(t *testing.T) {
+	// Given
+	dataDir, design, build, release := prepareDependencyChain(t)
+	_, err := executeCommand(t, dataDir, "add", "Unrelated")
+	require.NoError(t, err)
+
+	// When
+	output, err := executeCommand(t, dataDir, "deps", "--format", "dot")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "digraph tasks {\n")
+	assert.Contains(t, output, `"`+design.ID+`" -> "`+build.ID+`";`)
+	assert.Contains(t, output, `"`+build.ID+`" -> "`+release.ID+`";`)
+	assert.Contains(t, output, `label="Build\n`+shortID(build.ID)+` todo", color=red`)
+	assert.NotContains(t, output, "Unrelated")
+}
+
+func TestDepsCommand_WithProject_ShouldOnlyShowTasksOfProject(t *testing.T) {
+	// Given - 既定のプロジェクトの依存関係とは別に、Work プロジェクトにも依存関係がある
+	dataDir, design, build, _ := prepareDependencyChain(t)
+	_, err := executeCommand(t, dataDir, "project", "add", "Work")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "--project", "Work", "add", "Plan")
+	require.NoError(t, err)
+	plan := loadTasks(t, dataDir)[3]
+	_, err = executeCommand(t, dataDir, "--project", "Work", "add", "Ship", "--blocked-by", plan.ID)
+	require.NoError(t, err)
+
+	// When
+	listOutput, listErr := executeCommand(t, dataDir, "--project", "Work", "deps")
+	dotOutput, dotErr := executeCommand(t, dataDir, "--project", "Work", "deps", "--format", "dot")
+
+	// Then
+	require.NoError(t, listErr)
+	assert.Contains(t, listOutput, "Ship")
+	assert.Contains(t, listOutput, "<- [todo] "+shortID(plan.ID)+" Plan")
+	assert.NotContains(t, listOutput, "Build")
+
+	require.NoError(t, dotErr)
+	assert.Contains(t, dotOutput, `"`+plan.ID+`" ->`)
+	assert.NotContains(t, dotOutput, design.ID)
+	assert.NotContains(t, dotOutput, build.ID)
+}
+
+func TestDepsCommand_WithInvalidFormat_ShouldReturnError(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "deps", "--format", "svg")
+
+	// Then
+	assert.Error(t, err)
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"task-cli/internal/dateparse"
+	"task-cli/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// logFields は log の出力列
+var logFields = []string{"at", "id", "title", "actor", "action", "field", "old", "new"}
+
+// newLogCommand は log サブコマンドを作成する
+func newLogCommand(config *Config) *cobra.Command {
+	var (
+		since  string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "log [id]",
+		Short: "Show the change history of tasks",
+		Long: `Show who changed which fields of tasks and when, oldest first.
+With an id, shows the history of that task. Without an id, shows the changes to all tasks.
+--since accepts a date (` + dateparse.Help + `) or a period to look back such as 1w or 3d.
+The history is kept with each task (only its most recent changes); deleted tasks are listed with a deleted entry
+and can still be looked up by their id.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := ParseOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			var from time.Time
+			if since != "" {
+				if from, err = parseSince(since, time.Now()); err != nil {
+					return err
+				}
+			}
+
+			taskService := newTaskService(config)
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			var taskID string
+			if len(args) == 1 {
+				if taskID, err = resolveLogTaskID(cmd.Context(), taskService, projectID, args[0]); err != nil {
+					return err
+				}
+			}
+
+			activities, err := taskService.GetActivity(cmd.Context(), taskID, from)
+			if err != nil {
+				return err
+			}
+			if projectID != "" {
+				activities = projectActivities(activities, projectID)
+			}
+			return newLogRecordSet(activities).write(cmd.OutOrStdout(), outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only show changes on or after this date, e.g. 2026-01-31, yesterday or 1w")
+	cmd.Flags().StringVarP(&format, "output", "o", string(OutputTable), "Output format (table, json, yaml, csv)")
+
+	return cmd
+}
+
+// newLogRecordSet は変更履歴を、変更されたフィールドごとに1行のrecordSetにする
+// フィールドの変更を含まない記録（作成など）は1行にまとめる
+func newLogRecordSet(activities []service.Activity) *recordSet {
+	set := &recordSet{fields: logFields, records: make([][]interface{}, 0, len(activities))}
+	for _, activity := range activities {
+		change := activity.Change
+		prefix := []interface{}{change.At, activity.Task.ID, activity.Task.Title, change.Actor, string(change.Action)}
+		if len(change.Fields) == 0 {
+			set.records = append(set.records, append(prefix, "", "", ""))
+			continue
+		}
+		for _, field := range change.Fields {
+			record := append(append([]interface{}{}, prefix...), field.Field, field.Old, field.New)
+			set.records = append(set.records, record)
+		}
+	}
+	return set
+}
+
+// resolveLogTaskID は log に指定されたIDをタスクのIDに解決する
+// 一致するタスクがない場合は、削除したタスクの記録から探す
+func resolveLogTaskID(ctx context.Context, taskService *service.TaskService, projectID, idOrPrefix string) (string, error) {
+	task, err := resolveTask(ctx, taskService, projectID, idOrPrefix)
+	if err == nil {
+		return task.ID, nil
+	}
+	if !errors.Is(err, errNoMatchingTask) {
+		return "", err
+	}
+
+	deletions, deletionsErr := taskService.GetDeletions(ctx)
+	if deletionsErr != nil {
+		return "", deletionsErr
+	}
+	matches := map[string]bool{}
+	for _, deletion := range deletions {
+		if projectID != "" && deletion.ProjectID != projectID {
+			continue
+		}
+		if deletion.TaskID == idOrPrefix {
+			return deletion.TaskID, nil
+		}
+		if strings.HasPrefix(deletion.TaskID, idOrPrefix) {
+			matches[deletion.TaskID] = true
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", err
+	case 1:
+		for id := range matches {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("id %q is ambiguous: matches %d deleted tasks", idOrPrefix, len(matches))
+}
+
+// projectActivities はプロジェクトのタスクの変更だけを返す
+func projectActivities(activities []service.Activity, projectID string) []service.Activity {
+	filtered := []service.Activity{}
+	for _, activity := range activities {
+		if activity.Task.ProjectID == projectID {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// parseSince は --since フラグの値を解析する
+// "1w" や "3d" のように数字で始まる期間は、今日からさかのぼった日として解釈する
+func parseSince(value string, now time.Time) (time.Time, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed != "" && unicode.IsDigit(rune(trimmed[0])) {
+		if from, err := dateparse.Parse("-"+trimmed, now); err == nil {
+			return from, nil
+		}
+	}
+
+	from, err := dateparse.Parse(trimmed, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value: %w", err)
+	}
+	return from, nil
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogCommand_WithID_ShouldShowFieldChanges(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Draft title")
+	require.NoError(t, err)
+	id := loadTasks(t, dataDir)[0].ID
+	_, err = executeCommand(t, dataDir, "edit", id, "--title", "Final title", "--status", "in_progress")
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "log", id[:8], "-o", "json")
+
+	// Then
+	require.NoError(t, err)
+	var records []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &records))
+	require.Len(t, records, 3)
+	assert.Equal(t, "created", records[0]["action"])
+	assert.Equal(t, "", records[0]["field"])
+	assert.Equal(t, "updated", records[1]["action"])
+	assert.Equal(t, "title", records[1]["field"])
+	assert.Equal(t, "Draft title", records[1]["old"])
+	assert.Equal(t, "Final title", records[1]["new"])
+	assert.Equal(t, "status", records[2]["field"])
+	assert.Equal(t, "todo", records[2]["old"])
+	assert.Equal(t, "in_progress", records[2]["new"])
+	assert.Equal(t, id, records[2]["id"])
+	assert.NotEmpty(t, records[2]["actor"])
+}
+
+func TestLogCommand_WithSince_ShouldShowAllTasks(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "First")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Second")
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "log", "--since", "1w")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, out, "ACTION")
+	assert.Contains(t, out, "First")
+	assert.Contains(t, out, "Second")
+}
+
+func TestLogCommand_WithSince_ShouldShowDeletedTasks(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Short lived")
+	require.NoError(t, err)
+	id := loadTasks(t, dataDir)[0].ID
+	_, err = executeCommand(t, dataDir, "rm", id)
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "log", "--since", "1w", "-o", "json")
+
+	// Then - 作成の記録はタスクとともに失われ、削除の記録が残る
+	require.NoError(t, err)
+	var records []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &records))
+	require.Len(t, records, 1)
+	assert.Equal(t, "deleted", records[0]["action"])
+	assert.Equal(t, id, records[0]["id"])
+	assert.Equal(t, "Short lived", records[0]["title"])
+}
+
+func TestLogCommand_WithDeletedTaskID_ShouldShowDeletion(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Short lived")
+	require.NoError(t, err)
+	id := loadTasks(t, dataDir)[0].ID
+	_, err = executeCommand(t, dataDir, "rm", id)
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "log", id[:8], "-o", "json")
+
+	// Then
+	require.NoError(t, err)
+	var records []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &records))
+	require.Len(t, records, 1)
+	assert.Equal(t, "deleted", records[0]["action"])
+	assert.Equal(t, id, records[0]["id"])
+}
+
+func TestLogCommand_WithUnknownID_ShouldFail(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Still here")
+	require.NoError(t, err)
+
+	// When
+	_, err = executeCommand(t, dataDir, "log", "zzzzzzzz")
+
+	// Then
+	assert.ErrorContains(t, err, "no task matches id")
+}
+
+func TestLogCommand_WithInvalidSince_ShouldFail(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "log", "--since", "someday")
+
+	// Then
+	assert.ErrorContains(t, err, "invalid --since value")
+}
+
+func TestParseSince_ShouldLookBackForPeriods(t *testing.T) {
+	// Given
+	now := time.Date(2026, 3, 18, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"1w", time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"3d", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"2026-01-31", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"yesterday", time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			// When
+			since, err := parseSince(tc.input, now)
+
+			// Then
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(since), "got %s", since)
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat は出力形式を定義
+type OutputFormat string
+
+const (
+	OutputTable OutputFormat = "table"
+	OutputJSON  OutputFormat = "json"
+	OutputYAML  OutputFormat = "yaml"
+	OutputCSV   OutputFormat = "csv"
+)
+
+// ParseOutputFormat は文字列をOutputFormatに変換する
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(strings.TrimSpace(s))) {
+	case OutputTable, "":
+		return OutputTable, nil
+	case OutputJSON:
+		return OutputJSON, nil
+	case OutputYAML, "yml":
+		return OutputYAML, nil
+	case OutputCSV:
+		return OutputCSV, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q: must be one of table, json, yaml, csv", s)
+	}
+}
+
+// defaultTableTaskFields はテーブル出力で --fields 未指定時に表示する列
+var defaultTableTaskFields = []string{"id", "status", "priority", "due_date", "title", "tags"}
+
+// taskFieldIndex はmodel.Taskのjsonタグ名と構造体フィールドの対応（宣言順）
+var taskFieldIndex = buildFieldIndex(reflect.TypeOf(model.Task{}))
+
+// defaultTaskFields はテーブル以外の出力で --fields 未指定時に出力する列
+// 変更履歴は log コマンドで表示するため、明示的に指定した場合のみ出力する
+var defaultTaskFields = withoutField(taskFieldIndex.names, "changes")
+
+// fieldIndex はjsonタグ名から構造体フィールドを引くための表
+type fieldIndex struct {
+	names   []string
+	indexes map[string]int
+}
+
+// buildFieldIndex は構造体のjsonタグから出力可能なフィールドの一覧を作成する
+func buildFieldIndex(t reflect.Type) fieldIndex {
+	index := fieldIndex{indexes: make(map[string]int)}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		index.names = append(index.names, name)
+		index.indexes[name] = i
+	}
+	return index
+}
+
+// outputOptions は一覧系コマンド共通の出力オプション
+type outputOptions struct {
+	format string
+	fields []string
+}
+
+// addOutputFlags は --output と --fields フラグをコマンドに追加する
+func addOutputFlags(cmd *cobra.Command, opts *outputOptions) {
+	cmd.Flags().StringVarP(&opts.format, "output", "o", string(OutputTable), "Output format (table, json, yaml, csv)")
+	cmd.Flags().StringSliceVar(&opts.fields, "fields", nil, "Comma separated list of fields to output")
+}
+
+// recordSet は出力形式に依存しない、列順を保持した表形式データ
+type recordSet struct {
+	fields  []string
+	records [][]interface{}
+}
+
+// writeTasks はタスク一覧を指定された形式で出力する
+func (o *outputOptions) writeTasks(out io.Writer, tasks []*model.Task) error {
+	format, err := ParseOutputFormat(o.format)
+	if err != nil {
+		return err
+	}
+
+	set, err := newTaskRecordSet(tasks, o.taskFields(format))
+	if err != nil {
+		return err
+	}
+	return set.write(out, format)
+}
+
+// writeTask は単一タスクを指定された形式で出力する（JSON/YAMLは配列ではなくオブジェクト）
+func (o *outputOptions) writeTask(out io.Writer, task *model.Task) error {
+	format, err := ParseOutputFormat(o.format)
+	if err != nil {
+		return err
+	}
+
+	set, err := newTaskRecordSet([]*model.Task{task}, o.taskFields(format))
+	if err != nil {
+		return err
+	}
+	switch format {
+	case OutputJSON:
+		return writeJSON(out, set.object(0))
+	case OutputYAML:
+		return writeYAML(out, set.object(0))
+	default:
+		return set.write(out, format)
+	}
+}
+
+// taskFields は出力形式に応じた出力列を返す
+func (o *outputOptions) taskFields(format OutputFormat) []string {
+	if len(o.fields) > 0 {
+		return o.fields
+	}
+	if format == OutputTable {
+		return defaultTableTaskFields
+	}
+	return defaultTaskFields
+}
+
+// withoutField は fields から field を除いたコピーを返す
+func withoutField(fields []string, field string) []string {
+	result := make([]string, 0, len(fields))
+	for _, name := range fields {
+		if name != field {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// newTaskRecordSet はタスク一覧から指定された列のrecordSetを作成する
+func newTaskRecordSet(tasks []*model.Task, fields []string) (*recordSet, error) {
+	for _, field := range fields {
+		if _, ok := taskFieldIndex.indexes[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q: available fields are %s",
+				field, strings.Join(taskFieldIndex.names, ", "))
+		}
+	}
+
+	set := &recordSet{fields: fields, records: make([][]interface{}, 0, len(tasks))}
+	for _, task := range tasks {
+		value := reflect.ValueOf(task).Elem()
+		record := make([]interface{}, len(fields))
+		for i, field := range fields {
+			record[i] = value.Field(taskFieldIndex.indexes[field]).Interface()
+		}
+		set.records = append(set.records, record)
+	}
+	return set, nil
+}
+
+// write はrecordSetを指定された形式で出力する
+func (s *recordSet) write(out io.Writer, format OutputFormat) error {
+	switch format {
+	case OutputJSON:
+		objects := make([]orderedObject, len(s.records))
+		for i := range s.records {
+			objects[i] = s.object(i)
+		}
+		return writeJSON(out, objects)
+	case OutputYAML:
+		objects := make([]orderedObject, len(s.records))
+		for i := range s.records {
+			objects[i] = s.object(i)
+		}
+		return writeYAML(out, objects)
+	case OutputCSV:
+		return s.writeCSV(out)
+	default:
+		return s.writeTable(out)
+	}
+}
+
+// object はi番目のレコードを列順を保持したオブジェクトとして返す
+func (s *recordSet) object(i int) orderedObject {
+	object := make(orderedObject, len(s.fields))
+	for j, field := range s.fields {
+		object[j] = orderedField{name: field, value: s.records[i][j]}
+	}
+	return object
+}
+
+// writeCSV はヘッダー付きのCSVとして出力する
+func (s *recordSet) writeCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(s.fields); err != nil {
+		return err
+	}
+	for _, record := range s.records {
+		row := make([]string, len(record))
+		for i, value := range record {
+			row[i] = formatCell(s.fields[i], value, false)
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// writeTable は人間向けに整列したテーブルとして出力する
+func (s *recordSet) writeTable(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(s.fields))
+	for i, field := range s.fields {
+		headers[i] = strings.ToUpper(field)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, record := range s.records {
+		row := make([]string, len(record))
+		for i, value := range record {
+			row[i] = formatCell(s.fields[i], value, true)
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// formatCell はテーブル/CSV用にセルの値を文字列化する
+// human が true の場合は短縮IDや読みやすい日時形式を使う
+func formatCell(field string, value interface{}, human bool) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if human && field == "id" {
+			return shortID(v)
+		}
+		return v
+	case time.Time:
+		return formatTime(field, v, human)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatTime(field, *v, human)
+	case []string:
+		return strings.Join(v, ",")
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return ""
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct, reflect.Ptr:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// formatTime は日時を出力用に整形する
+func formatTime(field string, t time.Time, human bool) string {
+	if t.IsZero() {
+		return ""
+	}
+	if !human {
+		return t.Format(time.RFC3339)
+	}
+	if field == "due_date" {
+		return t.Format(dueDateLayout)
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// orderedField はorderedObjectの1要素
+type orderedField struct {
+	name  string
+	value interface{}
+}
+
+// orderedObject はキーの順序を保持してJSON/YAMLに変換されるオブジェクト
+type orderedObject []orderedField
+
+// MarshalJSON はキーの順序を保持したJSONオブジェクトを出力する
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML はキーの順序を保持したYAMLマッピングを出力する
+// 値はJSONと同じ表現（日時はRFC3339文字列など）になるようJSONを経由して変換する
+func (o orderedObject) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, field := range o {
+		data, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		var plain interface{}
+		if err := json.Unmarshal(data, &plain); err != nil {
+			return nil, err
+		}
+
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(plain); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: field.name},
+			valueNode)
+	}
+	return node, nil
+}
+
+// writeJSON は値をインデント付きJSONとして出力する
+func writeJSON(out io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeYAML は値をYAMLとして出力する
+func writeYAML(out io.Writer, value interface{}) error {
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// newOutputTestTasks は出力テスト用のタスクを作成する
+func newOutputTestTasks() []*model.Task {
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	return []*model.Task{
+		{
+			ID:        "11111111-aaaa-bbbb-cccc-000000000001",
+			Title:     "Write docs",
+			Status:    model.StatusTodo,
+			Priority:  model.PriorityHigh,
+			Tags:      []string{"docs", "release"},
+			CreatedAt: created,
+			UpdatedAt: created,
+			DueDate:   &due,
+		},
+		{
+			ID:        "22222222-aaaa-bbbb-cccc-000000000002",
+			Title:     "Fix, quote \"bug\"",
+			Status:    model.StatusCompleted,
+			Priority:  model.PriorityLow,
+			CreatedAt: created,
+			UpdatedAt: created,
+		},
+	}
+}
+
+func TestParseOutputFormat_WithInvalidFormat_ShouldReturnError(t *testing.T) {
+	// When
+	_, err := ParseOutputFormat("xml")
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output format")
+}
+
+func TestOutputOptions_WriteTasks_JSON_ShouldUseModelFieldNames(t *testing.T) {
+	// Given
+	opts := outputOptions{format: "json"}
+	buf := new(bytes.Buffer)
+
+	// When
+	err := opts.writeTasks(buf, newOutputTestTasks())
+
+	// Then
+	require.NoError(t, err)
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, "Write docs", decoded[0]["title"])
+	assert.Equal(t, "high", decoded[0]["priority"])
+	assert.Equal(t, "2026-11-01T00:00:00Z", decoded[0]["due_date"])
+	assert.Contains(t, decoded[0], "created_at")
+	assert.Nil(t, decoded[1]["due_date"])
+}
+
+func TestOutputOptions_WriteTasks_WithFields_ShouldKeepFieldOrder(t *testing.T) {
+	// Given
+	opts := outputOptions{format: "json", fields: []string{"title", "id"}}
+	buf := new(bytes.Buffer)
+
+	// When
+	err := opts.writeTasks(buf, newOutputTestTasks()[:1])
+
+	// Then
+	require.NoError(t, err)
+	compact := new(bytes.Buffer)
+	require.NoError(t, json.Compact(compact, buf.Bytes()))
+	assert.Equal(t, `[{"title":"Write docs","id":"11111111-aaaa-bbbb-cccc-000000000001"}]`, compact.String())
+}
+
+func TestOutputOptions_WriteTasks_WithUnknownField_ShouldReturnError(t *testing.T) {
+	// Given
+	opts := outputOptions{format: "csv", fields: []string{"nope"}}
+
+	// When
+	err := opts.writeTasks(new(bytes.Buffer), newOutputTestTasks())
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown field")
+}
+
+func TestOutputOptions_WriteTasks_YAML_ShouldBeParsable(t *testing.T) {
+	// Given
+	opts := outputOptions{format: "yaml", fields: []string{"id", "tags", "due_date"}}
+	buf := new(bytes.Buffer)
+
+	// When
+	err := opts.writeTasks(buf, newOutputTestTasks())
+
+	// Then
+	require.NoError(t, err)
+	var decoded []map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, []interface{}{"docs", "release"}, decoded[0]["tags"])
+	assert.Equal(t, "2026-11-01T00:00:00Z", decoded[0]["due_date"])
+	assert.True(t, strings.HasPrefix(buf.String(), "- id: 11111111"))
+}
+
+func TestOutputOptions_WriteTasks_CSV_ShouldQuoteValues(t *testing.T) {
+	// Given
+	opts := outputOptions{format: "csv", fields: []string{"id", "title", "tags"}}
+	buf := new(bytes.Buffer)
+
+	// When
+	err := opts.writeTasks(buf, newOutputTestTasks())
+
+	// Then
+	require.NoError(t, err)
+	rows, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"id", "title", "tags"}, rows[0])
+	assert.Equal(t, "docs,release", rows[1][2])
+	assert.Equal(t, `Fix, quote "bug"`, rows[2][1])
+}
+
+func TestOutputOptions_WriteTasks_Table_ShouldUseShortIDs(t *testing.T) {
+	// Given
+	opts := outputOptions{format: "table"}
+	buf := new(bytes.Buffer)
+
+	// When
+	err := opts.writeTasks(buf, newOutputTestTasks())
+
+	// Then
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "ID"))
+	assert.Contains(t, lines[1], "11111111 ")
+	assert.Contains(t, lines[1], "2026-11-01")
+	assert.NotContains(t, lines[1], "aaaa")
+}
+
+func TestShowCommand_WithJSONOutput_ShouldPrintObject(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Structured")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	output, err := executeCommand(t, dataDir, "show", task.ID, "-o", "json")
+
+	// Then
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+	assert.Equal(t, task.ID, decoded["id"])
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"task-cli/internal/repository"
+)
+
+// configFileName は設定ファイルのファイル名
+const configFileName = "config.json"
+
+// skipProfileAnnotation が "true" のコマンドは実行前にプロファイルを適用しない
+const skipProfileAnnotation = "task-cli/skip-profile"
+
+// Profile はデータディレクトリとその既定の設定に付けた名前
+type Profile struct {
+	Name    string `json:"name"`
+	DataDir string `json:"data_dir"`
+	Theme   string `json:"theme,omitempty"`   // 空の場合は --theme の既定値
+	Project string `json:"project,omitempty"` // 空の場合は全てのプロジェクト
+}
+
+// ProfileConfig は設定ファイルの形式
+type ProfileConfig struct {
+	Current  string    `json:"current,omitempty"` // --profile を指定しない場合に使うプロファイル
+	Profiles []Profile `json:"profiles"`
+}
+
+// defaultConfigFile はユーザーの設定ディレクトリにある設定ファイルのパスを返す
+func defaultConfigFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		homeDir, _ := os.UserHomeDir()
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "task-cli", configFileName)
+}
+
+// loadProfileConfig は設定ファイルを読み込む
+// ファイルが存在しない場合はプロファイルのない設定を返す
+func loadProfileConfig(path string) (*ProfileConfig, error) {
+	jsonData, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ProfileConfig{Profiles: []Profile{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config ProfileConfig
+	if err := json.Unmarshal(jsonData, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = []Profile{}
+	}
+	return &config, nil
+}
+
+// saveProfileConfig は設定ファイルを一時ファイル経由で原子的に書き込む
+func saveProfileConfig(path string, config *ProfileConfig) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := repository.WriteFileAtomic(path, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// Find は名前（大文字小文字は区別しない）でプロファイルを探す
+func (c *ProfileConfig) Find(name string) (*Profile, bool) {
+	for i := range c.Profiles {
+		if strings.EqualFold(c.Profiles[i].Name, name) {
+			return &c.Profiles[i], true
+		}
+	}
+	return nil, false
+}
+
+// Add はプロファイルを追加する（同じ名前のプロファイルがある場合はエラー）
+func (c *ProfileConfig) Add(profile Profile) error {
+	profile.Name = strings.TrimSpace(profile.Name)
+	if profile.Name == "" {
+		return errors.New("profile name cannot be empty")
+	}
+	if profile.DataDir == "" {
+		return errors.New("profile data directory cannot be empty")
+	}
+	if _, ok := c.Find(profile.Name); ok {
+		return fmt.Errorf("profile %q already exists", profile.Name)
+	}
+	if profile.Theme != "" {
+		if err := (&Config{Theme: profile.Theme}).Validate(); err != nil {
+			return err
+		}
+	}
+
+	c.Profiles = append(c.Profiles, profile)
+	return nil
+}
+
+// Remove はプロファイルを削除する（使用中のプロファイルの場合は使用も解除する）
+func (c *ProfileConfig) Remove(name string) (Profile, error) {
+	for i, profile := range c.Profiles {
+		if strings.EqualFold(profile.Name, name) {
+			c.Profiles = append(c.Profiles[:i], c.Profiles[i+1:]...)
+			if strings.EqualFold(c.Current, profile.Name) {
+				c.Current = ""
+			}
+			return profile, nil
+		}
+	}
+	return Profile{}, fmt.Errorf("profile %q not found", name)
+}
+
+// ResolveProfile は --profile で指定した、または使用中のプロファイルの設定を適用する
+// explicit が true を返すフラグはコマンドラインで指定されたものとし、プロファイルの値で上書きしない
+func (c *Config) ResolveProfile(explicit func(flag string) bool) error {
+	profileConfig, err := loadProfileConfig(c.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	name := c.Profile
+	if name == "" {
+		name = profileConfig.Current
+	}
+	if name == "" {
+		return nil
+	}
+
+	profile, ok := profileConfig.Find(name)
+	if !ok && c.Profile == "" {
+		return fmt.Errorf("profile %q in use is not in %s; run 'task-cli profile use' to choose another", name, c.ConfigFile)
+	}
+	if !ok {
+		return fmt.Errorf("profile %q not found in %s", name, c.ConfigFile)
+	}
+	c.Profile = profile.Name
+	if !explicit("data-dir") {
+		c.DataDir = profile.DataDir
+	}
+	if profile.Theme != "" && !explicit("theme") {
+		c.Theme = profile.Theme
+	}
+	if profile.Project != "" && !explicit("project") {
+		c.Project = profile.Project
+	}
+	return nil
+}
+
+// expandDataDir はデータディレクトリの先頭の ~ をホームディレクトリに置き換え、絶対パスにする
+func expandDataDir(dataDir string) (string, error) {
+	if dataDir == "~" || strings.HasPrefix(dataDir, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find home directory: %w", err)
+		}
+		dataDir = filepath.Join(homeDir, strings.TrimPrefix(dataDir, "~"))
+	}
+	return filepath.Abs(dataDir)
+}
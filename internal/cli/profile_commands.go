@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// profileListFields は profile list の出力列
+var profileListFields = []string{"name", "data_dir", "theme", "project", "current"}
+
+// profileCommandAnnotations はプロファイルを操作するコマンドの注釈
+// 使用中のプロファイルが壊れていても直せるよう、プロファイルの適用とタスクのデータの検査を行わない
+var profileCommandAnnotations = map[string]string{skipDataCheckAnnotation: "true", skipProfileAnnotation: "true"}
+
+// newProfileCommand は profile サブコマンド群を作成する
+func newProfileCommand(config *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "List, add, remove and switch profiles",
+		Long: `A profile names a data directory together with its own theme and default project.
+Profiles are stored in the config file (see --config).
+The profile selected with 'profile use' applies to every command; --profile overrides it for one command,
+and --data-dir, --theme and --project override the values of the profile.`,
+	}
+
+	cmd.AddCommand(
+		newProfileListCommand(config),
+		newProfileAddCommand(config),
+		newProfileUseCommand(config),
+		newProfileRmCommand(config),
+	)
+
+	return cmd
+}
+
+// newProfileListCommand は profile list サブコマンドを作成する
+func newProfileListCommand(config *Config) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Aliases:     []string{"ls"},
+		Short:       "List profiles",
+		Args:        cobra.NoArgs,
+		Annotations: profileCommandAnnotations,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := ParseOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			profileConfig, err := loadProfileConfig(config.ConfigFile)
+			if err != nil {
+				return err
+			}
+
+			current := config.Profile
+			if current == "" {
+				current = profileConfig.Current
+			}
+			set := &recordSet{fields: profileListFields, records: make([][]interface{}, 0, len(profileConfig.Profiles))}
+			for _, profile := range profileConfig.Profiles {
+				set.records = append(set.records, []interface{}{
+					profile.Name, profile.DataDir, profile.Theme, profile.Project, strings.EqualFold(profile.Name, current),
+				})
+			}
+			return set.write(cmd.OutOrStdout(), outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "output", "o", string(OutputTable), "Output format (table, json, yaml, csv)")
+
+	return cmd
+}
+
+// newProfileAddCommand は profile add サブコマンドを作成する
+func newProfileAddCommand(config *Config) *cobra.Command {
+	var profile Profile
+
+	cmd := &cobra.Command{
+		Use:         "add <name> <data-dir>",
+		Short:       "Add a profile for a data directory",
+		Example:     `  task-cli profile add work ~/work-tasks --theme dark --project backend`,
+		Args:        cobra.ExactArgs(2),
+		Annotations: profileCommandAnnotations,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dataDir, err := expandDataDir(args[1])
+			if err != nil {
+				return err
+			}
+			profile.Name, profile.DataDir = args[0], dataDir
+
+			profileConfig, err := loadProfileConfig(config.ConfigFile)
+			if err != nil {
+				return err
+			}
+			if err := profileConfig.Add(profile); err != nil {
+				return err
+			}
+			if err := saveProfileConfig(config.ConfigFile, profileConfig); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Added profile %q for %s\n", profile.Name, profile.DataDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&profile.Theme, "theme", "", "Theme used with the profile (default, dark, light)")
+	cmd.Flags().StringVar(&profile.Project, "project", "", "Project selected by default with the profile")
+
+	return cmd
+}
+
+// newProfileUseCommand は profile use サブコマンドを作成する
+func newProfileUseCommand(config *Config) *cobra.Command {
+	var none bool
+
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Use a profile for every following command",
+		Example: `  task-cli profile use work
+  task-cli profile use --none   # go back to --data-dir and the built-in defaults`,
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: profileCommandAnnotations,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if none == (len(args) == 1) {
+				return errors.New("specify either a profile name or --none")
+			}
+
+			profileConfig, err := loadProfileConfig(config.ConfigFile)
+			if err != nil {
+				return err
+			}
+			profileConfig.Current = ""
+			if !none {
+				profile, ok := profileConfig.Find(args[0])
+				if !ok {
+					return fmt.Errorf("profile %q not found", args[0])
+				}
+				profileConfig.Current = profile.Name
+			}
+			if err := saveProfileConfig(config.ConfigFile, profileConfig); err != nil {
+				return err
+			}
+
+			if none {
+				fmt.Fprintln(cmd.OutOrStdout(), "No profile is in use")
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Using profile %q\n", profileConfig.Current)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&none, "none", false, "Stop using a profile")
+
+	return cmd
+}
+
+// newProfileRmCommand は profile rm サブコマンドを作成する
+func newProfileRmCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:         "rm <name>",
+		Aliases:     []string{"delete"},
+		Short:       "Remove a profile (its data directory is kept)",
+		Args:        cobra.ExactArgs(1),
+		Annotations: profileCommandAnnotations,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profileConfig, err := loadProfileConfig(config.ConfigFile)
+			if err != nil {
+				return err
+			}
+			profile, err := profileConfig.Remove(args[0])
+			if err != nil {
+				return err
+			}
+			if err := saveProfileConfig(config.ConfigFile, profileConfig); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed profile %q; %s was kept\n", profile.Name, profile.DataDir)
+			return nil
+		},
+	}
+}
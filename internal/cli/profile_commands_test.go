@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeWithConfig は設定ファイルを指定してコマンドを実行し、出力を返す（--data-dir は指定しない）
+func executeWithConfig(t *testing.T, configFile string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := NewRootCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(append([]string{"--config", configFile}, args...))
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestProfileCommands_ShouldSwitchDataDirectory(t *testing.T) {
+	// Given
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	workDir, personalDir := t.TempDir(), t.TempDir()
+	out, err := executeWithConfig(t, configFile, "profile", "add", "work", workDir)
+	require.NoError(t, err)
+	assert.Equal(t, "Added profile \"work\" for "+workDir+"\n", out)
+	_, err = executeWithConfig(t, configFile, "profile", "add", "personal", personalDir, "--theme", "dark")
+	require.NoError(t, err)
+
+	// When
+	out, err = executeWithConfig(t, configFile, "profile", "use", "work")
+	require.NoError(t, err)
+	assert.Equal(t, "Using profile \"work\"\n", out)
+	_, err = executeWithConfig(t, configFile, "add", "Write report")
+	require.NoError(t, err)
+	_, err = executeWithConfig(t, configFile, "--profile", "personal", "add", "Groceries")
+	require.NoError(t, err)
+
+	// Then - 使用中のプロファイルと --profile で指定したプロファイルのデータディレクトリに保存される
+	work := loadTasks(t, workDir)
+	require.Len(t, work, 1)
+	assert.Equal(t, "Write report", work[0].Title)
+	personal := loadTasks(t, personalDir)
+	require.Len(t, personal, 1)
+	assert.Equal(t, "Groceries", personal[0].Title)
+
+	out, err = executeWithConfig(t, configFile, "profile", "list", "-o", "json")
+	require.NoError(t, err)
+	var profiles []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &profiles))
+	require.Len(t, profiles, 2)
+	assert.Equal(t, true, profiles[0]["current"])
+	assert.Equal(t, "dark", profiles[1]["theme"])
+	assert.Equal(t, false, profiles[1]["current"])
+}
+
+func TestProfileCommands_WithUnknownProfile_ShouldFail(t *testing.T) {
+	// Given
+	configFile := filepath.Join(t.TempDir(), "config.json")
+
+	// When
+	_, useErr := executeWithConfig(t, configFile, "profile", "use", "nope")
+	_, flagErr := executeWithConfig(t, configFile, "--profile", "nope", "list")
+
+	// Then
+	assert.ErrorContains(t, useErr, `profile "nope" not found`)
+	assert.ErrorContains(t, flagErr, `profile "nope" not found`)
+}
+
+func TestProfileCommands_AddDuplicate_ShouldFail(t *testing.T) {
+	// Given
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	_, err := executeWithConfig(t, configFile, "profile", "add", "work", t.TempDir())
+	require.NoError(t, err)
+
+	// When
+	_, err = executeWithConfig(t, configFile, "profile", "add", "Work", t.TempDir())
+
+	// Then
+	assert.ErrorContains(t, err, `profile "Work" already exists`)
+}
+
+func TestProfileRmCommand_ShouldStopUsingRemovedProfile(t *testing.T) {
+	// Given
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	workDir := t.TempDir()
+	_, err := executeWithConfig(t, configFile, "profile", "add", "work", workDir)
+	require.NoError(t, err)
+	_, err = executeWithConfig(t, configFile, "profile", "use", "work")
+	require.NoError(t, err)
+
+	// When
+	out, err := executeWithConfig(t, configFile, "profile", "rm", "work")
+
+	// Then - データディレクトリは残し、使用中のプロファイルも解除する
+	require.NoError(t, err)
+	assert.Equal(t, "Removed profile \"work\"; "+workDir+" was kept\n", out)
+	assert.DirExists(t, workDir)
+	profileConfig, err := loadProfileConfig(configFile)
+	require.NoError(t, err)
+	assert.Empty(t, profileConfig.Current)
+	assert.Empty(t, profileConfig.Profiles)
+}
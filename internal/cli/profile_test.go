@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProfileConfig はテスト用の設定ファイルを書き込み、そのパスを返す
+func writeProfileConfig(t *testing.T, profileConfig *ProfileConfig) string {
+	t.Helper()
+
+	configFile := filepath.Join(t.TempDir(), "task-cli", configFileName)
+	require.NoError(t, saveProfileConfig(configFile, profileConfig))
+	return configFile
+}
+
+func TestSaveProfileConfig_ShouldReplaceFileWithoutLeavingTempFiles(t *testing.T) {
+	// Given
+	configFile := writeProfileConfig(t, &ProfileConfig{Profiles: []Profile{{Name: "Home", DataDir: "/data/home"}}})
+
+	// When
+	err := saveProfileConfig(configFile, &ProfileConfig{Current: "home", Profiles: []Profile{{Name: "Home", DataDir: "/data/home"}}})
+
+	// Then
+	require.NoError(t, err)
+	loaded, err := loadProfileConfig(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "home", loaded.Current)
+	entries, err := os.ReadDir(filepath.Dir(configFile))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, configFileName, entries[0].Name())
+}
+
+func TestConfig_ResolveProfile_ShouldApplyCurrentProfile(t *testing.T) {
+	// Given
+	config := NewConfig()
+	config.ConfigFile = writeProfileConfig(t, &ProfileConfig{
+		Current:  "work",
+		Profiles: []Profile{{Name: "Work", DataDir: "/data/work", Theme: "light", Project: "backend"}},
+	})
+
+	// When
+	err := config.ResolveProfile(func(string) bool { return false })
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "Work", config.Profile)
+	assert.Equal(t, "/data/work", config.DataDir)
+	assert.Equal(t, "light", config.Theme)
+	assert.Equal(t, "backend", config.Project)
+}
+
+func TestConfig_ResolveProfile_WithExplicitFlags_ShouldKeepFlagValues(t *testing.T) {
+	// Given
+	config := NewConfig()
+	config.ConfigFile = writeProfileConfig(t, &ProfileConfig{
+		Profiles: []Profile{{Name: "work", DataDir: "/data/work", Theme: "light"}},
+	})
+	config.Profile = "work"
+	config.DataDir = "/elsewhere"
+
+	// When
+	err := config.ResolveProfile(func(flag string) bool { return flag == "data-dir" })
+
+	// Then - コマンドラインで指定した値はプロファイルより優先する
+	require.NoError(t, err)
+	assert.Equal(t, "/elsewhere", config.DataDir)
+	assert.Equal(t, "light", config.Theme)
+}
+
+func TestConfig_ResolveProfile_WithoutConfigFile_ShouldKeepDefaults(t *testing.T) {
+	// Given
+	config := NewConfig()
+	config.ConfigFile = filepath.Join(t.TempDir(), configFileName)
+	dataDir := config.DataDir
+
+	// When
+	err := config.ResolveProfile(func(string) bool { return false })
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, dataDir, config.DataDir)
+	assert.Empty(t, config.Profile)
+}
+
+func TestConfig_ResolveProfile_WithRemovedCurrentProfile_ShouldSuggestProfileUse(t *testing.T) {
+	// Given
+	config := NewConfig()
+	config.ConfigFile = writeProfileConfig(t, &ProfileConfig{Current: "gone", Profiles: []Profile{}})
+
+	// When
+	err := config.ResolveProfile(func(string) bool { return false })
+
+	// Then
+	assert.ErrorContains(t, err, "task-cli profile use")
+}
+
+func TestProfileConfig_Add_WithInvalidTheme_ShouldFail(t *testing.T) {
+	// Given
+	profileConfig := &ProfileConfig{}
+
+	// When
+	err := profileConfig.Add(Profile{Name: "work", DataDir: "/data/work", Theme: "neon"})
+
+	// Then
+	assert.ErrorContains(t, err, "invalid theme")
+	assert.Empty(t, profileConfig.Profiles)
+}
+
+func TestExpandDataDir_ShouldExpandHomeDirectory(t *testing.T) {
+	// Given
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	// When
+	dataDir, err := expandDataDir("~/work-tasks")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(homeDir, "work-tasks"), dataDir)
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"task-cli/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// projectListFields は project list の出力列
+var projectListFields = []string{"name", "color", "default_tags", "tasks", "todo", "in_progress", "completed", "overdue", "id"}
+
+// newProjectCommand は project サブコマンド群を作成する
+func newProjectCommand(config *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "List, create, delete projects and move tasks between them",
+		Long: `Projects keep separate task lists in one data directory.
+Tasks created before projects existed belong to the "default" project.
+Use --project <name> with any command to work in a single project.`,
+	}
+
+	cmd.AddCommand(
+		newProjectListCommand(config),
+		newProjectAddCommand(config),
+		newProjectMoveCommand(config),
+		newProjectRmCommand(config),
+	)
+
+	return cmd
+}
+
+// newProjectListCommand は project list サブコマンドを作成する
+func newProjectListCommand(config *Config) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List projects with task statistics",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := ParseOutputFormat(format)
+			if err != nil {
+				return err
+			}
+
+			taskService := newTaskService(config)
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			stats, err := taskService.GetProjectStats(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			set := &recordSet{fields: projectListFields, records: make([][]interface{}, 0, len(stats))}
+			for _, stat := range stats {
+				if projectID != "" && stat.Project.ID != projectID {
+					continue
+				}
+				set.records = append(set.records, []interface{}{
+					stat.Project.Name, stat.Project.Color, stat.Project.DefaultTags,
+					stat.Total, stat.Todo, stat.InProgress, stat.Completed, stat.Overdue, stat.Project.ID,
+				})
+			}
+			return set.write(cmd.OutOrStdout(), outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "output", "o", string(OutputTable), "Output format (table, json, yaml, csv)")
+
+	return cmd
+}
+
+// newProjectAddCommand は project add サブコマンドを作成する
+func newProjectAddCommand(config *Config) *cobra.Command {
+	var (
+		color string
+		tags  []string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "add <name>",
+		Short:   "Create a project",
+		Example: `  task-cli project add Work --color blue --tag work`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, err := newTaskService(config).CreateProject(cmd.Context(), service.CreateProjectRequest{
+				Name:        strings.Join(args, " "),
+				Color:       color,
+				DefaultTags: tags,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created project %q\n", project.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&color, "color", "", "Color of the project in the TUI (a color name or #rrggbb)")
+	cmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "Tags added to every task created in the project (comma separated)")
+
+	return cmd
+}
+
+// newProjectMoveCommand は project move サブコマンドを作成する
+func newProjectMoveCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "move <project> <id>...",
+		Short: "Move tasks and their subtasks to another project",
+		Long: `Move tasks and their subtasks to another project.
+A subtask moved without its parent is detached from the parent.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskService := newTaskService(config)
+			target, err := taskService.FindProject(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			ids, err := resolveTaskIDs(cmd.Context(), taskService, projectID, args[1:])
+			if err != nil {
+				return err
+			}
+
+			moved, err := taskService.MoveTasks(cmd.Context(), target.ID, ids...)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Moved %d tasks to %s\n", len(moved), target.Name)
+			return nil
+		},
+	}
+}
+
+// newProjectRmCommand は project rm サブコマンドを作成する
+func newProjectRmCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm <name>",
+		Aliases: []string{"delete"},
+		Short:   "Delete an empty project",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskService := newTaskService(config)
+			project, err := taskService.FindProject(cmd.Context(), strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			if err := taskService.DeleteProject(cmd.Context(), project.ID); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted project %q\n", project.Name)
+			return nil
+		},
+	}
+}
+
+// selectedProjectID は --project で指定したプロジェクトのIDを返す（指定がない場合は空文字列）
+func selectedProjectID(ctx context.Context, taskService *service.TaskService, config *Config) (string, error) {
+	if config.Project == "" {
+		return "", nil
+	}
+
+	project, err := taskService.FindProject(ctx, config.Project)
+	if err != nil {
+		return "", err
+	}
+	return project.ID, nil
+}
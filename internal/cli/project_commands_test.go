@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectCommands_ShouldScopeTasksToProject(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	out, err := executeCommand(t, dataDir, "project", "add", "Work", "--color", "blue", "--tag", "job")
+	require.NoError(t, err)
+	assert.Equal(t, "Created project \"Work\"\n", out)
+	_, err = executeCommand(t, dataDir, "add", "Groceries")
+	require.NoError(t, err)
+
+	// When
+	_, err = executeCommand(t, dataDir, "--project", "work", "add", "Write report")
+	require.NoError(t, err)
+	out, err = executeCommand(t, dataDir, "--project", "Work", "list", "-o", "json")
+
+	// Then - 指定したプロジェクトのタスクだけが表示され、既定のタグが付く
+	require.NoError(t, err)
+	var tasks []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &tasks))
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Write report", tasks[0]["title"])
+	assert.Equal(t, []interface{}{"job"}, tasks[0]["tags"])
+
+	out, err = executeCommand(t, dataDir, "list", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(out), &tasks))
+	assert.Len(t, tasks, 2)
+}
+
+func TestProjectCommands_WithUnknownProject_ShouldFail(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Groceries")
+	require.NoError(t, err)
+
+	// When
+	_, err = executeCommand(t, dataDir, "--project", "nope", "list")
+
+	// Then
+	assert.ErrorContains(t, err, `project "nope" not found`)
+}
+
+func TestProjectListCommand_ShouldShowStatistics(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "project", "add", "Work")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "--project", "Work", "add", "First")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "--project", "Work", "add", "Second", "--due", "yesterday")
+	require.NoError(t, err)
+	id := loadTasks(t, dataDir)[0].ID
+	_, err = executeCommand(t, dataDir, "done", id)
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "project", "list", "-o", "json")
+
+	// Then
+	require.NoError(t, err)
+	var projects []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &projects))
+	require.Len(t, projects, 2)
+	assert.Equal(t, model.DefaultProjectName, projects[0]["name"])
+	assert.Equal(t, float64(0), projects[0]["tasks"])
+	assert.Equal(t, "Work", projects[1]["name"])
+	assert.Equal(t, float64(2), projects[1]["tasks"])
+	assert.Equal(t, float64(1), projects[1]["completed"])
+	assert.Equal(t, float64(1), projects[1]["overdue"])
+}
+
+func TestProjectMoveCommand_ShouldMoveTasks(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "project", "add", "Work")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Report")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	out, err := executeCommand(t, dataDir, "project", "move", "Work", task.ID[:8])
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "Moved 1 tasks to Work\n", out)
+	moved := loadTasks(t, dataDir)[0]
+	assert.NotEqual(t, model.DefaultProjectID, moved.ProjectID)
+
+	// 空でないプロジェクトは削除できない
+	_, err = executeCommand(t, dataDir, "project", "rm", "Work")
+	assert.ErrorContains(t, err, "still has 1 tasks")
+	_, err = executeCommand(t, dataDir, "project", "move", "default", task.ID)
+	require.NoError(t, err)
+	out, err = executeCommand(t, dataDir, "project", "rm", "Work")
+	require.NoError(t, err)
+	assert.Equal(t, "Deleted project \"Work\"\n", out)
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"task-cli/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// skipDataCheckAnnotation が "true" のコマンドは実行前のデータ検査を行わない
+const skipDataCheckAnnotation = "task-cli/skip-data-check"
+
+// checkDataFile はコマンド実行前にデータファイルを読み込めるか確認する
+// 破損している場合は退避先を知らせ、最新のバックアップからの復元を提案する
+func checkDataFile(cmd *cobra.Command, config *Config) error {
+	if cmd.Annotations[skipDataCheckAnnotation] == "true" {
+		return nil
+	}
+
+	taskService := newTaskService(config)
+	_, err := taskService.GetAllTasks(cmd.Context())
+	if err == nil || !errors.Is(err, repository.ErrCorrupt) {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+
+	backups, listErr := taskService.ListBackups(cmd.Context())
+	if listErr != nil || len(backups) == 0 {
+		return fmt.Errorf("tasks.json could not be read and no backup is available to restore from")
+	}
+	latest := backups[0]
+
+	if !isInteractive(cmd.InOrStdin()) {
+		return fmt.Errorf("tasks.json could not be read; rerun in a terminal to restore from backup %s", latest.Name)
+	}
+
+	question := fmt.Sprintf("Restore from the newest backup %s (%s)?",
+		latest.Name, latest.CreatedAt.Format("2006-01-02 15:04:05"))
+	ok, promptErr := confirm(cmd, question)
+	if promptErr != nil {
+		return promptErr
+	}
+	if !ok {
+		return fmt.Errorf("tasks.json could not be read; it was left untouched")
+	}
+
+	restored, err := taskService.RestoreLatestBackup(cmd.Context())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Restored data from %s\n", restored.Name)
+	return nil
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/model"
+	"task-cli/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prepareCorruptDataDir はバックアップを1つ持ち、tasks.jsonが破損したデータディレクトリを作成する
+func prepareCorruptDataDir(t *testing.T) string {
+	t.Helper()
+
+	dataDir := t.TempDir()
+	appData := model.NewAppData()
+	task, _ := model.NewTask("Backed up task", "", model.PriorityMedium, nil)
+	appData.AddTask(task)
+	_, err := repository.NewFileRepository(dataDir).CreateBackup(context.Background(), appData)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "tasks.json"), []byte(`{"tasks": [`), 0644))
+	return dataDir
+}
+
+func TestCheckDataFile_WithCorruptFileAndConfirmation_ShouldRestoreNewestBackup(t *testing.T) {
+	// Given
+	dataDir := prepareCorruptDataDir(t)
+
+	// When
+	output, err := executeCommandWithInput(t, dataDir, "y\n", "list")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "Restored data from tasks_backup_")
+	assert.Contains(t, output, "Backed up task")
+
+	quarantined, _ := filepath.Glob(filepath.Join(dataDir, "quarantine", "tasks_corrupt_*.json"))
+	assert.Len(t, quarantined, 1)
+}
+
+func TestCheckDataFile_WithCorruptFileAndRefusal_ShouldLeaveFileUntouched(t *testing.T) {
+	// Given
+	dataDir := prepareCorruptDataDir(t)
+
+	// When
+	_, err := executeCommandWithInput(t, dataDir, "n\n", "add", "New task")
+
+	// Then
+	assert.Error(t, err)
+	current, _ := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	assert.Equal(t, `{"tasks": [`, string(current))
+}
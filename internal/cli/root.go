@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"task-cli/internal/repository"
 	"task-cli/internal/service"
@@ -16,8 +18,18 @@ import (
 
 // Config はアプリケーションの設定
 type Config struct {
-	DataDir string
-	Theme   string
+	DataDir     string
+	Theme       string
+	LockTimeout time.Duration
+	Retention   repository.RetentionPolicy
+
+	// Project は操作の対象にするプロジェクトの名前またはID（空の場合は全てのプロジェクト）
+	Project string
+
+	// ConfigFile はプロファイルを保存する設定ファイルのパス
+	ConfigFile string
+	// Profile は使用するプロファイルの名前（空の場合は設定ファイルで使用中のプロファイル）
+	Profile string
 }
 
 // NewConfig は新しい設定を作成する
@@ -26,8 +38,11 @@ func NewConfig() *Config {
 	defaultDataDir := filepath.Join(homeDir, ".task-cli")
 	
 	return &Config{
-		DataDir: defaultDataDir,
-		Theme:   "default",
+		DataDir:     defaultDataDir,
+		Theme:       "default",
+		LockTimeout: 5 * time.Second,
+		Retention:   repository.DefaultRetentionPolicy,
+		ConfigFile:  defaultConfigFile(),
 	}
 }
 
@@ -68,49 +83,68 @@ var (
 // NewRootCommand は新しいルートコマンドを作成する
 func NewRootCommand() *cobra.Command {
 	// 各テスト用に新しい設定を作成
-	config := NewConfig()
-	
-	rootCmd := &cobra.Command{
-		Use:   "task-cli",
-		Short: "Task management TUI application",
-		Long: `A terminal-based task management application with a text user interface.
-Manage your tasks efficiently with keyboard shortcuts and a clean interface.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAppWithConfig(config)
-		},
-		Version: "1.0.0",
-	}
-
-	// フラグを設定
-	rootCmd.PersistentFlags().StringVar(&config.DataDir, "data-dir", config.DataDir,
-		"Directory to store task data")
-	rootCmd.PersistentFlags().StringVar(&config.Theme, "theme", config.Theme,
-		"Theme to use (default, dark, light)")
-
-	return rootCmd
+	return newRootCommand(NewConfig())
 }
 
 // Execute はルートコマンドを実行する
 func Execute() error {
 	// メイン実行用は単一の設定を使用
+	return newRootCommand(globalConfig).Execute()
+}
+
+// newRootCommand は指定された設定にフラグとサブコマンドを結び付けたルートコマンドを作成する
+func newRootCommand(config *Config) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "task-cli",
 		Short: "Task management TUI application",
 		Long: `A terminal-based task management application with a text user interface.
 Manage your tasks efficiently with keyboard shortcuts and a clean interface.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// リポジトリを作成する前に、プロファイルのデータディレクトリと既定値を適用する
+			if cmd.Annotations[skipProfileAnnotation] != "true" {
+				if err := config.ResolveProfile(cmd.Flags().Changed); err != nil {
+					return err
+				}
+			}
+			return checkDataFile(cmd, config)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAppWithConfig(globalConfig)
+			return runAppWithConfig(config)
 		},
-		Version: "1.0.0",
+		Version:       "1.0.0",
+		SilenceUsage:  true,
+		SilenceErrors: true,
 	}
 
 	// フラグを設定
-	rootCmd.PersistentFlags().StringVar(&globalConfig.DataDir, "data-dir", globalConfig.DataDir,
+	rootCmd.PersistentFlags().StringVar(&config.DataDir, "data-dir", config.DataDir,
 		"Directory to store task data")
-	rootCmd.PersistentFlags().StringVar(&globalConfig.Theme, "theme", globalConfig.Theme,
+	rootCmd.PersistentFlags().StringVar(&config.Profile, "profile", config.Profile,
+		"Profile to use instead of the one selected with 'task-cli profile use'")
+	rootCmd.PersistentFlags().StringVar(&config.ConfigFile, "config", config.ConfigFile,
+		"Config file that stores the profiles")
+	rootCmd.PersistentFlags().StringVar(&config.Theme, "theme", config.Theme,
 		"Theme to use (default, dark, light)")
+	rootCmd.PersistentFlags().DurationVar(&config.LockTimeout, "lock-timeout", config.LockTimeout,
+		"How long to wait for another task-cli process to release the data directory")
+	rootCmd.PersistentFlags().StringVar(&config.Project, "project", config.Project,
+		"Project to work in (name or id); new tasks go to the default project and other commands cover all projects when omitted")
+	rootCmd.PersistentFlags().IntVar(&config.Retention.KeepLast, "keep-backups", config.Retention.KeepLast,
+		"Number of most recent backups to keep")
+	rootCmd.PersistentFlags().IntVar(&config.Retention.KeepDaily, "keep-daily-backups", config.Retention.KeepDaily,
+		"Number of days for which the newest backup of each day is kept")
+	rootCmd.PersistentFlags().IntVar(&config.Retention.KeepWeekly, "keep-weekly-backups", config.Retention.KeepWeekly,
+		"Number of weeks for which the newest backup of each week is kept")
+
+	// 非対話型のサブコマンドを登録
+	addTaskCommands(rootCmd, config)
+	rootCmd.AddCommand(newBackupCommand(config))
+	rootCmd.AddCommand(newViewCommand(config))
+	rootCmd.AddCommand(newUndoCommand(config), newRedoCommand(config))
+	rootCmd.AddCommand(newProjectCommand(config))
+	rootCmd.AddCommand(newProfileCommand(config))
 
-	return rootCmd.Execute()
+	return rootCmd
 }
 
 // runAppWithConfig は指定された設定でアプリケーションを実行する
@@ -121,9 +155,7 @@ func runAppWithConfig(config *Config) error {
 	}
 
 	// コンポーネントを初期化
-	repo := repository.NewFileRepository(config.DataDir)
-	validator := validator.New()
-	taskService := service.NewTaskService(repo, validator)
+	taskService := newTaskService(config)
 	stateManager := service.NewStateManager()
 	
 	// テーマを作成
@@ -134,16 +166,73 @@ func runAppWithConfig(config *Config) error {
 
 	// UIアプリケーションを作成
 	app := ui.NewApp(taskService, stateManager, theme)
+	// 操作はイベントループから呼ぶため、警告はそのままトーストで表示できる
+	taskService.SetWarningHandler(app.ShowWarning)
 
 	// アプリケーションを初期化
 	if err := app.Initialize(); err != nil {
 		return fmt.Errorf("failed to initialize application: %w", err)
 	}
 
+	// 保存したビューを数字キーに割り当てる
+	views, err := newViewService(config).ListViews(context.Background())
+	if err != nil {
+		return err
+	}
+	app.SetViews(views)
+
+	// --project を指定した場合はそのプロジェクトを選択した状態で開く
+	if config.Project != "" {
+		project, err := taskService.FindProject(context.Background(), config.Project)
+		if err != nil {
+			return err
+		}
+		app.SelectProject(project)
+	}
+
+	// TUIで表示したエラーをデータディレクトリのログにも残す
+	errorLog, err := openErrorLog(config.DataDir)
+	if err != nil {
+		return err
+	}
+	defer errorLog.Close()
+	app.SetErrorLog(errorLog)
+
 	// アプリケーションを実行
 	return app.Run()
 }
 
+// errorLogFileName はTUIのエラーログのファイル名
+const errorLogFileName = "errors.log"
+
+// openErrorLog はデータディレクトリのエラーログを追記用に開く
+func openErrorLog(dataDir string) (*os.File, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(dataDir, errorLogFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open error log: %w", err)
+	}
+	return file, nil
+}
+
+// newTaskService は設定に基づいてTaskServiceを作成する
+// タスクの変更はジャーナルに追記し、tasks.json はスナップショットとして使用する
+func newTaskService(config *Config) *service.TaskService {
+	repo := repository.NewJournalRepository(config.DataDir,
+		repository.WithLockTimeout(config.LockTimeout),
+		repository.WithRetentionPolicy(config.Retention),
+	)
+	taskService := service.NewTaskService(repo, validator.New())
+	// 保存した後の付随する処理の失敗は、操作を失敗にせず警告として表示する
+	taskService.SetWarningHandler(func(err error) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	})
+	return taskService
+}
+
 // createTheme は指定されたテーマ名からテーマを作成する
 func createTheme(themeName string) (*ui.Theme, error) {
 	switch themeName {
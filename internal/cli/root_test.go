@@ -2,9 +2,12 @@ package cli
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // RED: CLIコマンドのテスト
@@ -180,4 +183,23 @@ func TestConfig_Validate_WithInvalidTheme_ShouldReturnError(t *testing.T) {
 	// Then
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid theme")
-}
\ No newline at end of file
+}
+
+func TestOpenErrorLog_ShouldCreateDataDirAndAppend(t *testing.T) {
+	// Given
+	dataDir := filepath.Join(t.TempDir(), "data")
+
+	// When - 2回開いて書き込む
+	for _, line := range []string{"first\n", "second\n"} {
+		file, err := openErrorLog(dataDir)
+		require.NoError(t, err)
+		_, err = file.WriteString(line)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+
+	// Then - 追記されている
+	content, err := os.ReadFile(filepath.Join(dataDir, errorLogFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(content))
+}
@@ -0,0 +1,712 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"task-cli/internal/dateparse"
+	"task-cli/internal/model"
+	"task-cli/internal/query"
+	"task-cli/internal/quickadd"
+	"task-cli/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// shortIDLength は一覧表示で使用する短縮IDの長さ
+const shortIDLength = 8
+
+// dueDateLayout は期限日を表示する日付フォーマット
+const dueDateLayout = dateparse.Layout
+
+// addTaskCommands はタスク操作用の非対話型サブコマンドを登録する
+func addTaskCommands(rootCmd *cobra.Command, config *Config) {
+	rootCmd.AddCommand(
+		newAddCommand(config),
+		newListCommand(config),
+		newShowCommand(config),
+		newDoneCommand(config),
+		newEditCommand(config),
+		newRmCommand(config),
+		newDepsCommand(config),
+		newLogCommand(config),
+	)
+}
+
+// newAddCommand は add サブコマンドを作成する
+// タイトルは quickadd の構文で解釈し、フラグで指定した値はタイトル中の指定より優先する
+func newAddCommand(config *Config) *cobra.Command {
+	var (
+		description string
+		priority    string
+		tags        []string
+		due         string
+		parent      string
+		blockedBy   []string
+		repeat      string
+		estimate    string
+		quick       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <title>",
+		Short: "Create a new task",
+		Long: "Create a new task. The arguments are used as the title as they are.\n" +
+			"With --quick, the title may contain inline attributes:\n  " + quickadd.Help + "\n\n" +
+			"Flags take precedence over inline attributes; --tag adds to the inline tags.\n" +
+			"Quote the whole line so that the shell does not treat # as a comment or expand ! and @.",
+		Example: `  task-cli add "Reply to #42" --priority high
+  task-cli add --quick 'Fix login bug #auth #backend !high due:fri @work est:2h'`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request, err := parseAddLine(strings.Join(args, " "), quick)
+			if err != nil {
+				return err
+			}
+
+			flags := cmd.Flags()
+			request.Description = description
+			request.Tags = append(request.Tags, tags...)
+			if flags.Changed("priority") {
+				if request.Priority, err = model.ParsePriority(priority); err != nil {
+					return err
+				}
+			}
+			if flags.Changed("due") {
+				if request.DueDate, err = parseDueDate(due); err != nil {
+					return err
+				}
+			}
+			if flags.Changed("repeat") {
+				if request.Recurrence, err = parseRepeat(repeat); err != nil {
+					return err
+				}
+			}
+			if flags.Changed("estimate") {
+				if request.Estimate, err = model.ParseEstimate(estimate); err != nil {
+					return err
+				}
+			}
+
+			taskService := newTaskService(config)
+			if request.ProjectID, err = selectedProjectID(cmd.Context(), taskService, config); err != nil {
+				return err
+			}
+			if parent != "" {
+				parentTask, err := resolveTask(cmd.Context(), taskService, request.ProjectID, parent)
+				if err != nil {
+					return err
+				}
+				request.ParentID = parentTask.ID
+			}
+			// ブロックするタスクは他のプロジェクトのタスクでもよい
+			if request.BlockedBy, err = resolveTaskIDs(cmd.Context(), taskService, "", blockedBy); err != nil {
+				return err
+			}
+
+			task, err := taskService.CreateTask(cmd.Context(), request)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created task %s: %s\n", shortID(task.ID), task.Title)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Task description")
+	cmd.Flags().StringVarP(&priority, "priority", "p", string(model.PriorityMedium), "Priority (low, medium, high)")
+	cmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "Comma separated tags")
+	cmd.Flags().StringVar(&due, "due", "", "Due date ("+dateparse.Help+")")
+	cmd.Flags().StringVar(&parent, "parent", "", "Create the task as a subtask of this task")
+	cmd.Flags().StringSliceVar(&blockedBy, "blocked-by", nil, "Tasks that must be completed first (comma separated ids)")
+	cmd.Flags().StringVar(&repeat, "repeat", "", `Recurrence rule, e.g. "weekly", "every 2 weeks on mon,fri", "every 10 days after completion" or "FREQ=MONTHLY;INTERVAL=3"`)
+	cmd.Flags().StringVar(&estimate, "estimate", "", "Estimated effort, e.g. 30m, 2h or 1h30m")
+	cmd.Flags().BoolVarP(&quick, "quick", "q", false, "Parse inline attributes such as #tag, !high and due:fri in the title")
+
+	return cmd
+}
+
+// parseAddLine は add コマンドの引数をタスク作成リクエストに変換する
+// quick が false の場合は、スクリプトから渡したタイトルの意味が変わらないよう引数をそのままタイトルにする
+func parseAddLine(line string, quick bool) (service.CreateTaskRequest, error) {
+	if !quick {
+		return service.CreateTaskRequest{Title: line, Priority: model.PriorityMedium}, nil
+	}
+
+	request, err := quickadd.Parse(line, time.Now())
+	if err != nil {
+		return service.CreateTaskRequest{}, fmt.Errorf("could not parse the task:\n%w\n(omit --quick to keep the text as the title)", err)
+	}
+	return request, nil
+}
+
+// newListCommand は list サブコマンドを作成する
+func newListCommand(config *Config) *cobra.Command {
+	var (
+		status   string
+		priority string
+		search   string
+		where    string
+		output   outputOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List tasks",
+		Long:    "List tasks.\n\n--where query syntax: " + query.Help + ".",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := service.TaskFilter{Query: search}
+			if status != "" {
+				parsed, err := model.ParseStatus(status)
+				if err != nil {
+					return err
+				}
+				filter.Status = &parsed
+			}
+			if priority != "" {
+				parsed, err := model.ParsePriority(priority)
+				if err != nil {
+					return err
+				}
+				filter.Priority = &parsed
+			}
+			if where != "" {
+				parsed, err := parseWhere(where)
+				if err != nil {
+					return err
+				}
+				filter.Where = parsed
+			}
+
+			taskService := newTaskService(config)
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			filter.ProjectID = projectID
+
+			tasks, err := taskService.GetAllTasks(cmd.Context())
+			if err != nil {
+				return err
+			}
+			tasks = service.NewStateManager().ApplyFilter(tasks, filter)
+
+			return output.writeTasks(cmd.OutOrStdout(), tasks)
+		},
+	}
+
+	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status (todo, in_progress, completed)")
+	cmd.Flags().StringVarP(&priority, "priority", "p", "", "Filter by priority (low, medium, high)")
+	cmd.Flags().StringVarP(&search, "query", "q", "", "Filter by text in title or description")
+	cmd.Flags().StringVarP(&where, "where", "w", "", `Filter with a query, e.g. 'status:todo,in_progress priority>=medium tag:backend -tag:blocked due<7d "exact phrase"' (see "list --help")`)
+	addOutputFlags(cmd, &output)
+
+	return cmd
+}
+
+// newShowCommand は show サブコマンドを作成する
+func newShowCommand(config *Config) *cobra.Command {
+	var output outputOptions
+
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show the details of a task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskService := newTaskService(config)
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			task, err := resolveTask(cmd.Context(), taskService, projectID, args[0])
+			if err != nil {
+				return err
+			}
+
+			// テーブル形式で列指定がない場合は詳細表示にする
+			if format, _ := ParseOutputFormat(output.format); format == OutputTable && len(output.fields) == 0 {
+				appData, err := taskService.LoadCurrent(cmd.Context())
+				if err != nil {
+					return err
+				}
+				printTaskDetail(cmd.OutOrStdout(), task, appData)
+				return nil
+			}
+			return output.writeTask(cmd.OutOrStdout(), task)
+		},
+	}
+
+	addOutputFlags(cmd, &output)
+
+	return cmd
+}
+
+// newDoneCommand は done サブコマンドを作成する
+func newDoneCommand(config *Config) *cobra.Command {
+	var (
+		withSubtasks bool
+		force        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "done <id>...",
+		Short: "Mark tasks as completed",
+		Long: `Mark tasks as completed.
+Tasks blocked by open tasks are not completed unless --force is given.
+Completing a recurring task schedules its next occurrence.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskService := newTaskService(config)
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			before, err := taskService.GetAllTasks(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			// サブタスクも完了にする場合は親が完了済みでも処理する
+			if withSubtasks {
+				for _, arg := range args {
+					task, err := resolveTask(cmd.Context(), taskService, projectID, arg)
+					if err != nil {
+						return err
+					}
+					options := service.CompleteOptions{IncludeSubtasks: true, IgnoreBlockers: force}
+					if _, err := taskService.CompleteTask(cmd.Context(), task.ID, options); err != nil {
+						return withForceHint(err)
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "Completed task %s and its subtasks: %s\n", shortID(task.ID), task.Title)
+				}
+				return printNextOccurrences(cmd.Context(), cmd.OutOrStdout(), taskService, before)
+			}
+
+			// 全てのタスクを特定してから、まとめて1回で更新する
+			var (
+				requests []service.UpdateTaskRequest
+				titles   []string
+			)
+			for _, arg := range args {
+				task, err := resolveTask(cmd.Context(), taskService, projectID, arg)
+				if err != nil {
+					return err
+				}
+				if task.IsCompleted() {
+					fmt.Fprintf(cmd.OutOrStdout(), "Task %s is already completed\n", shortID(task.ID))
+					continue
+				}
+
+				request := updateRequestFromTask(task)
+				request.Status = model.StatusCompleted
+				request.IgnoreBlockers = force
+				requests = append(requests, request)
+				titles = append(titles, task.Title)
+			}
+
+			switch len(requests) {
+			case 0:
+				return nil
+			case 1:
+				// 単一タスクの完了は破壊的な操作ではないためバックアップしない
+				if _, err := taskService.UpdateTask(cmd.Context(), requests[0]); err != nil {
+					return withForceHint(err)
+				}
+			default:
+				if _, err := taskService.UpdateTasks(cmd.Context(), requests); err != nil {
+					return withForceHint(err)
+				}
+			}
+
+			for i, request := range requests {
+				fmt.Fprintf(cmd.OutOrStdout(), "Completed task %s: %s\n", shortID(request.ID), titles[i])
+			}
+			return printNextOccurrences(cmd.Context(), cmd.OutOrStdout(), taskService, before)
+		},
+	}
+
+	cmd.Flags().BoolVar(&withSubtasks, "with-subtasks", false, "Also complete all subtasks")
+	cmd.Flags().BoolVar(&force, "force", false, "Complete tasks even if they are blocked by open tasks")
+
+	return cmd
+}
+
+// newEditCommand は edit サブコマンドを作成する
+func newEditCommand(config *Config) *cobra.Command {
+	var (
+		title       string
+		description string
+		priority    string
+		status      string
+		tags        []string
+		due         string
+		clearDue    bool
+		parent      string
+		clearParent bool
+		blockedBy   []string
+		clearBlocks bool
+		repeat      string
+		clearRepeat bool
+		estimate    string
+		clearEst    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit <id>",
+		Short: "Edit an existing task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskService := newTaskService(config)
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+			task, err := resolveTask(cmd.Context(), taskService, projectID, args[0])
+			if err != nil {
+				return err
+			}
+
+			// 指定されたフラグのみを既存の値に上書きする
+			request := updateRequestFromTask(task)
+			flags := cmd.Flags()
+			if flags.Changed("title") {
+				request.Title = title
+			}
+			if flags.Changed("description") {
+				request.Description = description
+			}
+			if flags.Changed("priority") {
+				if request.Priority, err = model.ParsePriority(priority); err != nil {
+					return err
+				}
+			}
+			if flags.Changed("status") {
+				if request.Status, err = model.ParseStatus(status); err != nil {
+					return err
+				}
+			}
+			if flags.Changed("tag") {
+				request.Tags = tags
+			}
+			if flags.Changed("due") {
+				if request.DueDate, err = parseDueDate(due); err != nil {
+					return err
+				}
+			}
+			if clearDue {
+				request.DueDate = nil
+			}
+			if flags.Changed("parent") {
+				// 親タスクは同じプロジェクトのタスクから探す
+				parentTask, err := resolveTask(cmd.Context(), taskService, task.ProjectID, parent)
+				if err != nil {
+					return err
+				}
+				request.ParentID = &parentTask.ID
+			}
+			if clearParent {
+				noParent := ""
+				request.ParentID = &noParent
+			}
+			if flags.Changed("blocked-by") {
+				blockerIDs, err := resolveTaskIDs(cmd.Context(), taskService, "", blockedBy)
+				if err != nil {
+					return err
+				}
+				request.BlockedBy = &blockerIDs
+			}
+			if clearBlocks {
+				request.BlockedBy = &[]string{}
+			}
+			if flags.Changed("repeat") {
+				if request.Recurrence, err = parseRepeat(repeat); err != nil {
+					return err
+				}
+			}
+			request.ClearRecurrence = clearRepeat
+			if flags.Changed("estimate") {
+				minutes, err := model.ParseEstimate(estimate)
+				if err != nil {
+					return err
+				}
+				request.Estimate = &minutes
+			}
+			if clearEst {
+				noEstimate := 0
+				request.Estimate = &noEstimate
+			}
+
+			updated, err := taskService.UpdateTask(cmd.Context(), request)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated task %s: %s\n", shortID(updated.ID), updated.Title)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", "New title")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "New description")
+	cmd.Flags().StringVarP(&priority, "priority", "p", "", "New priority (low, medium, high)")
+	cmd.Flags().StringVarP(&status, "status", "s", "", "New status (todo, in_progress, completed)")
+	cmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "Replace tags (comma separated)")
+	cmd.Flags().StringVar(&due, "due", "", "New due date ("+dateparse.Help+")")
+	cmd.Flags().BoolVar(&clearDue, "clear-due", false, "Remove the due date")
+	cmd.Flags().StringVar(&parent, "parent", "", "Move the task under this task")
+	cmd.Flags().BoolVar(&clearParent, "clear-parent", false, "Make the task a top-level task")
+	cmd.Flags().StringSliceVar(&blockedBy, "blocked-by", nil, "Replace the tasks that must be completed first (comma separated ids)")
+	cmd.Flags().BoolVar(&clearBlocks, "clear-blocked-by", false, "Remove all blocking tasks")
+	cmd.Flags().StringVar(&repeat, "repeat", "", "New recurrence rule (see add --help)")
+	cmd.Flags().BoolVar(&clearRepeat, "clear-repeat", false, "Stop repeating the task")
+	cmd.Flags().StringVar(&estimate, "estimate", "", "New estimated effort, e.g. 30m, 2h or 1h30m")
+	cmd.Flags().BoolVar(&clearEst, "clear-estimate", false, "Remove the estimate")
+
+	return cmd
+}
+
+// newRmCommand は rm サブコマンドを作成する
+func newRmCommand(config *Config) *cobra.Command {
+	var withSubtasks bool
+
+	cmd := &cobra.Command{
+		Use:     "rm <id>...",
+		Aliases: []string{"delete"},
+		Short:   "Delete tasks",
+		Long: `Delete tasks.
+Subtasks of a deleted task are moved up to its parent unless --with-subtasks is given.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskService := newTaskService(config)
+			projectID, err := selectedProjectID(cmd.Context(), taskService, config)
+			if err != nil {
+				return err
+			}
+
+			// 全てのタスクを特定してから、まとめて1回で削除する
+			tasks := make([]*model.Task, 0, len(args))
+			ids := make([]string, 0, len(args))
+			for _, arg := range args {
+				task, err := resolveTask(cmd.Context(), taskService, projectID, arg)
+				if err != nil {
+					return err
+				}
+				tasks = append(tasks, task)
+				ids = append(ids, task.ID)
+			}
+
+			policy := service.ChildrenPromote
+			if withSubtasks {
+				policy = service.ChildrenDelete
+			}
+			if err := taskService.DeleteTasksWithPolicy(cmd.Context(), policy, ids...); err != nil {
+				return err
+			}
+			for _, task := range tasks {
+				fmt.Fprintf(cmd.OutOrStdout(), "Deleted task %s: %s\n", shortID(task.ID), task.Title)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&withSubtasks, "with-subtasks", false, "Also delete all subtasks")
+
+	return cmd
+}
+
+// errNoMatchingTask はIDに一致するタスクがないことを表す
+var errNoMatchingTask = errors.New("no task matches id")
+
+// resolveTask は完全なIDまたは一意なIDの前方一致でタスクを特定する
+// projectID が空でない場合はそのプロジェクトのタスクから探す
+func resolveTask(ctx context.Context, taskService *service.TaskService, projectID, idOrPrefix string) (*model.Task, error) {
+	if idOrPrefix == "" {
+		return nil, errors.New("task id is required")
+	}
+
+	tasks, err := taskService.GetAllTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks = service.FilterTasks(tasks, service.TaskFilter{ProjectID: projectID})
+
+	var matches []*model.Task
+	for _, task := range tasks {
+		if task.ID == idOrPrefix {
+			return task, nil
+		}
+		if strings.HasPrefix(task.ID, idOrPrefix) {
+			matches = append(matches, task)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w %q", errNoMatchingTask, idOrPrefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("id %q is ambiguous: matches %d tasks", idOrPrefix, len(matches))
+	}
+}
+
+// resolveTaskIDs は複数のIDまたは前方一致をタスクのIDに解決する
+// projectID が空でない場合はそのプロジェクトのタスクから探す
+func resolveTaskIDs(ctx context.Context, taskService *service.TaskService, projectID string, idsOrPrefixes []string) ([]string, error) {
+	ids := make([]string, 0, len(idsOrPrefixes))
+	for _, idOrPrefix := range idsOrPrefixes {
+		task, err := resolveTask(ctx, taskService, projectID, idOrPrefix)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, task.ID)
+	}
+	return ids, nil
+}
+
+// printNextOccurrences は before に含まれない繰り返しタスク（完了により作成された次の回）を出力する
+func printNextOccurrences(ctx context.Context, out io.Writer, taskService *service.TaskService, before []*model.Task) error {
+	existing := make(map[string]bool, len(before))
+	for _, task := range before {
+		existing[task.ID] = true
+	}
+
+	tasks, err := taskService.GetAllTasks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if existing[task.ID] || task.Recurrence == nil || task.DueDate == nil {
+			continue
+		}
+		fmt.Fprintf(out, "Next occurrence %s due %s: %s\n",
+			shortID(task.ID), task.DueDate.Format(dueDateLayout), task.Title)
+	}
+	return nil
+}
+
+// withForceHint はブロックされたタスクを完了できなかったエラーに --force の案内を付ける
+func withForceHint(err error) error {
+	if errors.Is(err, service.ErrBlocked) {
+		return fmt.Errorf("%w; use --force to complete it anyway", err)
+	}
+	return err
+}
+
+// updateRequestFromTask は既存タスクの値を引き継いだ更新リクエストを作成する
+func updateRequestFromTask(task *model.Task) service.UpdateTaskRequest {
+	return service.UpdateTaskRequest{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Priority:    task.Priority,
+		Status:      task.Status,
+		Tags:        task.Tags,
+		DueDate:     task.DueDate,
+
+		ExpectedRevision: task.Revision,
+	}
+}
+
+// parseDueDate は --due フラグの値を解析する（空文字列の場合はnilを返す）
+// "tomorrow" や "+3d" のような相対的な表現は今日を基準に解釈する
+func parseDueDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	due, err := dateparse.Parse(value, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("invalid due date: %w", err)
+	}
+	return &due, nil
+}
+
+// parseWhere は --where フラグのクエリを解析する
+// 構文エラーの場合はクエリの下に誤りの位置を示す
+func parseWhere(where string) (*query.Query, error) {
+	parsed, err := query.Parse(where, time.Now())
+	var syntaxErr *query.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, fmt.Errorf("%w\n  %s", err, strings.ReplaceAll(syntaxErr.Pointer(where), "\n", "\n  "))
+	}
+	return parsed, err
+}
+
+// parseRepeat は --repeat フラグの値を解析する（空文字列の場合はnilを返す）
+func parseRepeat(value string) (*model.Recurrence, error) {
+	if value == "" {
+		return nil, nil
+	}
+	return model.ParseRecurrence(value)
+}
+
+// shortID は表示用に短縮したIDを返す
+func shortID(id string) string {
+	if len(id) > shortIDLength {
+		return id[:shortIDLength]
+	}
+	return id
+}
+
+// printTaskDetail はタスクの詳細を、親タスク、サブタスクの進捗、依存関係を含めて出力する
+func printTaskDetail(out io.Writer, task *model.Task, appData *model.AppData) {
+	fmt.Fprintf(out, "ID:          %s\n", task.ID)
+	fmt.Fprintf(out, "Title:       %s\n", task.Title)
+	fmt.Fprintf(out, "Status:      %s\n", task.Status)
+	fmt.Fprintf(out, "Priority:    %s\n", task.Priority)
+	if project, err := appData.GetProjectByID(task.ProjectID); err == nil {
+		fmt.Fprintf(out, "Project:     %s\n", project.Name)
+	}
+	fmt.Fprintf(out, "Tags:        %s\n", strings.Join(task.Tags, ", "))
+	if task.DueDate != nil {
+		fmt.Fprintf(out, "Due:         %s\n", task.DueDate.Format(dueDateLayout))
+	}
+	if task.Recurrence != nil {
+		fmt.Fprintf(out, "Repeats:     %s\n", task.Recurrence)
+	}
+	if task.Estimate > 0 {
+		fmt.Fprintf(out, "Estimate:    %s\n", model.FormatEstimate(task.Estimate))
+	}
+	if task.ParentID != "" {
+		if parent, err := appData.GetTaskByID(task.ParentID); err == nil {
+			fmt.Fprintf(out, "Parent:      %s %s\n", shortID(parent.ID), parent.Title)
+		}
+	}
+	fmt.Fprintf(out, "Created:     %s\n", task.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(out, "Updated:     %s\n", task.UpdatedAt.Format(time.RFC3339))
+	if task.CompletedAt != nil {
+		fmt.Fprintf(out, "Completed:   %s\n", task.CompletedAt.Format(time.RFC3339))
+	}
+	if task.Description != "" {
+		fmt.Fprintf(out, "\n%s\n", task.Description)
+	}
+
+	if children := appData.GetChildren(task.ID); len(children) > 0 {
+		done, total := appData.SubtaskProgress(task.ID)
+		fmt.Fprintf(out, "\nSubtasks (%d/%d done):\n", done, total)
+		for _, child := range children {
+			fmt.Fprintf(out, "  [%s] %s %s\n", child.Status, shortID(child.ID), child.Title)
+		}
+	}
+
+	if blockers := appData.GetBlockers(task.ID); len(blockers) > 0 {
+		fmt.Fprintf(out, "\nBlocked by (%d open):\n", len(appData.GetOpenBlockers(task.ID)))
+		for _, blocker := range blockers {
+			fmt.Fprintf(out, "  [%s] %s %s\n", blocker.Status, shortID(blocker.ID), blocker.Title)
+		}
+	}
+	if dependents := appData.GetDependents(task.ID); len(dependents) > 0 {
+		fmt.Fprintln(out, "\nBlocks:")
+		for _, dependent := range dependents {
+			fmt.Fprintf(out, "  [%s] %s %s\n", dependent.Status, shortID(dependent.ID), dependent.Title)
+		}
+	}
+}
@@ -0,0 +1,517 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"task-cli/internal/dateparse"
+	"task-cli/internal/model"
+	"task-cli/internal/query"
+	"task-cli/internal/quickadd"
+	"task-cli/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeCommand は指定されたデータディレクトリでコマンドを実行し、出力を返す
+func executeCommand(t *testing.T, dataDir string, args ...string) (string, error) {
+	t.Helper()
+	return executeCommandWithInput(t, dataDir, "", args...)
+}
+
+// executeCommandWithInput は標準入力を与えてコマンドを実行し、出力を返す
+func executeCommandWithInput(t *testing.T, dataDir, input string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := NewRootCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader(input))
+	// 開発者の設定ファイルのプロファイルが適用されないよう、存在しない設定ファイルを指定する
+	configFile := filepath.Join(t.TempDir(), configFileName)
+	cmd.SetArgs(append([]string{"--data-dir", dataDir, "--config", configFile}, args...))
+
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+// loadTasks はデータディレクトリに保存されたタスクを読み込む
+func loadTasks(t *testing.T, dataDir string) []*model.Task {
+	t.Helper()
+
+	appData, err := repository.NewJournalRepository(dataDir).Load(context.Background())
+	require.NoError(t, err)
+	return appData.Tasks
+}
+
+func TestAddCommand_WithFlags_ShouldCreateTask(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	output, err := executeCommand(t, dataDir, "add", "Write release notes",
+		"--priority", "high", "--tag", "docs,release", "--due", "2026-11-01")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "Created task")
+
+	tasks := loadTasks(t, dataDir)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Write release notes", tasks[0].Title)
+	assert.Equal(t, model.PriorityHigh, tasks[0].Priority)
+	assert.Equal(t, []string{"docs", "release"}, tasks[0].Tags)
+	require.NotNil(t, tasks[0].DueDate)
+	assert.Equal(t, "2026-11-01", tasks[0].DueDate.Format("2006-01-02"))
+}
+
+func TestAddCommand_WithInvalidPriority_ShouldReturnError(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "add", "Task", "--priority", "urgent")
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid priority")
+}
+
+func TestListCommand_WithStatusFilter_ShouldListMatchingTasks(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Open task")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Finished task")
+	require.NoError(t, err)
+	finished := loadTasks(t, dataDir)[1]
+	_, err = executeCommand(t, dataDir, "done", finished.ID)
+	require.NoError(t, err)
+
+	// When
+	output, err := executeCommand(t, dataDir, "list", "--status", "todo")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "Open task")
+	assert.NotContains(t, output, "Finished task")
+}
+
+func TestListCommand_WithWhere_ShouldFilterByQuery(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	for _, line := range []string{"Fix login #backend !high due:+1d", "Refactor cache #backend #blocked", "Write docs !low"} {
+		_, err := executeCommand(t, dataDir, "add", "--quick", line)
+		require.NoError(t, err)
+	}
+
+	// When
+	output, err := executeCommand(t, dataDir, "list", "--where", "tag:backend -tag:blocked due<7d priority>=medium")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, "Fix login")
+	assert.NotContains(t, output, "Refactor cache")
+	assert.NotContains(t, output, "Write docs")
+}
+
+func TestListCommand_WithInvalidWhere_ShouldPointAtBadToken(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "list", "--where", "status:todo stauts:done")
+
+	// Then
+	require.Error(t, err)
+	assert.ErrorIs(t, err, query.ErrInvalidQuery)
+	assert.Contains(t, err.Error(), `column 13: "stauts": unknown field`)
+	assert.Contains(t, err.Error(), "\n  status:todo stauts:done\n              ^~~~~~")
+}
+
+func TestShowCommand_WithIDPrefix_ShouldPrintDetails(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Inspect me", "-d", "Full description")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	output, err := executeCommand(t, dataDir, "show", task.ID[:6])
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, output, task.ID)
+	assert.Contains(t, output, "Full description")
+}
+
+func TestShowCommand_WithUnknownID_ShouldReturnError(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "show", "does-not-exist")
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no task matches")
+}
+
+func TestDoneCommand_ShouldCompleteTask(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Complete me")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	_, err = executeCommand(t, dataDir, "done", task.ID)
+
+	// Then
+	require.NoError(t, err)
+	completed := loadTasks(t, dataDir)[0]
+	assert.Equal(t, model.StatusCompleted, completed.Status)
+	assert.NotNil(t, completed.CompletedAt)
+}
+
+func TestEditCommand_ShouldOnlyChangeGivenFields(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Original", "-d", "Keep me", "--tag", "a", "--due", "2026-11-01")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	_, err = executeCommand(t, dataDir, "edit", task.ID, "--title", "Renamed", "--status", "in_progress")
+
+	// Then
+	require.NoError(t, err)
+	edited := loadTasks(t, dataDir)[0]
+	assert.Equal(t, "Renamed", edited.Title)
+	assert.Equal(t, model.StatusInProgress, edited.Status)
+	assert.Equal(t, "Keep me", edited.Description)
+	assert.Equal(t, []string{"a"}, edited.Tags)
+	assert.NotNil(t, edited.DueDate)
+}
+
+func TestRmCommand_ShouldDeleteTasks(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "First")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Second")
+	require.NoError(t, err)
+	tasks := loadTasks(t, dataDir)
+
+	// When
+	output, err := executeCommand(t, dataDir, "rm", tasks[0].ID, tasks[1].ID)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(output, "Deleted task"))
+	assert.Empty(t, loadTasks(t, dataDir))
+}
+
+func TestRmCommand_ShouldBackupBeforeDeleting(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Precious")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	_, err = executeCommand(t, dataDir, "rm", task.ID)
+
+	// Then
+	require.NoError(t, err)
+	backups, err := repository.NewFileRepository(dataDir).ListBackups(context.Background())
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	restored, err := repository.NewFileRepository(dataDir).RestoreFromBackup(context.Background(), backups[0].Path)
+	require.NoError(t, err)
+	require.Len(t, restored.Tasks, 1)
+	assert.Equal(t, "Precious", restored.Tasks[0].Title)
+}
+
+func TestRmCommand_WithKeepBackups_ShouldPruneOldBackups(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	for _, title := range []string{"One", "Two", "Three", "Four"} {
+		_, err := executeCommand(t, dataDir, "add", title)
+		require.NoError(t, err)
+	}
+
+	// When
+	for _, task := range loadTasks(t, dataDir) {
+		_, err := executeCommand(t, dataDir, "--keep-backups", "2", "--keep-daily-backups", "0",
+			"--keep-weekly-backups", "0", "rm", task.ID)
+		require.NoError(t, err)
+	}
+
+	// Then
+	backups, err := repository.NewFileRepository(dataDir).ListBackups(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+}
+
+func TestAddCommand_WithParent_ShouldCreateSubtask(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Parent")
+	require.NoError(t, err)
+	parent := loadTasks(t, dataDir)[0]
+
+	// When
+	_, err = executeCommand(t, dataDir, "add", "Child", "--parent", parent.ID[:6])
+
+	// Then
+	require.NoError(t, err)
+	tasks := loadTasks(t, dataDir)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, parent.ID, tasks[1].ParentID)
+
+	output, err := executeCommand(t, dataDir, "show", parent.ID)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Subtasks (0/1 done)")
+	assert.Contains(t, output, "Child")
+}
+
+func TestEditCommand_WithClearParent_ShouldMakeTopLevel(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Parent")
+	require.NoError(t, err)
+	parent := loadTasks(t, dataDir)[0]
+	_, err = executeCommand(t, dataDir, "add", "Child", "--parent", parent.ID)
+	require.NoError(t, err)
+	child := loadTasks(t, dataDir)[1]
+
+	// When
+	_, err = executeCommand(t, dataDir, "edit", child.ID, "--clear-parent")
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, loadTasks(t, dataDir)[1].ParentID)
+}
+
+func TestDoneCommand_WithSubtasks_ShouldCompleteChildren(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Parent")
+	require.NoError(t, err)
+	parent := loadTasks(t, dataDir)[0]
+	_, err = executeCommand(t, dataDir, "add", "Child", "--parent", parent.ID)
+	require.NoError(t, err)
+
+	// When
+	_, err = executeCommand(t, dataDir, "done", parent.ID, "--with-subtasks")
+
+	// Then
+	require.NoError(t, err)
+	for _, task := range loadTasks(t, dataDir) {
+		assert.Equal(t, model.StatusCompleted, task.Status, task.Title)
+	}
+}
+
+func TestRmCommand_WithParent_ShouldKeepOrDeleteSubtasks(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Parent")
+	require.NoError(t, err)
+	parent := loadTasks(t, dataDir)[0]
+	_, err = executeCommand(t, dataDir, "add", "Child", "--parent", parent.ID)
+	require.NoError(t, err)
+	child := loadTasks(t, dataDir)[1]
+	_, err = executeCommand(t, dataDir, "add", "Grandchild", "--parent", child.ID)
+	require.NoError(t, err)
+
+	// When - 子を削除すると孫は親に付け替えられる
+	_, err = executeCommand(t, dataDir, "rm", child.ID)
+	require.NoError(t, err)
+	promoted := loadTasks(t, dataDir)
+
+	// Then
+	require.Len(t, promoted, 2)
+	assert.Equal(t, parent.ID, promoted[1].ParentID)
+
+	// When - サブタスクごと削除する
+	_, err = executeCommand(t, dataDir, "rm", parent.ID, "--with-subtasks")
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, loadTasks(t, dataDir))
+}
+
+func TestDoneCommand_WithRecurringTask_ShouldScheduleNextOccurrence(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	due := time.Now().AddDate(0, 0, 1).Format(dueDateLayout)
+	_, err := executeCommand(t, dataDir, "add", "Rotate certs", "--due", due, "--repeat", "every 2 months")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	showOutput, err := executeCommand(t, dataDir, "show", task.ID)
+	require.NoError(t, err)
+	assert.Contains(t, showOutput, "Repeats:     every 2 months")
+
+	// When
+	output, err := executeCommand(t, dataDir, "done", task.ID)
+
+	// Then
+	require.NoError(t, err)
+	tasks := loadTasks(t, dataDir)
+	require.Len(t, tasks, 2)
+	next := tasks[1]
+	assert.Equal(t, model.StatusTodo, next.Status)
+	assert.Equal(t, task.DueDate.AddDate(0, 2, 0).Format(dueDateLayout), next.DueDate.Format(dueDateLayout))
+	assert.Contains(t, output, "Next occurrence "+shortID(next.ID)+" due "+next.DueDate.Format(dueDateLayout))
+}
+
+func TestEditCommand_WithRepeatFlags_ShouldSetAndClearRecurrence(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Chore")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	_, setErr := executeCommand(t, dataDir, "edit", task.ID, "--repeat", "FREQ=WEEKLY;BYDAY=MO")
+	afterSet := loadTasks(t, dataDir)[0].Recurrence
+	_, invalidErr := executeCommand(t, dataDir, "edit", task.ID, "--repeat", "now and then")
+	_, clearErr := executeCommand(t, dataDir, "edit", task.ID, "--clear-repeat")
+
+	// Then
+	require.NoError(t, setErr)
+	require.NotNil(t, afterSet)
+	assert.Equal(t, "every week on mon", afterSet.String())
+	assert.ErrorIs(t, invalidErr, model.ErrInvalidRecurrence)
+	require.NoError(t, clearErr)
+	assert.Nil(t, loadTasks(t, dataDir)[0].Recurrence)
+}
+
+func TestAddCommand_WithRelativeDue_ShouldResolveDate(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "add", "Soon", "--due", "+3d")
+	_, invalidErr := executeCommand(t, dataDir, "add", "Never", "--due", "someday")
+
+	// Then
+	require.NoError(t, err)
+	tasks := loadTasks(t, dataDir)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, time.Now().AddDate(0, 0, 3).Format(dueDateLayout), tasks[0].DueDate.Format(dueDateLayout))
+	assert.ErrorIs(t, invalidErr, dateparse.ErrInvalidDate)
+}
+
+func TestAddCommand_WithInlineAttributes_ShouldParseTitle(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "add", "--quick", "Fix login bug #auth #backend !high due:+2d @work est:2h")
+
+	// Then
+	require.NoError(t, err)
+	tasks := loadTasks(t, dataDir)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Fix login bug", tasks[0].Title)
+	assert.Equal(t, model.PriorityHigh, tasks[0].Priority)
+	assert.Equal(t, []string{"auth", "backend", "@work"}, tasks[0].Tags)
+	assert.Equal(t, time.Now().AddDate(0, 0, 2).Format(dueDateLayout), tasks[0].DueDate.Format(dueDateLayout))
+	assert.Equal(t, 120, tasks[0].Estimate)
+}
+
+func TestAddCommand_WithFlagsAndInlineAttributes_ShouldPreferFlags(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "add", "--quick", "Deploy", "!high", "#ops", "est:1h",
+		"--priority", "low", "--tag", "release", "--estimate", "90m")
+
+	// Then
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+	assert.Equal(t, "Deploy", task.Title)
+	assert.Equal(t, model.PriorityLow, task.Priority)
+	assert.Equal(t, []string{"ops", "release"}, task.Tags)
+	assert.Equal(t, 90, task.Estimate)
+}
+
+func TestAddCommand_WithUnknownInlineKey_ShouldReportTokenAndNotCreate(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "add", "-q", "Ship", "it", "prio:high")
+
+	// Then
+	require.Error(t, err)
+	assert.ErrorIs(t, err, quickadd.ErrUnknownKey)
+	assert.Contains(t, err.Error(), `column 9: "prio:high"`)
+	assert.Contains(t, err.Error(), "--quick")
+	assert.NoFileExists(t, filepath.Join(dataDir, "tasks.json"))
+}
+
+func TestAddCommand_WithoutQuick_ShouldKeepTitleAsIs(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When - スクリプトから渡したタイトルは属性として解釈しない
+	_, err := executeCommand(t, dataDir, "add", "Reply to #42 re:", "prio:high")
+
+	// Then
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+	assert.Equal(t, "Reply to #42 re: prio:high", task.Title)
+	assert.Empty(t, task.Tags)
+	assert.Equal(t, model.PriorityMedium, task.Priority)
+}
+
+func TestEditCommand_WithEstimateFlags_ShouldSetShowAndClearEstimate(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Review")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	_, setErr := executeCommand(t, dataDir, "edit", task.ID, "--estimate", "1h30m")
+	output, showErr := executeCommand(t, dataDir, "show", task.ID)
+	_, clearErr := executeCommand(t, dataDir, "edit", task.ID, "--clear-estimate")
+
+	// Then
+	require.NoError(t, setErr)
+	require.NoError(t, showErr)
+	assert.Contains(t, output, "Estimate:    1h30m")
+	require.NoError(t, clearErr)
+	assert.Zero(t, loadTasks(t, dataDir)[0].Estimate)
+}
+
+func TestTaskCommands_ShouldRecordChangesInJournal(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Write docs")
+	require.NoError(t, err)
+	task := loadTasks(t, dataDir)[0]
+
+	// When
+	_, err = executeCommand(t, dataDir, "done", task.ID)
+	require.NoError(t, err)
+
+	// Then - 最初の保存はスナップショット、以降の変更はジャーナルに追記される
+	journal, err := os.ReadFile(filepath.Join(dataDir, "journal.jsonl"))
+	require.NoError(t, err)
+	assert.Contains(t, string(journal), `"type":"task_completed"`)
+	assert.Equal(t, model.StatusCompleted, loadTasks(t, dataDir)[0].Status)
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newUndoCommand は undo サブコマンドを作成する
+func newUndoCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo",
+		Short: "Undo the last change to tasks",
+		Long: `Undo the last change to tasks made from the CLI or the TUI.
+Creating, editing, completing and deleting tasks can be undone, including bulk changes.
+The history is kept in the data directory, so it survives restarts.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operation, err := newTaskService(config).Undo(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Undid %s\n", operation.Description)
+			return nil
+		},
+	}
+}
+
+// newRedoCommand は redo サブコマンドを作成する
+func newRedoCommand(config *Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo",
+		Short: "Redo the last undone change",
+		Long: `Redo the last change undone with "task-cli undo".
+Making any other change discards the changes that can be redone.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operation, err := newTaskService(config).Redo(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Redid %s\n", operation.Description)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoCommand_ShouldRestoreDeletedTask(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Write docs")
+	require.NoError(t, err)
+	tasks := loadTasks(t, dataDir)
+	require.Len(t, tasks, 1)
+	_, err = executeCommand(t, dataDir, "rm", tasks[0].ID)
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "undo")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "Undid delete \"Write docs\"\n", out)
+	assert.Len(t, loadTasks(t, dataDir), 1)
+	assert.FileExists(t, filepath.Join(dataDir, "undo.json"))
+}
+
+func TestRedoCommand_ShouldReapplyUndoneChange(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Write docs")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "undo")
+	require.NoError(t, err)
+	require.Empty(t, loadTasks(t, dataDir))
+
+	// When
+	out, err := executeCommand(t, dataDir, "redo")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "Redid create \"Write docs\"\n", out)
+	assert.Len(t, loadTasks(t, dataDir), 1)
+}
+
+func TestUndoCommand_WithoutHistory_ShouldFail(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, undoErr := executeCommand(t, dataDir, "undo")
+	_, redoErr := executeCommand(t, dataDir, "redo")
+
+	// Then
+	assert.True(t, errors.Is(undoErr, service.ErrNothingToUndo))
+	assert.True(t, errors.Is(redoErr, service.ErrNothingToRedo))
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"task-cli/internal/model"
+	"task-cli/internal/query"
+	"task-cli/internal/repository"
+	"task-cli/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// viewListFields は view list の出力列（position はTUIで選択する数字キー）
+var viewListFields = []string{"position", "name", "query"}
+
+// reservedViewNames は view のサブコマンドと重なるため、ビュー名に使えない名前
+var reservedViewNames = []string{"save", "list", "ls", "rm"}
+
+// newViewCommand は view サブコマンド群を作成する
+func newViewCommand(config *Config) *cobra.Command {
+	var output outputOptions
+
+	cmd := &cobra.Command{
+		Use:   "view <name>",
+		Short: "Run, save, list and remove saved views",
+		Long: `Run a saved view: a named --where query stored in the data directory.
+Relative dates in the query (e.g. due<=+7d) are resolved each time the view runs.
+In the TUI, the number keys 1-9 switch to the first nine views and 0 shows all tasks.
+
+Query syntax: ` + query.Help + ".",
+		Example: `  task-cli view save "My backend work" --where 'tag:backend is:open'
+  task-cli view save "Due this week" --where 'due<=+7d is:open'
+  task-cli view "Due this week"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			view, err := newViewService(config).GetView(cmd.Context(), strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			filter, err := service.ViewFilter(view, time.Now())
+			if err != nil {
+				return err
+			}
+
+			taskService := newTaskService(config)
+			if filter.ProjectID, err = selectedProjectID(cmd.Context(), taskService, config); err != nil {
+				return err
+			}
+			tasks, err := taskService.GetAllTasks(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return output.writeTasks(cmd.OutOrStdout(), service.FilterTasks(tasks, filter))
+		},
+	}
+
+	addOutputFlags(cmd, &output)
+
+	cmd.AddCommand(
+		newViewSaveCommand(config),
+		newViewListCommand(config),
+		newViewRmCommand(config),
+	)
+
+	return cmd
+}
+
+// newViewSaveCommand は view save サブコマンドを作成する
+func newViewSaveCommand(config *Config) *cobra.Command {
+	var where string
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save a query as a view, replacing any view with the same name",
+		Args:  cobra.MinimumNArgs(1),
+		// ビューの保存はタスクのデータを読み込まない
+		Annotations: map[string]string{skipDataCheckAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.Join(args, " ")
+			for _, reserved := range reservedViewNames {
+				if strings.EqualFold(name, reserved) {
+					return fmt.Errorf("%q cannot be used as a view name", name)
+				}
+			}
+			if _, err := parseWhere(where); err != nil {
+				return err
+			}
+
+			replaced, err := newViewService(config).SaveView(cmd.Context(), model.View{Name: name, Query: where})
+			if err != nil {
+				return err
+			}
+			if replaced {
+				fmt.Fprintf(cmd.OutOrStdout(), "Updated view %q\n", name)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Saved view %q\n", name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&where, "where", "w", "", "Query that selects the tasks of the view (see \"list --help\")")
+	cmd.MarkFlagRequired("where")
+
+	return cmd
+}
+
+// newViewListCommand は view list サブコマンドを作成する
+func newViewListCommand(config *Config) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Aliases:     []string{"ls"},
+		Short:       "List saved views",
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{skipDataCheckAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := ParseOutputFormat(format)
+			if err != nil {
+				return err
+			}
+
+			views, err := newViewService(config).ListViews(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			set := &recordSet{fields: viewListFields, records: make([][]interface{}, 0, len(views))}
+			for i, view := range views {
+				set.records = append(set.records, []interface{}{i + 1, view.Name, view.Query})
+			}
+			return set.write(cmd.OutOrStdout(), outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "output", "o", string(OutputTable), "Output format (table, json, yaml, csv)")
+
+	return cmd
+}
+
+// newViewRmCommand は view rm サブコマンドを作成する
+func newViewRmCommand(config *Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "rm <name>",
+		Short:       "Remove a saved view",
+		Args:        cobra.MinimumNArgs(1),
+		Annotations: map[string]string{skipDataCheckAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.Join(args, " ")
+			if err := newViewService(config).DeleteView(cmd.Context(), name); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed view %q\n", name)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newViewService は設定に基づいてViewServiceを作成する
+func newViewService(config *Config) *service.ViewService {
+	repo := repository.NewFileViewRepository(config.DataDir,
+		repository.WithLockTimeout(config.LockTimeout),
+	)
+	return service.NewViewService(repo)
+}
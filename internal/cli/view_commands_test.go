@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCommand_ShouldRunSavedView(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "add", "Fix API", "--tag", "backend")
+	require.NoError(t, err)
+	_, err = executeCommand(t, dataDir, "add", "Polish UI", "--tag", "frontend")
+	require.NoError(t, err)
+
+	// When
+	saveOut, saveErr := executeCommand(t, dataDir, "view", "save", "My", "backend", "work", "--where", "tag:backend is:open")
+	out, err := executeCommand(t, dataDir, "view", "my backend work", "--fields", "title")
+
+	// Then
+	require.NoError(t, saveErr)
+	assert.Contains(t, saveOut, `Saved view "My backend work"`)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Fix API")
+	assert.NotContains(t, out, "Polish UI")
+	assert.FileExists(t, filepath.Join(dataDir, "views.json"))
+}
+
+func TestViewSaveCommand_WithExistingName_ShouldReplaceView(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "view", "save", "Soon", "--where", "due<=+7d")
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "view", "save", "soon", "--where", "due<=+3d")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, out, `Updated view "soon"`)
+	list, err := executeCommand(t, dataDir, "view", "list", "-o", "csv")
+	require.NoError(t, err)
+	assert.Equal(t, "position,name,query\n1,soon,due<=+3d\n", list)
+}
+
+func TestViewSaveCommand_WithInvalidQuery_ShouldPointAtError(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+
+	// When
+	_, err := executeCommand(t, dataDir, "view", "save", "Broken", "--where", "tag:a (")
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "^")
+	assert.NoFileExists(t, filepath.Join(dataDir, "views.json"))
+}
+
+func TestViewSaveCommand_WithReservedName_ShouldReturnError(t *testing.T) {
+	// When
+	_, err := executeCommand(t, t.TempDir(), "view", "save", "list", "--where", "tag:a")
+
+	// Then
+	assert.ErrorContains(t, err, `"list" cannot be used as a view name`)
+}
+
+func TestViewRmCommand_ShouldRemoveView(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	_, err := executeCommand(t, dataDir, "view", "save", "Backend", "--where", "tag:backend")
+	require.NoError(t, err)
+
+	// When
+	out, err := executeCommand(t, dataDir, "view", "rm", "backend")
+	_, runErr := executeCommand(t, dataDir, "view", "Backend")
+
+	// Then
+	require.NoError(t, err)
+	assert.Contains(t, out, `Removed view "backend"`)
+	assert.True(t, errors.Is(runErr, service.ErrViewNotFound))
+}
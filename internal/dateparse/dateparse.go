@@ -0,0 +1,126 @@
+// Package dateparse は期限日の入力を日付に変換する
+// "2026-11-01" のような絶対日付に加えて "today"、"tomorrow"、"fri"、"+3d" のような相対的な表現を受け付ける
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout は期限日の表示と入力に使う日付フォーマット
+const Layout = "2006-01-02"
+
+// ErrInvalidDate は日付として解釈できない入力を表す
+var ErrInvalidDate = errors.New("invalid date")
+
+// Help は受け付ける入力形式の説明
+const Help = "YYYY-MM-DD, today, tomorrow, a weekday like fri, or an offset like +3d, +2w, +1m"
+
+// absoluteLayouts は受け付ける絶対日付のフォーマット
+var absoluteLayouts = []string{Layout, "2006/01/02", "2006-1-2", "2006/1/2"}
+
+// Parse は input を now を基準に解釈し、その日の0時（now のタイムゾーン）を返す
+// 曜日名は今日より後の最初のその曜日を表す
+func Parse(input string, now time.Time) (time.Time, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	if normalized == "" {
+		return time.Time{}, fmt.Errorf("%w: empty input", ErrInvalidDate)
+	}
+
+	today := StartOfDay(now)
+	switch normalized {
+	case "today", "now":
+		return today, nil
+	case "tomorrow", "tmr", "tom":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "next week":
+		return today.AddDate(0, 0, 7), nil
+	case "next month":
+		return today.AddDate(0, 1, 0), nil
+	}
+
+	for _, layout := range absoluteLayouts {
+		if date, err := time.ParseInLocation(layout, normalized, now.Location()); err == nil {
+			return date, nil
+		}
+	}
+
+	if weekday, ok := parseWeekday(strings.TrimPrefix(normalized, "next ")); ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	if date, ok := parseOffset(normalized, today); ok {
+		return date, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w %q: expected %s", ErrInvalidDate, input, Help)
+}
+
+// StartOfDay は t と同じ日の0時を返す
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// parseWeekday は "fri" や "friday" を time.Weekday に変換する
+func parseWeekday(s string) (time.Weekday, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if s == name || s == name[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// parseOffset は "+3d"、"-1w"、"2m"、"in 3 days" のような相対指定を today からずらした日付に変換する
+func parseOffset(s string, today time.Time) (time.Time, bool) {
+	s = strings.ReplaceAll(strings.TrimPrefix(s, "in "), " ", "")
+
+	sign := 1
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	}
+
+	digits := 0
+	for digits < len(s) && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 {
+		return time.Time{}, false
+	}
+	amount, err := strconv.Atoi(s[:digits])
+	if err != nil {
+		return time.Time{}, false
+	}
+	amount *= sign
+
+	switch strings.TrimSuffix(s[digits:], "s") {
+	case "d", "day":
+		return today.AddDate(0, 0, amount), true
+	case "w", "week":
+		return today.AddDate(0, 0, 7*amount), true
+	case "m", "month":
+		return today.AddDate(0, amount, 0), true
+	case "y", "year":
+		return today.AddDate(amount, 0, 0), true
+	default:
+		return time.Time{}, false
+	}
+}
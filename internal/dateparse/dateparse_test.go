@@ -0,0 +1,77 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_ShouldResolveAbsoluteAndRelativeDates(t *testing.T) {
+	// 2026-10-14 (水) 15:04 を基準にする
+	now := time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "2026-11-01", want: date(2026, 11, 1)},
+		{input: "2026/1/5", want: date(2026, 1, 5)},
+		{input: "today", want: date(2026, 10, 14)},
+		{input: "Tomorrow", want: date(2026, 10, 15)},
+		{input: "yesterday", want: date(2026, 10, 13)},
+		{input: "fri", want: date(2026, 10, 16)},
+		{input: "monday", want: date(2026, 10, 19)},
+		{input: "wed", want: date(2026, 10, 21)},
+		{input: "next fri", want: date(2026, 10, 16)},
+		{input: "+3d", want: date(2026, 10, 17)},
+		{input: "-1d", want: date(2026, 10, 13)},
+		{input: "+2w", want: date(2026, 10, 28)},
+		{input: "+1m", want: date(2026, 11, 14)},
+		{input: "1y", want: date(2027, 10, 14)},
+		{input: "in 3 days", want: date(2026, 10, 17)},
+		{input: "next week", want: date(2026, 10, 21)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// When
+			got, err := Parse(tt.input, now)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_WithInvalidInput_ShouldReturnError(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+
+	for _, input := range []string{"", "someday", "+3", "+3x", "2026-13-01", "fr"} {
+		t.Run(input, func(t *testing.T) {
+			// When
+			_, err := Parse(input, now)
+
+			// Then
+			assert.ErrorIs(t, err, ErrInvalidDate)
+		})
+	}
+}
+
+func TestParse_ShouldUseLocationOfNow(t *testing.T) {
+	// Given
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 14, 23, 30, 0, 0, tokyo)
+
+	// When
+	got, err := Parse("tomorrow", now)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, tokyo), got)
+}
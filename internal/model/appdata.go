@@ -10,10 +10,13 @@ import (
 
 // AppData はアプリケーションのデータ全体を管理する構造体
 type AppData struct {
+	SchemaVersion int    `json:"schema_version"`
 	ID        string     `json:"id"`
+	Projects  []*Project `json:"projects"`
 	Tasks     []*Task    `json:"tasks"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
+	Revision  int64      `json:"revision"`
 }
 
 // NewAppData は新しいAppDataインスタンスを作成する
@@ -21,6 +24,7 @@ func NewAppData() *AppData {
 	now := time.Now()
 	return &AppData{
 		ID:        uuid.New().String(),
+		Projects:  []*Project{NewDefaultProject(now)},
 		Tasks:     make([]*Task, 0),
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -34,7 +38,7 @@ func (a *AppData) AddTask(task *Task) error {
 	}
 	
 	a.Tasks = append(a.Tasks, task)
-	a.UpdatedAt = time.Now()
+	a.touch()
 	return nil
 }
 
@@ -56,8 +60,10 @@ func (a *AppData) UpdateTask(updatedTask *Task) error {
 
 	for i, task := range a.Tasks {
 		if task.ID == updatedTask.ID {
+			// タスクのリビジョンは既存の値を基準に進める
+			updatedTask.Revision = task.Revision + 1
 			a.Tasks[i] = updatedTask
-			a.UpdatedAt = time.Now()
+			a.touch()
 			return nil
 		}
 	}
@@ -70,13 +76,37 @@ func (a *AppData) DeleteTask(id string) error {
 		if task.ID == id {
 			// スライスから要素を削除
 			a.Tasks = append(a.Tasks[:i], a.Tasks[i+1:]...)
-			a.UpdatedAt = time.Now()
+			a.touch()
 			return nil
 		}
 	}
 	return errors.New("task not found")
 }
 
+// Clone はプロジェクトとタスクを含むデータ全体のコピーを返す
+func (a *AppData) Clone() *AppData {
+	clone := *a
+	if a.Projects != nil {
+		clone.Projects = make([]*Project, len(a.Projects))
+		for i, project := range a.Projects {
+			clone.Projects[i] = project.Clone()
+		}
+	}
+	if a.Tasks != nil {
+		clone.Tasks = make([]*Task, len(a.Tasks))
+		for i, task := range a.Tasks {
+			clone.Tasks[i] = task.Clone()
+		}
+	}
+	return &clone
+}
+
+// touch はデータ全体のリビジョンと更新日時を進める
+func (a *AppData) touch() {
+	a.Revision++
+	a.UpdatedAt = time.Now()
+}
+
 // GetTasksByStatus は指定されたステータスのタスクを返す
 func (a *AppData) GetTasksByStatus(status Status) []*Task {
 	var filteredTasks []*Task
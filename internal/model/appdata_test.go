@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // RED: AppDataモデルのテスト
@@ -87,6 +88,27 @@ func TestAppData_UpdateTask_WithValidTask_ShouldUpdateTask(t *testing.T) {
 	assert.Equal(t, PriorityHigh, retrievedTask.Priority)
 }
 
+func TestAppData_UpdateTask_ShouldIncrementRevisions(t *testing.T) {
+	// Given
+	appData := NewAppData()
+	task, err := NewTask("Test Task", "Description", PriorityMedium, nil)
+	assert.NoError(t, err)
+	appData.AddTask(task)
+	assert.Equal(t, int64(1), task.Revision)
+	assert.Equal(t, int64(1), appData.Revision)
+
+	updatedTask := *task
+	updatedTask.Title = "Updated Task"
+
+	// When
+	err = appData.UpdateTask(&updatedTask)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), updatedTask.Revision)
+	assert.Equal(t, int64(2), appData.Revision)
+}
+
 func TestAppData_DeleteTask_WithValidID_ShouldRemoveTask(t *testing.T) {
 	// Given
 	appData := NewAppData()
@@ -247,4 +269,21 @@ func TestAppData_SearchTasksByTitle_ShouldReturnMatchingTasks(t *testing.T) {
 			assert.Len(t, results, tt.expected)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestAppData_Clone_ShouldNotShareProjectsOrTasks(t *testing.T) {
+	// Given
+	appData := NewAppData()
+	task, _ := NewTask("Write docs", "", PriorityLow, []string{"docs"})
+	require.NoError(t, appData.AddTask(task))
+
+	// When
+	clone := appData.Clone()
+	clone.Tasks[0].Tags[0] = "changed"
+	clone.Projects[0].Name = "changed"
+
+	// Then
+	assert.Equal(t, appData.Revision, clone.Revision)
+	assert.Equal(t, "docs", appData.Tasks[0].Tags[0])
+	assert.Equal(t, DefaultProjectName, appData.Projects[0].Name)
+}
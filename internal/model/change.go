@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// ChangeAction はタスクの変更履歴に記録する変更の種類を定義
+type ChangeAction string
+
+const (
+	ChangeCreated ChangeAction = "created"
+	ChangeUpdated ChangeAction = "updated"
+	ChangeUndone  ChangeAction = "undone"
+	ChangeRedone  ChangeAction = "redone"
+	ChangeDeleted ChangeAction = "deleted"
+)
+
+// FieldChange は1つのフィールドの変更前と変更後の値
+// Field はTaskのjsonタグ名、Old と New は表示用に文字列化した値
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ChangeEntry はタスクに対する1回の変更の記録
+type ChangeEntry struct {
+	At     time.Time     `json:"at"`
+	Actor  string        `json:"actor"`
+	Action ChangeAction  `json:"action"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// Deletion はタスクの削除の記録
+// タスクの変更履歴はタスクとともに失われるため、削除はタスクとは別に記録する
+type Deletion struct {
+	TaskID    string      `json:"task_id"`
+	Title     string      `json:"title"`
+	ProjectID string      `json:"project_id,omitempty"`
+	Change    ChangeEntry `json:"change"`
+}
+
+// AddChange はタスクの変更履歴に変更を追加する
+// limit を超えた古い変更は捨てる（0以下の場合は制限しない）
+func (t *Task) AddChange(entry ChangeEntry, limit int) {
+	t.Changes = append(t.Changes, entry)
+	if limit > 0 && len(t.Changes) > limit {
+		t.Changes = append([]ChangeEntry{}, t.Changes[len(t.Changes)-limit:]...)
+	}
+}
+
+// cloneChanges は変更履歴のコピーを返す（nilはnilのまま）
+func cloneChanges(changes []ChangeEntry) []ChangeEntry {
+	if changes == nil {
+		return nil
+	}
+	clones := make([]ChangeEntry, len(changes))
+	for i, change := range changes {
+		clones[i] = change
+		clones[i].Fields = cloneSlice(change.Fields)
+	}
+	return clones
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidBlocker はブロックしているタスクの指定が不正なことを表す
+	ErrInvalidBlocker = errors.New("invalid blocking task")
+
+	// ErrDependencyCycle は依存関係が循環することを表す
+	ErrDependencyCycle = errors.New("dependency cycle")
+)
+
+// GetBlockers は指定したタスクをブロックしているタスクを返す（存在しないIDは無視する）
+func (a *AppData) GetBlockers(id string) []*Task {
+	task, err := a.GetTaskByID(id)
+	if err != nil {
+		return nil
+	}
+
+	var blockers []*Task
+	for _, blockerID := range task.BlockedBy {
+		if blocker, err := a.GetTaskByID(blockerID); err == nil {
+			blockers = append(blockers, blocker)
+		}
+	}
+	return blockers
+}
+
+// GetOpenBlockers は指定したタスクをブロックしている未完了のタスクを返す
+func (a *AppData) GetOpenBlockers(id string) []*Task {
+	var open []*Task
+	for _, blocker := range a.GetBlockers(id) {
+		if !blocker.IsCompleted() {
+			open = append(open, blocker)
+		}
+	}
+	return open
+}
+
+// IsBlocked は未完了のタスクにブロックされているかを返す
+func (a *AppData) IsBlocked(id string) bool {
+	return len(a.GetOpenBlockers(id)) > 0
+}
+
+// GetDependents は指定したタスクにブロックされているタスクを返す
+func (a *AppData) GetDependents(id string) []*Task {
+	var dependents []*Task
+	for _, task := range a.Tasks {
+		for _, blockerID := range task.BlockedBy {
+			if blockerID == id {
+				dependents = append(dependents, task)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// ValidateBlockers は taskID のタスクを blockerIDs のタスクにブロックさせられるかを検証する
+// 存在しないタスクや自身を指定した場合、依存関係が循環する場合はエラーを返す
+func (a *AppData) ValidateBlockers(taskID string, blockerIDs []string) error {
+	for _, blockerID := range blockerIDs {
+		if blockerID == taskID {
+			return fmt.Errorf("%w: a task cannot block itself", ErrDependencyCycle)
+		}
+		if _, err := a.GetTaskByID(blockerID); err != nil {
+			return fmt.Errorf("%w: task %s not found", ErrInvalidBlocker, blockerID)
+		}
+		if path := a.dependencyPath(blockerID, taskID); path != nil {
+			return fmt.Errorf("%w: %s would block itself through %d other tasks",
+				ErrDependencyCycle, taskID, len(path)-1)
+		}
+	}
+	return nil
+}
+
+// dependencyPath は from のタスクが（ブロックしているタスクを辿って）to に依存している場合、その経路を返す
+func (a *AppData) dependencyPath(from, to string) []string {
+	visited := make(map[string]bool)
+
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if id == to {
+			return []string{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+
+		task, err := a.GetTaskByID(id)
+		if err != nil {
+			return nil
+		}
+		for _, blockerID := range task.BlockedBy {
+			if path := walk(blockerID); path != nil {
+				return append([]string{id}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// RemoveMissingBlockers は存在しないタスクへのブロック参照を取り除き、変更したタスクを返す
+func (a *AppData) RemoveMissingBlockers() []*Task {
+	var changed []*Task
+	for _, task := range a.Tasks {
+		var kept []string
+		for _, blockerID := range task.BlockedBy {
+			if _, err := a.GetTaskByID(blockerID); err == nil {
+				kept = append(kept, blockerID)
+			}
+		}
+		if len(kept) != len(task.BlockedBy) {
+			task.BlockedBy = kept
+			changed = append(changed, task)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDependencyChain は first <- second <- third の順にブロックし合うタスクを持つAppDataを作成する
+func newDependencyChain(t *testing.T) (appData *AppData, first, second, third *Task) {
+	t.Helper()
+
+	appData = NewAppData()
+	first, err := NewTask("First", "", PriorityMedium, nil)
+	require.NoError(t, err)
+	second, err = NewTask("Second", "", PriorityMedium, nil)
+	require.NoError(t, err)
+	third, err = NewTask("Third", "", PriorityMedium, nil)
+	require.NoError(t, err)
+
+	second.BlockedBy = []string{first.ID}
+	third.BlockedBy = []string{second.ID}
+	for _, task := range []*Task{first, second, third} {
+		appData.AddTask(task)
+	}
+	return appData, first, second, third
+}
+
+func TestAppData_GetBlockersAndDependents_ShouldFollowBlockedBy(t *testing.T) {
+	// Given
+	appData, first, second, third := newDependencyChain(t)
+
+	// When
+	blockers := appData.GetBlockers(second.ID)
+	dependents := appData.GetDependents(second.ID)
+
+	// Then
+	assert.Equal(t, []*Task{first}, blockers)
+	assert.Equal(t, []*Task{third}, dependents)
+}
+
+func TestAppData_IsBlocked_ShouldIgnoreCompletedBlockers(t *testing.T) {
+	// Given
+	appData, first, second, _ := newDependencyChain(t)
+	require.True(t, appData.IsBlocked(second.ID))
+
+	// When
+	first.Status = StatusCompleted
+
+	// Then
+	assert.False(t, appData.IsBlocked(second.ID))
+	assert.Empty(t, appData.GetOpenBlockers(second.ID))
+	assert.False(t, appData.IsBlocked(first.ID))
+}
+
+func TestAppData_ValidateBlockers_ShouldRejectInvalidDependencies(t *testing.T) {
+	appData, first, second, third := newDependencyChain(t)
+
+	tests := []struct {
+		name     string
+		taskID   string
+		blockers []string
+		wantErr  error
+	}{
+		{name: "no blockers", taskID: first.ID, blockers: nil},
+		{name: "independent blocker", taskID: third.ID, blockers: []string{first.ID}},
+		{name: "self", taskID: first.ID, blockers: []string{first.ID}, wantErr: ErrDependencyCycle},
+		{name: "missing task", taskID: first.ID, blockers: []string{"missing"}, wantErr: ErrInvalidBlocker},
+		{name: "direct cycle", taskID: first.ID, blockers: []string{second.ID}, wantErr: ErrDependencyCycle},
+		{name: "transitive cycle", taskID: first.ID, blockers: []string{third.ID}, wantErr: ErrDependencyCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			err := appData.ValidateBlockers(tt.taskID, tt.blockers)
+
+			// Then
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAppData_RemoveMissingBlockers_ShouldDropReferencesToDeletedTasks(t *testing.T) {
+	// Given
+	appData, first, second, third := newDependencyChain(t)
+	require.NoError(t, appData.DeleteTask(first.ID))
+
+	// When
+	changed := appData.RemoveMissingBlockers()
+
+	// Then
+	assert.Equal(t, []*Task{second}, changed)
+	assert.Empty(t, second.BlockedBy)
+	assert.Equal(t, []string{second.ID}, third.BlockedBy)
+}
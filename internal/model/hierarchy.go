@@ -0,0 +1,89 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidParent は親タスクの指定が不正なことを表す
+var ErrInvalidParent = errors.New("invalid parent task")
+
+// GetChildren は指定したタスクの直接のサブタスクを返す
+func (a *AppData) GetChildren(id string) []*Task {
+	var children []*Task
+	for _, task := range a.Tasks {
+		if task.ParentID == id && id != "" {
+			children = append(children, task)
+		}
+	}
+	return children
+}
+
+// GetDescendants は指定したタスクの全てのサブタスクを深さ優先で返す
+func (a *AppData) GetDescendants(id string) []*Task {
+	var descendants []*Task
+	visited := map[string]bool{id: true}
+
+	var walk func(parentID string)
+	walk = func(parentID string) {
+		for _, child := range a.GetChildren(parentID) {
+			// 壊れたデータに循環があっても無限に辿らない
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			descendants = append(descendants, child)
+			walk(child.ID)
+		}
+	}
+	walk(id)
+
+	return descendants
+}
+
+// GetAncestors は指定したタスクの親を近い順に返す
+func (a *AppData) GetAncestors(id string) []*Task {
+	var ancestors []*Task
+	visited := map[string]bool{id: true}
+
+	task, err := a.GetTaskByID(id)
+	for err == nil && task.ParentID != "" && !visited[task.ParentID] {
+		visited[task.ParentID] = true
+		if task, err = a.GetTaskByID(task.ParentID); err == nil {
+			ancestors = append(ancestors, task)
+		}
+	}
+	return ancestors
+}
+
+// SubtaskProgress は全てのサブタスクのうち完了した数と総数を返す
+func (a *AppData) SubtaskProgress(id string) (done, total int) {
+	for _, task := range a.GetDescendants(id) {
+		total++
+		if task.IsCompleted() {
+			done++
+		}
+	}
+	return done, total
+}
+
+// ValidateParent は taskID のタスクの親を parentID にできるかを検証する
+// 親が存在しない場合や、自身またはサブタスクを親にして循環する場合はエラーを返す
+// parentID が空の場合は親を持たないタスクになるため常に有効
+func (a *AppData) ValidateParent(taskID, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	if parentID == taskID {
+		return fmt.Errorf("%w: a task cannot be its own parent", ErrInvalidParent)
+	}
+	if _, err := a.GetTaskByID(parentID); err != nil {
+		return fmt.Errorf("%w: parent task %s not found", ErrInvalidParent, parentID)
+	}
+	for _, descendant := range a.GetDescendants(taskID) {
+		if descendant.ID == parentID {
+			return fmt.Errorf("%w: a task cannot be moved under its own subtask", ErrInvalidParent)
+		}
+	}
+	return nil
+}
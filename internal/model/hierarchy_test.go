@@ -0,0 +1,90 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTree は root > child > grandchild と root > sibling の階層を持つAppDataを作成する
+func newTree(t *testing.T) (appData *AppData, root, child, grandchild, sibling *Task) {
+	t.Helper()
+
+	appData = NewAppData()
+	root, err := NewTask("Root", "", PriorityMedium, nil)
+	require.NoError(t, err)
+	child, err = NewTask("Child", "", PriorityMedium, nil)
+	require.NoError(t, err)
+	grandchild, err = NewTask("Grandchild", "", PriorityMedium, nil)
+	require.NoError(t, err)
+	sibling, err = NewTask("Sibling", "", PriorityMedium, nil)
+	require.NoError(t, err)
+
+	child.ParentID = root.ID
+	grandchild.ParentID = child.ID
+	sibling.ParentID = root.ID
+	for _, task := range []*Task{root, child, grandchild, sibling} {
+		appData.AddTask(task)
+	}
+	return appData, root, child, grandchild, sibling
+}
+
+func TestAppData_GetChildren_ShouldReturnDirectSubtasksOnly(t *testing.T) {
+	// Given
+	appData, root, child, _, sibling := newTree(t)
+
+	// When
+	children := appData.GetChildren(root.ID)
+
+	// Then
+	assert.Equal(t, []*Task{child, sibling}, children)
+}
+
+func TestAppData_GetDescendants_ShouldWalkDepthFirst(t *testing.T) {
+	// Given
+	appData, root, child, grandchild, sibling := newTree(t)
+
+	// When
+	descendants := appData.GetDescendants(root.ID)
+
+	// Then
+	assert.Equal(t, []*Task{child, grandchild, sibling}, descendants)
+}
+
+func TestAppData_GetAncestors_ShouldReturnNearestFirst(t *testing.T) {
+	// Given
+	appData, root, child, grandchild, _ := newTree(t)
+
+	// When
+	ancestors := appData.GetAncestors(grandchild.ID)
+
+	// Then
+	assert.Equal(t, []*Task{child, root}, ancestors)
+}
+
+func TestAppData_SubtaskProgress_ShouldRollUpAllLevels(t *testing.T) {
+	// Given
+	appData, root, _, grandchild, sibling := newTree(t)
+	grandchild.Status = StatusCompleted
+	sibling.Status = StatusCompleted
+
+	// When
+	done, total := appData.SubtaskProgress(root.ID)
+
+	// Then
+	assert.Equal(t, 2, done)
+	assert.Equal(t, 3, total)
+}
+
+func TestAppData_ValidateParent_ShouldRejectCyclesAndMissingParents(t *testing.T) {
+	// Given
+	appData, root, child, grandchild, sibling := newTree(t)
+
+	// Then
+	assert.NoError(t, appData.ValidateParent(grandchild.ID, sibling.ID))
+	assert.NoError(t, appData.ValidateParent(child.ID, ""))
+	assert.ErrorIs(t, appData.ValidateParent(root.ID, root.ID), ErrInvalidParent)
+	assert.ErrorIs(t, appData.ValidateParent(root.ID, grandchild.ID), ErrInvalidParent)
+	assert.ErrorIs(t, appData.ValidateParent(child.ID, "missing"), ErrInvalidParent)
+}
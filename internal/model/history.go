@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Operation は元に戻せる1回の変更
+// 変更されたタスクの変更前と変更後の状態を保持する
+type Operation struct {
+	Description string    `json:"description"`
+	At          time.Time `json:"at"`
+
+	// Before は変更前のタスク（この変更で作成したタスクは含まない）
+	Before []*Task `json:"before"`
+
+	// After は変更後のタスク（この変更で削除したタスクは含まない）
+	After []*Task `json:"after"`
+}
+
+// History は元に戻す（undo）とやり直す（redo）ための操作の履歴
+// どちらのスタックも末尾が最新の操作
+type History struct {
+	Undo []Operation `json:"undo"`
+	Redo []Operation `json:"redo"`
+}
+
+// NewHistory は空の履歴を作成する
+func NewHistory() *History {
+	return &History{Undo: []Operation{}, Redo: []Operation{}}
+}
+
+// Record は新しい操作を元に戻せるように記録する
+// やり直せる操作は破棄し、limit を超えた古い操作は捨てる（0以下の場合は制限しない）
+func (h *History) Record(operation Operation, limit int) {
+	h.Undo = append(h.Undo, operation)
+	if limit > 0 && len(h.Undo) > limit {
+		h.Undo = append([]Operation{}, h.Undo[len(h.Undo)-limit:]...)
+	}
+	h.Redo = []Operation{}
+}
+
+// CanUndo は元に戻せる操作があるかを返す
+func (h *History) CanUndo() bool {
+	return len(h.Undo) > 0
+}
+
+// CanRedo はやり直せる操作があるかを返す
+func (h *History) CanRedo() bool {
+	return len(h.Redo) > 0
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory_Record_ShouldClearRedoAndKeepLimit(t *testing.T) {
+	// Given
+	history := NewHistory()
+	history.Redo = []Operation{{Description: "redo me"}}
+
+	// When
+	for _, description := range []string{"first", "second", "third"} {
+		history.Record(Operation{Description: description}, 2)
+	}
+
+	// Then - 古い操作は捨てられ、やり直せる操作はなくなる
+	assert.Equal(t, []Operation{{Description: "second"}, {Description: "third"}}, history.Undo)
+	assert.True(t, history.CanUndo())
+	assert.False(t, history.CanRedo())
+}
@@ -0,0 +1,171 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultProjectID は既定のプロジェクトのID（プロジェクト導入前のタスクはここに移行する）
+	DefaultProjectID = "default"
+
+	// DefaultProjectName は既定のプロジェクトの名前
+	DefaultProjectName = "default"
+
+	// maxProjectNameLength はプロジェクト名の最大文字数
+	maxProjectNameLength = 50
+)
+
+// projectColorPattern はプロジェクトの色として受け付ける形式（色名または #rrggbb）
+var projectColorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|[a-zA-Z]+)$`)
+
+// Project はタスクをまとめるプロジェクト（ワークスペース）
+// DefaultTags はこのプロジェクトに作成するタスクに自動で付けるタグ
+type Project struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color,omitempty"`
+	DefaultTags []string  `json:"default_tags,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewProject は新しいProjectを作成する
+func NewProject(name, color string, defaultTags []string) (*Project, error) {
+	project := &Project{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Color:       color,
+		DefaultTags: defaultTags,
+		CreatedAt:   time.Now(),
+	}
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// NewDefaultProject は既定のプロジェクトを作成する
+func NewDefaultProject(createdAt time.Time) *Project {
+	return &Project{ID: DefaultProjectID, Name: DefaultProjectName, CreatedAt: createdAt}
+}
+
+// Validate はProjectの値を検証する
+func (p *Project) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("project name is required")
+	}
+	if p.Name != strings.TrimSpace(p.Name) {
+		return errors.New("project name must not start or end with spaces")
+	}
+	if len([]rune(p.Name)) > maxProjectNameLength {
+		return errors.New("project name must be 50 characters or less")
+	}
+	for _, r := range p.Name {
+		if unicode.IsControl(r) {
+			return errors.New("project name must not contain control characters")
+		}
+	}
+	if p.Color != "" && !projectColorPattern.MatchString(p.Color) {
+		return fmt.Errorf("invalid project color %q: use a color name or #rrggbb", p.Color)
+	}
+	return nil
+}
+
+// Clone はプロジェクトのコピーを返す
+func (p *Project) Clone() *Project {
+	clone := *p
+	clone.DefaultTags = cloneSlice(p.DefaultTags)
+	return &clone
+}
+
+// IsDefault は既定のプロジェクトかを返す
+func (p *Project) IsDefault() bool {
+	return p.ID == DefaultProjectID
+}
+
+// AddProject はプロジェクトを追加する（同じ名前のプロジェクトがある場合はエラー）
+func (a *AppData) AddProject(project *Project) error {
+	if project == nil {
+		return errors.New("project cannot be nil")
+	}
+	if existing, err := a.FindProject(project.Name); err == nil {
+		return fmt.Errorf("project %q already exists", existing.Name)
+	}
+
+	a.Projects = append(a.Projects, project)
+	a.touch()
+	return nil
+}
+
+// GetProjectByID はIDでプロジェクトを取得する
+func (a *AppData) GetProjectByID(id string) (*Project, error) {
+	for _, project := range a.Projects {
+		if project.ID == id {
+			return project, nil
+		}
+	}
+	return nil, errors.New("project not found")
+}
+
+// FindProject は名前（大文字小文字は区別しない）またはIDでプロジェクトを取得する
+func (a *AppData) FindProject(nameOrID string) (*Project, error) {
+	for _, project := range a.Projects {
+		if strings.EqualFold(project.Name, nameOrID) || project.ID == nameOrID {
+			return project, nil
+		}
+	}
+	return nil, fmt.Errorf("project %q not found", nameOrID)
+}
+
+// DeleteProject はタスクのないプロジェクトを削除する（既定のプロジェクトは削除できない）
+func (a *AppData) DeleteProject(id string) error {
+	project, err := a.GetProjectByID(id)
+	if err != nil {
+		return err
+	}
+	if project.IsDefault() {
+		return errors.New("the default project cannot be deleted")
+	}
+	if count := len(a.GetProjectTasks(id)); count > 0 {
+		return fmt.Errorf("project %q still has %d tasks: move or delete them first", project.Name, count)
+	}
+
+	for i, p := range a.Projects {
+		if p.ID == id {
+			a.Projects = append(a.Projects[:i], a.Projects[i+1:]...)
+			break
+		}
+	}
+	a.touch()
+	return nil
+}
+
+// GetProjectTasks は指定したプロジェクトのタスクを返す
+func (a *AppData) GetProjectTasks(projectID string) []*Task {
+	var tasks []*Task
+	for _, task := range a.Tasks {
+		if task.ProjectID == projectID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// EnsureDefaultProject は既定のプロジェクトがなければ追加し、プロジェクトのないタスクを既定のプロジェクトに入れる
+// プロジェクト導入前に記録されたタスクを読み込んだ場合に使用する
+func (a *AppData) EnsureDefaultProject() {
+	if _, err := a.GetProjectByID(DefaultProjectID); err != nil {
+		a.Projects = append([]*Project{NewDefaultProject(a.CreatedAt)}, a.Projects...)
+	}
+	for _, task := range a.Tasks {
+		if task.ProjectID == "" {
+			task.ProjectID = DefaultProjectID
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProject_ShouldValidateNameAndColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		project string
+		color   string
+		wantErr string
+	}{
+		{"valid", "Work", "blue", ""},
+		{"hex color", "Work", "#1e90ff", ""},
+		{"empty name", " ", "", "project name is required"},
+		{"padded name", " Work", "", "must not start or end with spaces"},
+		{"invalid color", "Work", "not a color", "invalid project color"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When
+			project, err := NewProject(tc.project, tc.color, []string{"job"})
+
+			// Then
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, project.ID)
+			assert.Equal(t, []string{"job"}, project.DefaultTags)
+			assert.False(t, project.IsDefault())
+		})
+	}
+}
+
+func TestAppData_NewAppData_ShouldHaveDefaultProject(t *testing.T) {
+	// When
+	data := NewAppData()
+
+	// Then
+	require.Len(t, data.Projects, 1)
+	assert.True(t, data.Projects[0].IsDefault())
+	assert.Equal(t, DefaultProjectName, data.Projects[0].Name)
+}
+
+func TestAppData_AddProject_WithDuplicateName_ShouldFail(t *testing.T) {
+	// Given
+	data := NewAppData()
+	work, _ := NewProject("Work", "", nil)
+	require.NoError(t, data.AddProject(work))
+	duplicate, _ := NewProject("work", "", nil)
+
+	// When
+	err := data.AddProject(duplicate)
+
+	// Then
+	assert.ErrorContains(t, err, `project "Work" already exists`)
+	found, err := data.FindProject("WORK")
+	require.NoError(t, err)
+	assert.Equal(t, work.ID, found.ID)
+	found, err = data.FindProject(work.ID)
+	require.NoError(t, err)
+	assert.Equal(t, work, found)
+}
+
+func TestAppData_DeleteProject_ShouldRefuseDefaultAndNonEmptyProjects(t *testing.T) {
+	// Given
+	data := NewAppData()
+	work, _ := NewProject("Work", "", nil)
+	require.NoError(t, data.AddProject(work))
+	task, _ := NewTask("Report", "", PriorityLow, nil)
+	task.ProjectID = work.ID
+	require.NoError(t, data.AddTask(task))
+
+	// When / Then
+	assert.ErrorContains(t, data.DeleteProject(DefaultProjectID), "cannot be deleted")
+	assert.ErrorContains(t, data.DeleteProject(work.ID), "still has 1 tasks")
+
+	require.NoError(t, data.DeleteTask(task.ID))
+	require.NoError(t, data.DeleteProject(work.ID))
+	assert.Len(t, data.Projects, 1)
+}
+
+func TestAppData_EnsureDefaultProject_ShouldAdoptTasksWithoutProject(t *testing.T) {
+	// Given
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	orphan, _ := NewTask("Orphan", "", PriorityLow, nil)
+	data := &AppData{CreatedAt: createdAt, Tasks: []*Task{orphan}}
+
+	// When
+	data.EnsureDefaultProject()
+	data.EnsureDefaultProject()
+
+	// Then
+	require.Len(t, data.Projects, 1)
+	assert.Equal(t, createdAt, data.Projects[0].CreatedAt)
+	assert.Equal(t, DefaultProjectID, orphan.ProjectID)
+	assert.Equal(t, []*Task{orphan}, data.GetProjectTasks(DefaultProjectID))
+}
@@ -0,0 +1,324 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecurrence は繰り返しルールが不正なことを表す
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// Frequency は繰り返しの単位を定義
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+	FrequencyYearly  Frequency = "yearly"
+)
+
+// frequencyUnits は Frequency と "every N <unit>" 形式の単位の対応
+var frequencyUnits = map[Frequency]string{
+	FrequencyDaily:   "day",
+	FrequencyWeekly:  "week",
+	FrequencyMonthly: "month",
+	FrequencyYearly:  "year",
+}
+
+// weekdayNames は曜日の短縮名（RRULEの BYDAY では先頭2文字を使う）
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Recurrence はタスクの繰り返しルールを定義する
+type Recurrence struct {
+	Frequency Frequency `json:"frequency"`
+
+	// Interval は繰り返しの間隔（0と1はどちらも毎回を表す）
+	Interval int `json:"interval,omitempty"`
+
+	// Weekdays は毎週の繰り返しで対象とする曜日（空の場合は期限日と同じ曜日）
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+
+	// FromCompletion が true の場合、次の期限日を期限日ではなく完了日から数える
+	FromCompletion bool `json:"from_completion,omitempty"`
+
+	// DayOfMonth は毎月・毎年の繰り返しで基準とする日（0の場合は数え始める日時の日）
+	// 月末に丸めた期限日から次の回を数えても、元の日（31日など）に戻れるようにする
+	DayOfMonth int `json:"day_of_month,omitempty"`
+}
+
+// ParseRecurrence は繰り返しルールの文字列を解析する
+// "daily" などのキーワード、"every 2 weeks on mon,fri"、"every 3 days after completion"、
+// RRULE形式の "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR" を受け付ける
+func ParseRecurrence(s string) (*Recurrence, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(s), " "))
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRecurrence)
+	}
+
+	var (
+		rule *Recurrence
+		err  error
+	)
+	if strings.HasPrefix(normalized, "rrule:") || strings.HasPrefix(normalized, "freq=") {
+		rule, err = parseRRule(strings.TrimPrefix(normalized, "rrule:"))
+	} else {
+		rule, err = parseEvery(normalized)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidRecurrence, s, err)
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// parseEvery は "every N <unit> [on <days>] [after completion]" 形式とキーワードを解析する
+func parseEvery(s string) (*Recurrence, error) {
+	rule := &Recurrence{}
+	if rest, ok := strings.CutSuffix(s, " after completion"); ok {
+		rule.FromCompletion = true
+		s = rest
+	}
+
+	switch s {
+	case "daily", "weekly", "monthly", "yearly":
+		rule.Frequency = Frequency(s)
+		return rule, nil
+	case "weekdays", "every weekday":
+		rule.Frequency = FrequencyWeekly
+		rule.Weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		return rule, nil
+	}
+
+	rest, ok := strings.CutPrefix(s, "every ")
+	if !ok {
+		return nil, errors.New(`expected "daily", "weekly", "monthly", "yearly" or "every ..."`)
+	}
+	fields := strings.Fields(rest)
+
+	// "every mon,fri" は毎週の指定曜日を表す
+	if weekdays, err := parseWeekdays(strings.Join(fields, "")); err == nil {
+		rule.Frequency = FrequencyWeekly
+		rule.Weekdays = weekdays
+		return rule, nil
+	}
+
+	if interval, err := strconv.Atoi(fields[0]); err == nil {
+		rule.Interval = interval
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("missing unit (day, week, month or year)")
+	}
+
+	unit := strings.TrimSuffix(fields[0], "s")
+	for frequency, name := range frequencyUnits {
+		if name == unit {
+			rule.Frequency = frequency
+		}
+	}
+	if rule.Frequency == "" {
+		return nil, fmt.Errorf("unknown unit %q", fields[0])
+	}
+
+	fields = fields[1:]
+	if len(fields) > 0 {
+		if fields[0] != "on" || len(fields) == 1 {
+			return nil, fmt.Errorf("unexpected %q", strings.Join(fields, " "))
+		}
+		weekdays, err := parseWeekdays(strings.Join(fields[1:], ""))
+		if err != nil {
+			return nil, err
+		}
+		rule.Weekdays = weekdays
+	}
+	return rule, nil
+}
+
+// parseRRule は RRULE の FREQ、INTERVAL、BYDAY を解析する
+func parseRRule(s string) (*Recurrence, error) {
+	rule := &Recurrence{}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed part %q", part)
+		}
+		switch key {
+		case "freq":
+			rule.Frequency = Frequency(value)
+		case "interval":
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid interval %q", value)
+			}
+			rule.Interval = interval
+		case "byday":
+			weekdays, err := parseWeekdays(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Weekdays = weekdays
+		default:
+			return nil, fmt.Errorf("unsupported part %q", strings.ToUpper(key))
+		}
+	}
+	return rule, nil
+}
+
+// parseWeekdays はカンマ区切りの曜日名（"mon"、"monday"、"mo"）を解析する
+func parseWeekdays(s string) ([]time.Weekday, error) {
+	var weekdays []time.Weekday
+	for _, name := range strings.Split(s, ",") {
+		weekday, ok := lookupWeekday(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		weekdays = append(weekdays, weekday)
+	}
+	return weekdays, nil
+}
+
+// lookupWeekday は曜日名を time.Weekday に変換する
+func lookupWeekday(name string) (time.Weekday, bool) {
+	if len(name) < 2 {
+		return 0, false
+	}
+	for i, short := range weekdayNames {
+		full := strings.ToLower(time.Weekday(i).String())
+		if name == short || name == full || name == short[:2] {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// Validate は繰り返しルールの値を検証する
+func (r *Recurrence) Validate() error {
+	if _, ok := frequencyUnits[r.Frequency]; !ok {
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidRecurrence, r.Frequency)
+	}
+	if r.Interval < 0 {
+		return fmt.Errorf("%w: interval must not be negative", ErrInvalidRecurrence)
+	}
+	if len(r.Weekdays) > 0 && r.Frequency != FrequencyWeekly {
+		return fmt.Errorf("%w: weekdays can only be used with weekly rules", ErrInvalidRecurrence)
+	}
+	for _, weekday := range r.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return fmt.Errorf("%w: invalid weekday %d", ErrInvalidRecurrence, weekday)
+		}
+	}
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+		return fmt.Errorf("%w: day of month must be between 1 and 31", ErrInvalidRecurrence)
+	}
+	return nil
+}
+
+// String は ParseRecurrence で解析できる "every ..." 形式の文字列を返す
+func (r *Recurrence) String() string {
+	if r == nil {
+		return ""
+	}
+
+	unit := frequencyUnits[r.Frequency]
+	s := "every " + unit
+	if r.Interval > 1 {
+		s = fmt.Sprintf("every %d %ss", r.Interval, unit)
+	}
+	if len(r.Weekdays) > 0 {
+		names := make([]string, len(r.Weekdays))
+		for i, weekday := range r.Weekdays {
+			names[i] = weekdayNames[weekday]
+		}
+		s += " on " + strings.Join(names, ",")
+	}
+	if r.FromCompletion {
+		s += " after completion"
+	}
+	return s
+}
+
+// Next は after より後の次の発生日時を返す（時刻は after のものを保つ）
+// 毎月・毎年の繰り返しは DayOfMonth（0の場合は after の日）の日とし、月末を超える日付はその月の末日に丸める
+func (r *Recurrence) Next(after time.Time) time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case FrequencyWeekly:
+		if len(r.Weekdays) == 0 {
+			return after.AddDate(0, 0, 7*interval)
+		}
+		return r.nextWeekday(after, interval)
+	case FrequencyMonthly:
+		return addMonthsClamped(after, interval, r.anchorDay(after))
+	case FrequencyYearly:
+		return addMonthsClamped(after, 12*interval, r.anchorDay(after))
+	default:
+		return after.AddDate(0, 0, interval)
+	}
+}
+
+// nextWeekday は同じ週の残りの対象曜日、なければ interval 週後の週の最初の対象曜日を返す
+// 週は月曜日から始まる
+func (r *Recurrence) nextWeekday(after time.Time, interval int) time.Time {
+	included := make(map[time.Weekday]bool, len(r.Weekdays))
+	for _, weekday := range r.Weekdays {
+		included[weekday] = true
+	}
+
+	offset := (int(after.Weekday()) + 6) % 7
+	for d := 1; d < 7-offset; d++ {
+		if candidate := after.AddDate(0, 0, d); included[candidate.Weekday()] {
+			return candidate
+		}
+	}
+
+	weekStart := after.AddDate(0, 0, 7*interval-offset)
+	for d := 0; d < 7; d++ {
+		if candidate := weekStart.AddDate(0, 0, d); included[candidate.Weekday()] {
+			return candidate
+		}
+	}
+	return weekStart
+}
+
+// anchorDay は毎月・毎年の繰り返しで基準とする日を返す
+func (r *Recurrence) anchorDay(after time.Time) int {
+	if r.DayOfMonth > 0 {
+		return r.DayOfMonth
+	}
+	return after.Day()
+}
+
+// Anchor は t を最初の回として数える場合の基準の日を記録したルールの複製を返す
+// 毎月・毎年以外のルール、完了日から数えるルール、既に基準の日があるルールはそのまま複製する
+func (r *Recurrence) Anchor(t time.Time) *Recurrence {
+	anchored := *r
+	anchored.Weekdays = append([]time.Weekday(nil), r.Weekdays...)
+	if anchored.DayOfMonth == 0 && !r.FromCompletion && (r.Frequency == FrequencyMonthly || r.Frequency == FrequencyYearly) {
+		anchored.DayOfMonth = t.Day()
+	}
+	return &anchored
+}
+
+// addMonthsClamped は months か月後の day 日を返し、存在しない日は月末に丸める
+func addMonthsClamped(t time.Time, months, day int) time.Time {
+	year, month, _ := t.Date()
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
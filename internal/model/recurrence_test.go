@@ -0,0 +1,160 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence_ShouldAcceptKeywordsPhrasesAndRRules(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Recurrence
+	}{
+		{input: "daily", want: Recurrence{Frequency: FrequencyDaily}},
+		{input: "Weekly", want: Recurrence{Frequency: FrequencyWeekly}},
+		{input: "every month", want: Recurrence{Frequency: FrequencyMonthly}},
+		{input: "every 2 years", want: Recurrence{Frequency: FrequencyYearly, Interval: 2}},
+		{input: "every 10 days after completion", want: Recurrence{Frequency: FrequencyDaily, Interval: 10, FromCompletion: true}},
+		{input: "every 2 weeks on mon, fri", want: Recurrence{Frequency: FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Monday, time.Friday}}},
+		{input: "every tuesday", want: Recurrence{Frequency: FrequencyWeekly, Weekdays: []time.Weekday{time.Tuesday}}},
+		{input: "weekdays", want: Recurrence{Frequency: FrequencyWeekly, Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}},
+		{input: "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", want: Recurrence{Frequency: FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Monday, time.Friday}}},
+		{input: "FREQ=MONTHLY", want: Recurrence{Frequency: FrequencyMonthly}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// When
+			rule, err := ParseRecurrence(tt.input)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *rule)
+		})
+	}
+}
+
+func TestParseRecurrence_WithInvalidRule_ShouldReturnError(t *testing.T) {
+	for _, input := range []string{"", "sometimes", "every", "every 3", "every 2 fortnights", "every month on mon", "FREQ=HOURLY", "FREQ=DAILY;COUNT=3", "every -1 days"} {
+		t.Run(input, func(t *testing.T) {
+			// When
+			_, err := ParseRecurrence(input)
+
+			// Then
+			assert.ErrorIs(t, err, ErrInvalidRecurrence)
+		})
+	}
+}
+
+func TestRecurrence_String_ShouldRoundTrip(t *testing.T) {
+	for _, input := range []string{"every day", "every 3 weeks on mon,thu", "every 10 days after completion", "every year"} {
+		t.Run(input, func(t *testing.T) {
+			// Given
+			rule, err := ParseRecurrence(input)
+			require.NoError(t, err)
+
+			// When
+			formatted := rule.String()
+
+			// Then
+			assert.Equal(t, input, formatted)
+			reparsed, err := ParseRecurrence(formatted)
+			require.NoError(t, err)
+			assert.Equal(t, rule, reparsed)
+		})
+	}
+}
+
+func TestRecurrence_Next_ShouldAdvanceByRule(t *testing.T) {
+	// 2026-10-14 は水曜日
+	wednesday := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		rule Recurrence
+		from time.Time
+		want time.Time
+	}{
+		{name: "daily", rule: Recurrence{Frequency: FrequencyDaily}, from: wednesday, want: date(2026, 10, 15)},
+		{name: "every 3 days", rule: Recurrence{Frequency: FrequencyDaily, Interval: 3}, from: wednesday, want: date(2026, 10, 17)},
+		{name: "weekly", rule: Recurrence{Frequency: FrequencyWeekly}, from: wednesday, want: date(2026, 10, 21)},
+		{name: "later weekday in the same week", rule: Recurrence{Frequency: FrequencyWeekly, Weekdays: []time.Weekday{time.Monday, time.Friday}}, from: wednesday, want: date(2026, 10, 16)},
+		{name: "first weekday of the next week", rule: Recurrence{Frequency: FrequencyWeekly, Weekdays: []time.Weekday{time.Monday}}, from: wednesday, want: date(2026, 10, 19)},
+		{name: "sunday ends the week", rule: Recurrence{Frequency: FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Tuesday, time.Sunday}}, from: wednesday, want: date(2026, 10, 18)},
+		{name: "skips interval weeks", rule: Recurrence{Frequency: FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Tuesday}}, from: wednesday, want: date(2026, 10, 27)},
+		{name: "monthly", rule: Recurrence{Frequency: FrequencyMonthly}, from: wednesday, want: date(2026, 11, 14)},
+		{name: "monthly clamps to month end", rule: Recurrence{Frequency: FrequencyMonthly}, from: date(2027, 1, 31), want: date(2027, 2, 28)},
+		{name: "yearly clamps leap day", rule: Recurrence{Frequency: FrequencyYearly}, from: date(2028, 2, 29), want: date(2029, 2, 28)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			next := tt.rule.Next(tt.from)
+
+			// Then
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func TestRecurrence_Next_WithAnchor_ShouldNotDriftAfterClamping(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		rule  Recurrence
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "31st of every month",
+			rule:  Recurrence{Frequency: FrequencyMonthly},
+			start: date(2026, 1, 31),
+			want:  []time.Time{date(2026, 2, 28), date(2026, 3, 31), date(2026, 4, 30), date(2026, 5, 31)},
+		},
+		{
+			name:  "leap day every year",
+			rule:  Recurrence{Frequency: FrequencyYearly},
+			start: date(2028, 2, 29),
+			want:  []time.Time{date(2029, 2, 28), date(2030, 2, 28), date(2031, 2, 28), date(2032, 2, 29)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			rule := tt.rule.Anchor(tt.start)
+
+			// When - 前の回の期限日から順に数える
+			var got []time.Time
+			for due := tt.start; len(got) < len(tt.want); {
+				due = rule.Next(due)
+				got = append(got, due)
+			}
+
+			// Then - 月末に丸めた後も元の日に戻る
+			assert.Equal(t, tt.start.Day(), rule.DayOfMonth)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecurrence_Anchor_ShouldOnlyAnchorMonthlyAndYearlyRulesFromDueDate(t *testing.T) {
+	// Given
+	due := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	// When & Then
+	assert.Equal(t, 0, (&Recurrence{Frequency: FrequencyWeekly}).Anchor(due).DayOfMonth)
+	assert.Equal(t, 0, (&Recurrence{Frequency: FrequencyMonthly, FromCompletion: true}).Anchor(due).DayOfMonth)
+	assert.Equal(t, 15, (&Recurrence{Frequency: FrequencyMonthly, DayOfMonth: 15}).Anchor(due).DayOfMonth)
+	assert.Error(t, (&Recurrence{Frequency: FrequencyMonthly, DayOfMonth: 32}).Validate())
+}
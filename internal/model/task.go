@@ -2,6 +2,9 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,16 +12,26 @@ import (
 
 // Task はタスクの基本構造を定義
 type Task struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      Status     `json:"status"`
-	Priority    Priority   `json:"priority"`
-	Tags        []string   `json:"tags"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Status      Status      `json:"status"`
+	Priority    Priority    `json:"priority"`
+	Tags        []string    `json:"tags"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+	DueDate     *time.Time  `json:"due_date,omitempty"`
+	ProjectID   string      `json:"project_id"`
+	ParentID    string      `json:"parent_id,omitempty"`
+	BlockedBy   []string    `json:"blocked_by,omitempty"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
+	SeriesID    string      `json:"series_id,omitempty"`
+	Estimate    int         `json:"estimate_minutes,omitempty"`
+	Revision    int64       `json:"revision"`
+
+	// Changes はTaskServiceを通じて行われた変更の履歴（古い順）
+	Changes []ChangeEntry `json:"changes,omitempty"`
 }
 
 // Status はタスクのステータスを定義
@@ -51,6 +64,7 @@ func NewTask(title, description string, priority Priority, tags []string) (*Task
 		Tags:        tags,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Revision:    1,
 	}
 
 	if err := task.Validate(); err != nil {
@@ -100,6 +114,66 @@ func (s Status) String() string {
 	return string(s)
 }
 
+// ParseStatus は文字列をStatusに変換する（大文字小文字、ハイフン区切りも受け付ける）
+func ParseStatus(s string) (Status, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_")
+	switch normalized {
+	case "todo":
+		return StatusTodo, nil
+	case "in_progress", "doing", "wip":
+		return StatusInProgress, nil
+	case "completed", "done":
+		return StatusCompleted, nil
+	default:
+		return "", fmt.Errorf("invalid status %q: must be one of todo, in_progress, completed", s)
+	}
+}
+
+// ParsePriority は文字列をPriorityに変換する（h/m/l の短縮形も受け付ける）
+func ParsePriority(s string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low", "l":
+		return PriorityLow, nil
+	case "medium", "med", "m":
+		return PriorityMedium, nil
+	case "high", "h":
+		return PriorityHigh, nil
+	default:
+		return "", fmt.Errorf("invalid priority %q: must be one of low, medium, high", s)
+	}
+}
+
+// ParseEstimate は "2h"、"90m"、"1h30m"、"1.5h" のような見積もり時間を分に変換する
+// 単位のない数値は分として扱う
+func ParseEstimate(s string) (int, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(s))
+	if minutes, err := strconv.Atoi(trimmed); err == nil {
+		trimmed = fmt.Sprintf("%dm", minutes)
+	}
+
+	duration, err := time.ParseDuration(trimmed)
+	if err != nil || duration < time.Minute {
+		return 0, fmt.Errorf("invalid estimate %q: use a duration like 30m, 2h or 1h30m", s)
+	}
+	return int(duration.Round(time.Minute) / time.Minute), nil
+}
+
+// FormatEstimate は分単位の見積もり時間を "1h30m" の形式で返す（0の場合は空文字列）
+func FormatEstimate(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	hours, rest := minutes/60, minutes%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", rest)
+	case rest == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, rest)
+	}
+}
+
 // IsValid はPriorityが有効かを検証する
 func (p Priority) IsValid() bool {
 	switch p {
@@ -115,7 +189,87 @@ func (p Priority) String() string {
 	return string(p)
 }
 
+// Rank は優先度の大小を比較するための順位を返す（low < medium < high、不正な値は0）
+func (p Priority) Rank() int {
+	switch p {
+	case PriorityLow:
+		return 1
+	case PriorityMedium:
+		return 2
+	case PriorityHigh:
+		return 3
+	default:
+		return 0
+	}
+}
+
 // IsCompleted はタスクが完了しているかを返す
 func (t *Task) IsCompleted() bool {
 	return t.Status == StatusCompleted
-}
\ No newline at end of file
+}
+
+// Clone はスライスやポインタのフィールドも複製したタスクのコピーを返す
+func (t *Task) Clone() *Task {
+	clone := *t
+	clone.Tags = cloneSlice(t.Tags)
+	clone.BlockedBy = cloneSlice(t.BlockedBy)
+	if t.CompletedAt != nil {
+		completedAt := *t.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+	if t.DueDate != nil {
+		dueDate := *t.DueDate
+		clone.DueDate = &dueDate
+	}
+	if t.Recurrence != nil {
+		recurrence := *t.Recurrence
+		recurrence.Weekdays = cloneSlice(t.Recurrence.Weekdays)
+		clone.Recurrence = &recurrence
+	}
+	clone.Changes = cloneChanges(t.Changes)
+	return &clone
+}
+
+// cloneSlice はスライスのコピーを返す（nilはnilのまま）
+func cloneSlice[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+	return append([]T{}, values...)
+}
+
+// DueSoonDays は期限が近いとみなす日数（今日を含む）
+const DueSoonDays = 2
+
+// DueStatus は期限日に対するタスクの状態を定義
+type DueStatus int
+
+const (
+	// DueNone は期限日がない、または完了済みであることを表す
+	DueNone DueStatus = iota
+	// DueLater は期限まで余裕があることを表す
+	DueLater
+	// DueSoon は期限が今日か明日であることを表す
+	DueSoon
+	// DueOverdue は期限日を過ぎていることを表す
+	DueOverdue
+)
+
+// DueStatus は now 時点での期限日に対する状態を返す
+// 期限日は日単位で扱い、期限日の当日中は期限切れとしない
+func (t *Task) DueStatus(now time.Time) DueStatus {
+	if t.DueDate == nil || t.IsCompleted() {
+		return DueNone
+	}
+
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	switch {
+	case t.DueDate.Before(today):
+		return DueOverdue
+	case t.DueDate.Before(today.AddDate(0, 0, DueSoonDays)):
+		return DueSoon
+	default:
+		return DueLater
+	}
+}
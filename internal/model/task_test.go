@@ -217,4 +217,189 @@ func TestPriority_IsValid_WithInvalidPriority_ShouldReturnFalse(t *testing.T) {
 			assert.False(t, result)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParseStatus_WithAliases_ShouldReturnStatus(t *testing.T) {
+	testCases := map[string]Status{
+		"todo":        StatusTodo,
+		"TODO":        StatusTodo,
+		"in_progress": StatusInProgress,
+		"in-progress": StatusInProgress,
+		"done":        StatusCompleted,
+		"completed":   StatusCompleted,
+	}
+
+	for input, expected := range testCases {
+		// When
+		status, err := ParseStatus(input)
+
+		// Then
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, status, input)
+	}
+}
+
+func TestParseStatus_WithInvalidValue_ShouldReturnError(t *testing.T) {
+	// When
+	_, err := ParseStatus("someday")
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status")
+}
+
+func TestParsePriority_WithAliases_ShouldReturnPriority(t *testing.T) {
+	testCases := map[string]Priority{
+		"low":    PriorityLow,
+		"l":      PriorityLow,
+		"Medium": PriorityMedium,
+		"m":      PriorityMedium,
+		"HIGH":   PriorityHigh,
+		"h":      PriorityHigh,
+	}
+
+	for input, expected := range testCases {
+		// When
+		priority, err := ParsePriority(input)
+
+		// Then
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, priority, input)
+	}
+}
+
+func TestParsePriority_WithInvalidValue_ShouldReturnError(t *testing.T) {
+	// When
+	_, err := ParsePriority("urgent")
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid priority")
+}
+
+func TestParseEstimate_ShouldConvertToMinutes(t *testing.T) {
+	testCases := map[string]int{
+		"30m":   30,
+		"2h":    120,
+		"1h30m": 90,
+		"1.5h":  90,
+		"45":    45,
+		" 2H ":  120,
+	}
+
+	for input, expected := range testCases {
+		// When
+		minutes, err := ParseEstimate(input)
+
+		// Then
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, minutes, input)
+	}
+}
+
+func TestParseEstimate_WithInvalidValue_ShouldReturnError(t *testing.T) {
+	for _, input := range []string{"", "soon", "-1h", "0", "30s"} {
+		// When
+		_, err := ParseEstimate(input)
+
+		// Then
+		assert.Error(t, err, input)
+	}
+}
+
+func TestFormatEstimate_ShouldUseHoursAndMinutes(t *testing.T) {
+	assert.Equal(t, "", FormatEstimate(0))
+	assert.Equal(t, "45m", FormatEstimate(45))
+	assert.Equal(t, "2h", FormatEstimate(120))
+	assert.Equal(t, "1h30m", FormatEstimate(90))
+}
+
+func TestTask_DueStatus_ShouldCompareByDay(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		due := time.Date(2026, 10, 14+offset, 0, 0, 0, 0, time.UTC)
+		return &due
+	}
+
+	testCases := []struct {
+		name     string
+		due      *time.Time
+		status   Status
+		expected DueStatus
+	}{
+		{name: "no due date", due: nil, status: StatusTodo, expected: DueNone},
+		{name: "yesterday", due: day(-1), status: StatusTodo, expected: DueOverdue},
+		{name: "today", due: day(0), status: StatusTodo, expected: DueSoon},
+		{name: "tomorrow", due: day(1), status: StatusInProgress, expected: DueSoon},
+		{name: "in two days", due: day(2), status: StatusTodo, expected: DueLater},
+		{name: "completed", due: day(-1), status: StatusCompleted, expected: DueNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			task := &Task{Title: "Task", Status: tc.status, DueDate: tc.due}
+
+			// When
+			status := task.DueStatus(now)
+
+			// Then
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func TestTask_Clone_ShouldNotShareMutableFields(t *testing.T) {
+	// Given
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	task := &Task{
+		ID:         "1",
+		Title:      "Original",
+		Tags:       []string{"work"},
+		BlockedBy:  []string{"2"},
+		DueDate:    &due,
+		Recurrence: &Recurrence{Frequency: FrequencyWeekly, Weekdays: []time.Weekday{time.Monday}},
+		Changes: []ChangeEntry{
+			{Action: ChangeUpdated, Fields: []FieldChange{{Field: "title", Old: "Draft", New: "Original"}}},
+		},
+	}
+
+	// When
+	clone := task.Clone()
+	clone.Title = "Changed"
+	clone.Tags[0] = "home"
+	clone.BlockedBy[0] = "3"
+	*clone.DueDate = due.AddDate(0, 0, 1)
+	clone.Recurrence.Weekdays[0] = time.Friday
+	clone.Changes[0].Fields[0].New = "Changed"
+
+	// Then
+	assert.Equal(t, "Original", task.Title)
+	assert.Equal(t, []string{"work"}, task.Tags)
+	assert.Equal(t, []string{"2"}, task.BlockedBy)
+	assert.Equal(t, due, *task.DueDate)
+	assert.Equal(t, []time.Weekday{time.Monday}, task.Recurrence.Weekdays)
+	assert.Equal(t, "Original", task.Changes[0].Fields[0].New)
+}
+
+func TestTask_AddChange_ShouldKeepOnlyNewestChanges(t *testing.T) {
+	// Given
+	task := &Task{ID: "1"}
+
+	// When
+	for _, actor := range []string{"first", "second", "third"} {
+		task.AddChange(ChangeEntry{Actor: actor, Action: ChangeUpdated}, 2)
+	}
+
+	// Then - 上限を超えた古い変更は捨てられる
+	assert.Len(t, task.Changes, 2)
+	assert.Equal(t, "second", task.Changes[0].Actor)
+	assert.Equal(t, "third", task.Changes[1].Actor)
+}
+
+func TestPriority_Rank_ShouldOrderFromLowToHigh(t *testing.T) {
+	// When & Then
+	assert.Equal(t, 0, Priority("urgent").Rank())
+	assert.Less(t, PriorityLow.Rank(), PriorityMedium.Rank())
+	assert.Less(t, PriorityMedium.Rank(), PriorityHigh.Rank())
+}
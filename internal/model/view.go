@@ -0,0 +1,46 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// maxViewNameLength はビュー名の最大文字数
+const maxViewNameLength = 50
+
+// View は名前を付けて保存したフィルター（スマートビュー）
+// 条件はクエリ言語の文字列で保持し、相対的な日付は使用するたびに解釈する
+type View struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// ValidateViewName はビュー名が有効かを検証する
+func ValidateViewName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("view name is required")
+	}
+	if name != strings.TrimSpace(name) {
+		return errors.New("view name must not start or end with spaces")
+	}
+	if len([]rune(name)) > maxViewNameLength {
+		return errors.New("view name must be 50 characters or less")
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return errors.New("view name must not contain control characters")
+		}
+	}
+	return nil
+}
+
+// FindView は名前が一致するビューのインデックスを返す（大文字小文字は区別しない、ない場合は-1）
+func FindView(views []View, name string) int {
+	for i, view := range views {
+		if strings.EqualFold(view.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateViewName_ShouldRejectInvalidNames(t *testing.T) {
+	assert.NoError(t, ValidateViewName("My backend work"))
+
+	for _, name := range []string{"", "   ", " padded", "line\nbreak", string(make([]rune, 51))} {
+		assert.Error(t, ValidateViewName(name), "%q", name)
+	}
+}
+
+func TestFindView_ShouldIgnoreCase(t *testing.T) {
+	// Given
+	views := []View{{Name: "Due this week"}, {Name: "My backend work"}}
+
+	// When & Then
+	assert.Equal(t, 1, FindView(views, "my BACKEND work"))
+	assert.Equal(t, -1, FindView(views, "backend"))
+}
@@ -0,0 +1,303 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"task-cli/internal/dateparse"
+	"task-cli/internal/model"
+)
+
+// Env は条件の評価に使う情報
+type Env struct {
+	Now  time.Time
+	Data *model.AppData // ブロック状態やサブタスクの判定に使う（nilの場合はどちらも偽）
+}
+
+// Node はクエリの構文木のノード
+type Node interface {
+	// Match はタスクが条件を満たすかを返す
+	Match(task *model.Task, env Env) bool
+	// String は条件をクエリの構文で返す
+	String() string
+}
+
+// And はすべての条件を満たすことを表す
+type And struct {
+	Nodes []Node
+}
+
+// Match はすべての条件を満たすかを返す
+func (n *And) Match(task *model.Task, env Env) bool {
+	for _, node := range n.Nodes {
+		if !node.Match(task, env) {
+			return false
+		}
+	}
+	return true
+}
+
+// String は条件を空白区切りで返す
+func (n *And) String() string {
+	return joinNodes(n.Nodes, " ")
+}
+
+// Or はいずれかの条件を満たすことを表す
+type Or struct {
+	Nodes []Node
+}
+
+// Match はいずれかの条件を満たすかを返す
+func (n *Or) Match(task *model.Task, env Env) bool {
+	for _, node := range n.Nodes {
+		if node.Match(task, env) {
+			return true
+		}
+	}
+	return false
+}
+
+// String は条件を OR で区切り、括弧で囲んで返す
+func (n *Or) String() string {
+	return "(" + joinNodes(n.Nodes, " OR ") + ")"
+}
+
+// Not は条件を満たさないことを表す
+type Not struct {
+	Node Node
+}
+
+// Match は条件を満たさないかを返す
+func (n *Not) Match(task *model.Task, env Env) bool {
+	return !n.Node.Match(task, env)
+}
+
+// String は条件に - を付けて返す
+func (n *Not) String() string {
+	return "-" + n.Node.String()
+}
+
+// Text はタイトルか説明に語句を含むことを表す（大文字小文字は区別しない）
+type Text struct {
+	Text string
+}
+
+// Match はタイトルか説明に語句を含むかを返す
+func (n *Text) Match(task *model.Task, env Env) bool {
+	text := strings.ToLower(n.Text)
+	return strings.Contains(strings.ToLower(task.Title), text) ||
+		strings.Contains(strings.ToLower(task.Description), text)
+}
+
+// String は語句を返す（空白などを含む場合は引用符で囲む）
+func (n *Text) String() string {
+	return quote(n.Text)
+}
+
+// Field は比較できるタスクの属性
+type Field string
+
+const (
+	FieldStatus      Field = "status"
+	FieldPriority    Field = "priority"
+	FieldTag         Field = "tag"
+	FieldTitle       Field = "title"
+	FieldDescription Field = "desc"
+	FieldDue         Field = "due"
+	FieldCreated     Field = "created"
+	FieldUpdated     Field = "updated"
+	FieldCompleted   Field = "completed"
+	FieldIs          Field = "is"
+)
+
+// Operator は比較演算子
+type Operator string
+
+const (
+	OpMatch    Operator = ":"
+	OpEqual    Operator = "="
+	OpNotEqual Operator = "!="
+	OpLess     Operator = "<"
+	OpLessEq   Operator = "<="
+	OpGreater  Operator = ">"
+	OpGreaterE Operator = ">="
+)
+
+// Comparison は属性と値の比較を表す
+// 値が複数の場合はいずれかに一致すれば真とする（!= の場合はどれにも一致しなければ真）
+type Comparison struct {
+	Field    Field
+	Operator Operator
+	Values   []string
+
+	statuses   []model.Status
+	priorities []model.Priority
+	dates      []*time.Time // nil は "none"（日付なし）を表す
+}
+
+// Match はタスクの属性が比較を満たすかを返す
+func (n *Comparison) Match(task *model.Task, env Env) bool {
+	switch n.Field {
+	case FieldPriority:
+		if n.Operator.isOrdered() {
+			return compareOrder(task.Priority.Rank()-n.priorities[0].Rank(), n.Operator)
+		}
+	case FieldDue, FieldCreated, FieldUpdated, FieldCompleted:
+		if n.Operator.isOrdered() {
+			value := taskDate(task, n.Field)
+			if value == nil {
+				return false
+			}
+			return compareOrder(compareDays(*value, *n.dates[0]), n.Operator)
+		}
+	}
+
+	matched := false
+	for i := range n.Values {
+		if n.matchValue(task, env, i) {
+			matched = true
+			break
+		}
+	}
+	if n.Operator == OpNotEqual {
+		return !matched
+	}
+	return matched
+}
+
+// matchValue は i 番目の値に一致するかを返す
+func (n *Comparison) matchValue(task *model.Task, env Env, i int) bool {
+	value := n.Values[i]
+	switch n.Field {
+	case FieldStatus:
+		return task.Status == n.statuses[i]
+	case FieldPriority:
+		return task.Priority == n.priorities[i]
+	case FieldTag:
+		for _, tag := range task.Tags {
+			if strings.EqualFold(tag, value) {
+				return true
+			}
+		}
+		return false
+	case FieldTitle:
+		return n.matchString(task.Title, value)
+	case FieldDescription:
+		return n.matchString(task.Description, value)
+	case FieldDue, FieldCreated, FieldUpdated, FieldCompleted:
+		date := taskDate(task, n.Field)
+		if n.dates[i] == nil || date == nil {
+			return n.dates[i] == nil && date == nil
+		}
+		return compareDays(*date, *n.dates[i]) == 0
+	case FieldIs:
+		return matchState(task, env, value)
+	}
+	return false
+}
+
+// matchString は : の場合は部分一致、それ以外は完全一致で比較する（大文字小文字は区別しない）
+func (n *Comparison) matchString(text, value string) bool {
+	if n.Operator == OpMatch {
+		return strings.Contains(strings.ToLower(text), strings.ToLower(value))
+	}
+	return strings.EqualFold(text, value)
+}
+
+// String は比較をクエリの構文で返す
+func (n *Comparison) String() string {
+	values := make([]string, len(n.Values))
+	for i, value := range n.Values {
+		values[i] = quote(value)
+	}
+	return string(n.Field) + string(n.Operator) + strings.Join(values, ",")
+}
+
+// states は is: で指定できるタスクの状態
+var states = []string{"open", "done", "overdue", "soon", "blocked", "recurring", "subtask"}
+
+// matchState はタスクが is: で指定した状態かを返す
+func matchState(task *model.Task, env Env, state string) bool {
+	switch state {
+	case "open":
+		return !task.IsCompleted()
+	case "done":
+		return task.IsCompleted()
+	case "overdue":
+		return task.DueStatus(env.Now) == model.DueOverdue
+	case "soon":
+		return task.DueStatus(env.Now) == model.DueSoon
+	case "blocked":
+		return !task.IsCompleted() && env.Data != nil && env.Data.IsBlocked(task.ID)
+	case "recurring":
+		return task.Recurrence != nil
+	case "subtask":
+		return task.ParentID != ""
+	}
+	return false
+}
+
+// isOrdered は大小比較の演算子かを返す
+func (op Operator) isOrdered() bool {
+	switch op {
+	case OpLess, OpLessEq, OpGreater, OpGreaterE:
+		return true
+	}
+	return false
+}
+
+// compareOrder は比較結果 diff（負: 小さい、0: 等しい、正: 大きい）が演算子を満たすかを返す
+func compareOrder(diff int, op Operator) bool {
+	switch op {
+	case OpLess:
+		return diff < 0
+	case OpLessEq:
+		return diff <= 0
+	case OpGreater:
+		return diff > 0
+	case OpGreaterE:
+		return diff >= 0
+	}
+	return false
+}
+
+// compareDays は日付を日単位で比較する（a のタイムゾーンで b の日付を求める）
+func compareDays(a, b time.Time) int {
+	dayA := dateparse.StartOfDay(a)
+	dayB := dateparse.StartOfDay(b.In(a.Location()))
+	return dayA.Compare(dayB)
+}
+
+// taskDate はフィールドに対応するタスクの日時を返す（ない場合はnil）
+func taskDate(task *model.Task, field Field) *time.Time {
+	switch field {
+	case FieldDue:
+		return task.DueDate
+	case FieldCreated:
+		return &task.CreatedAt
+	case FieldUpdated:
+		return &task.UpdatedAt
+	case FieldCompleted:
+		return task.CompletedAt
+	}
+	return nil
+}
+
+// joinNodes はノードの文字列表現を sep で連結する
+func joinNodes(nodes []Node, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = node.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// quote は空白や構文上の記号を含む値を引用符で囲む
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\"(),:<>=!") && !strings.HasPrefix(value, "-") &&
+		!strings.EqualFold(value, "or") && !strings.EqualFold(value, "and") {
+		return value
+	}
+	return fmt.Sprintf("%q", value)
+}
@@ -0,0 +1,102 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQueryFixture は検索対象のタスクを作成する
+func newQueryFixture() []*model.Task {
+	day := func(offset int) *time.Time {
+		d := time.Date(2026, 10, 14+offset, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	created := time.Date(2025, 12, 20, 9, 0, 0, 0, time.UTC)
+	completed := time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)
+
+	return []*model.Task{
+		{ID: "login", Title: "Fix login bug", Status: model.StatusTodo, Priority: model.PriorityHigh,
+			Tags: []string{"backend", "auth"}, DueDate: day(1), CreatedAt: created.AddDate(0, 1, 0)},
+		{ID: "docs", Title: "Write docs", Description: "Exact phrase inside", Status: model.StatusInProgress,
+			Priority: model.PriorityMedium, Tags: []string{"Docs"}, DueDate: day(-1), CreatedAt: created},
+		{ID: "deploy", Title: "Deploy", Status: model.StatusTodo, Priority: model.PriorityLow,
+			Tags: []string{"backend", "blocked"}, BlockedBy: []string{"login"}, CreatedAt: created.AddDate(0, 2, 0)},
+		{ID: "old", Title: "Old chore", Status: model.StatusCompleted, Priority: model.PriorityHigh,
+			DueDate: day(30), CreatedAt: created, CompletedAt: &completed, ParentID: "docs",
+			Recurrence: &model.Recurrence{Frequency: model.FrequencyWeekly}},
+	}
+}
+
+func TestQuery_Filter_ShouldMatchConditions(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{input: "", want: []string{"login", "docs", "deploy", "old"}},
+		{input: "status:todo,in_progress", want: []string{"login", "docs", "deploy"}},
+		{input: "status!=todo", want: []string{"docs", "old"}},
+		{input: "priority>=medium", want: []string{"login", "docs", "old"}},
+		{input: "priority<high", want: []string{"docs", "deploy"}},
+		{input: "tag:backend -tag:blocked", want: []string{"login"}},
+		{input: "tag:docs", want: []string{"docs"}},
+		{input: "due<7d", want: []string{"login", "docs"}},
+		{input: "due:none", want: []string{"deploy"}},
+		{input: "due!=none", want: []string{"login", "docs", "old"}},
+		{input: "due:tomorrow", want: []string{"login"}},
+		{input: "created>2026-01-01", want: []string{"login", "deploy"}},
+		{input: "created<=2025-12-20", want: []string{"docs", "old"}},
+		{input: "completed>=2026-10-10", want: []string{"old"}},
+		{input: `"exact phrase"`, want: []string{"docs"}},
+		{input: "LOGIN", want: []string{"login"}},
+		{input: "title:log", want: []string{"login"}},
+		{input: "title=deploy", want: []string{"deploy"}},
+		{input: "is:overdue", want: []string{"docs"}},
+		{input: "is:soon", want: []string{"login"}},
+		{input: "is:blocked", want: []string{"deploy"}},
+		{input: "is:recurring,subtask", want: []string{"old"}},
+		{input: "is:done", want: []string{"old"}},
+		{input: "tag:auth OR is:blocked", want: []string{"login", "deploy"}},
+		{input: "-(tag:backend OR is:done)", want: []string{"docs"}},
+		{input: "status:todo (priority:low OR due:none)", want: []string{"deploy"}},
+	}
+
+	tasks := newQueryFixture()
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Given
+			q, err := Parse(tt.input, now)
+			require.NoError(t, err)
+
+			// When
+			filtered := q.Filter(tasks)
+
+			// Then
+			ids := make([]string, 0, len(filtered))
+			for _, task := range filtered {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestQuery_Match_WithoutData_ShouldNotTreatTasksAsBlocked(t *testing.T) {
+	// Given
+	q, err := Parse("is:blocked", now)
+	require.NoError(t, err)
+	deploy := newQueryFixture()[2]
+
+	// When & Then
+	assert.False(t, q.Match(deploy, nil))
+}
+
+func TestQuery_Match_WithNilQuery_ShouldMatchEverything(t *testing.T) {
+	var q *Query
+
+	assert.True(t, q.Match(&model.Task{ID: "any"}, nil))
+}
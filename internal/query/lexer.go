@@ -0,0 +1,94 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenKind はトークンの種類を定義
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenMinus
+	tokenOr
+	tokenAnd
+	tokenEOF
+)
+
+// token はクエリを区切った字句
+type token struct {
+	kind   tokenKind
+	raw    string // 入力に書かれたままの文字列
+	value  string // 引用符とエスケープを取り除いた文字列
+	column int    // 開始位置（1始まりの文字単位）
+	quoted bool   // 引用符で始まる単語（フィールド指定ではなく語句として扱う）
+}
+
+// lex は input をトークンに分割する
+// 単語は空白と括弧で区切り、引用符で囲んだ部分は空白を含めて単語の一部とする
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, raw: "(", column: i + 1})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, raw: ")", column: i + 1})
+			i++
+			continue
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')':
+			// 単語の先頭の - は否定を表す
+			tokens = append(tokens, token{kind: tokenMinus, raw: "-", column: i + 1})
+			i++
+			continue
+		}
+
+		start := i
+		var value strings.Builder
+		quoteStart := -1
+		for ; i < len(runes); i++ {
+			c := runes[i]
+			if quoteStart < 0 && (unicode.IsSpace(c) || c == '(' || c == ')') {
+				break
+			}
+			switch {
+			case c == '\\' && quoteStart >= 0 && i+1 < len(runes):
+				i++
+				value.WriteRune(runes[i])
+			case c != '"':
+				value.WriteRune(c)
+			case quoteStart >= 0:
+				quoteStart = -1
+			default:
+				quoteStart = i
+			}
+		}
+		if quoteStart >= 0 {
+			return nil, &SyntaxError{Column: quoteStart + 1, Token: string(runes[quoteStart:]), Message: "unterminated quote"}
+		}
+
+		tok := token{kind: tokenWord, raw: string(runes[start:i]), value: value.String(), column: start + 1, quoted: runes[start] == '"'}
+		if !tok.quoted {
+			switch strings.ToLower(tok.raw) {
+			case "or":
+				tok.kind = tokenOr
+			case "and":
+				tok.kind = tokenAnd
+			}
+		}
+		tokens = append(tokens, tok)
+	}
+
+	return append(tokens, token{kind: tokenEOF, column: len(runes) + 1}), nil
+}
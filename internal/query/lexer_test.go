@@ -0,0 +1,40 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLex_ShouldSplitWordsParenthesesAndKeywords(t *testing.T) {
+	// When
+	tokens, err := lex(`-(tag:a OR tag:"two words") and "exact phrase" well-known`)
+
+	// Then
+	require.NoError(t, err)
+	kinds := make([]tokenKind, len(tokens))
+	for i, tok := range tokens {
+		kinds[i] = tok.kind
+	}
+	assert.Equal(t, []tokenKind{tokenMinus, tokenLParen, tokenWord, tokenOr, tokenWord, tokenRParen,
+		tokenAnd, tokenWord, tokenWord, tokenEOF}, kinds)
+
+	assert.Equal(t, `tag:"two words"`, tokens[4].raw)
+	assert.Equal(t, "tag:two words", tokens[4].value)
+	assert.Equal(t, 12, tokens[4].column)
+	assert.True(t, tokens[7].quoted)
+	assert.Equal(t, "exact phrase", tokens[7].value)
+	assert.Equal(t, "well-known", tokens[8].value)
+}
+
+func TestLex_WithUnterminatedQuote_ShouldPointAtQuote(t *testing.T) {
+	// When
+	_, err := lex(`tag:a "open phrase`)
+
+	// Then
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, 7, syntaxErr.Column)
+	assert.Equal(t, "unterminated quote", syntaxErr.Message)
+}
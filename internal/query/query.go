@@ -0,0 +1,450 @@
+// Package query はタスクを絞り込むクエリ言語を実装する
+//
+// クエリは空白区切りの条件の並びで、すべての条件を満たすタスクに一致する
+//
+//	status:todo,in_progress priority>=medium tag:backend -tag:blocked due<7d created>2026-01-01 "exact phrase"
+//
+// 条件は OR でつなぎ、括弧でまとめることができる。先頭の - は条件を否定する
+// フィールドのない単語や引用符で囲んだ語句はタイトルと説明で部分一致検索する
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"task-cli/internal/dateparse"
+	"task-cli/internal/model"
+)
+
+// ErrInvalidQuery はクエリの構文や値が正しくないことを表す
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Help はクエリの構文の説明
+const Help = `conditions separated by spaces must all match; combine with OR and parentheses, negate with -. ` +
+	`Fields: status, priority, tag, title, desc, due, created, updated, completed, is ` +
+	`(open, done, overdue, soon, blocked, recurring, subtask). ` +
+	`Operators: ":" "=" "!=" and "<" "<=" ">" ">=" for priority and dates. ` +
+	`Bare words and "quoted phrases" search the title and description`
+
+// SyntaxError はクエリ中の特定の位置に関するエラー
+type SyntaxError struct {
+	Column  int    // エラーの位置（1始まりの文字単位）
+	Token   string // エラーの原因となったトークン
+	Message string
+}
+
+// Error はエラーメッセージを返す
+func (e *SyntaxError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+	}
+	return fmt.Sprintf("column %d: %q: %s", e.Column, e.Token, e.Message)
+}
+
+// Unwrap は ErrInvalidQuery を返す
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+// Pointer は input の下にエラーの位置を示す印を付けた2行の文字列を返す
+func (e *SyntaxError) Pointer(input string) string {
+	width := len([]rune(e.Token))
+	if width == 0 {
+		width = 1
+	}
+	return input + "\n" + strings.Repeat(" ", e.Column-1) + "^" + strings.Repeat("~", width-1)
+}
+
+// Query は解析済みのクエリ
+type Query struct {
+	Root   Node // nilの場合はすべてのタスクに一致する
+	source string
+	now    time.Time
+}
+
+// Parse は input を解析する
+// "7d" や "tomorrow" のような相対的な日付は now を基準に解釈する
+func Parse(input string, now time.Time) (*Query, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, now: now}
+	q := &Query{source: input, now: now}
+	if p.peek().kind == tokenEOF {
+		return q, nil
+	}
+
+	q.Root, err = p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok)
+	}
+	return q, nil
+}
+
+// Match はタスクがクエリに一致するかを返す
+// data はブロック状態の判定に使う（nilの場合は is:blocked に一致しない）
+func (q *Query) Match(task *model.Task, data *model.AppData) bool {
+	if q == nil || q.Root == nil {
+		return true
+	}
+	return q.Root.Match(task, Env{Now: q.now, Data: data})
+}
+
+// Filter は tasks のうちクエリに一致するタスクを返す
+func (q *Query) Filter(tasks []*model.Task) []*model.Task {
+	data := &model.AppData{Tasks: tasks}
+	var filtered []*model.Task
+	for _, task := range tasks {
+		if q.Match(task, data) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// Source は解析前のクエリを返す
+func (q *Query) Source() string {
+	return q.source
+}
+
+// String は解析した条件をクエリの構文で返す
+func (q *Query) String() string {
+	if q.Root == nil {
+		return ""
+	}
+	return q.Root.String()
+}
+
+// parser は再帰下降でトークンを構文木にする
+//
+//	or      := and ("OR" and)*
+//	and     := unary ("AND"? unary)*
+//	unary   := "-" unary | primary
+//	primary := "(" or ")" | word
+type parser struct {
+	tokens []token
+	pos    int
+	now    time.Time
+}
+
+// peek は次のトークンを返す
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next は次のトークンを返して読み進める
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr は OR でつないだ条件を解析する
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{first}
+	for p.peek().kind == tokenOr {
+		p.next()
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return &Or{Nodes: nodes}, nil
+}
+
+// parseAnd は空白（または AND）で並べた条件を解析する
+func (p *parser) parseAnd() (Node, error) {
+	var nodes []Node
+	for {
+		switch tok := p.peek(); tok.kind {
+		case tokenAnd:
+			if len(nodes) == 0 {
+				return nil, &SyntaxError{Column: tok.column, Token: tok.raw, Message: "expected a condition before AND"}
+			}
+			p.next()
+			if next := p.peek(); next.kind == tokenEOF || next.kind == tokenOr || next.kind == tokenRParen || next.kind == tokenAnd {
+				return nil, &SyntaxError{Column: tok.column, Token: tok.raw, Message: "expected a condition after AND"}
+			}
+			continue
+		case tokenOr, tokenRParen, tokenEOF:
+			if len(nodes) == 0 {
+				return nil, p.expectedCondition(tok)
+			}
+			if len(nodes) == 1 {
+				return nodes[0], nil
+			}
+			return &And{Nodes: nodes}, nil
+		}
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+}
+
+// parseUnary は否定を解析する
+func (p *parser) parseUnary() (Node, error) {
+	if p.peek().kind == tokenMinus {
+		p.next()
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary は括弧でまとめた条件か単語を解析する
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, &SyntaxError{Column: tok.column, Token: tok.raw, Message: "missing closing parenthesis"}
+		}
+		p.next()
+		return node, nil
+	case tokenWord:
+		return p.parseWord(tok)
+	}
+	return nil, p.expectedCondition(tok)
+}
+
+// expectedCondition は条件があるべき位置に tok があることを表すエラーを返す
+func (p *parser) expectedCondition(tok token) error {
+	switch tok.kind {
+	case tokenEOF:
+		return &SyntaxError{Column: tok.column, Message: "expected a condition at the end of the query"}
+	case tokenRParen:
+		if p.pos > 0 && p.tokens[p.pos-1].kind == tokenLParen {
+			return &SyntaxError{Column: tok.column, Token: tok.raw, Message: "empty parentheses"}
+		}
+	}
+	return &SyntaxError{Column: tok.column, Token: tok.raw, Message: fmt.Sprintf("expected a condition before %s", tok.raw)}
+}
+
+// unexpected は構文上ありえない位置の tok を表すエラーを返す
+func (p *parser) unexpected(tok token) error {
+	if tok.kind == tokenRParen {
+		return &SyntaxError{Column: tok.column, Token: tok.raw, Message: "unexpected closing parenthesis"}
+	}
+	return &SyntaxError{Column: tok.column, Token: tok.raw, Message: "unexpected token"}
+}
+
+// fieldAliases はフィールド名と別名
+var fieldAliases = map[string]Field{
+	"status":      FieldStatus,
+	"priority":    FieldPriority,
+	"prio":        FieldPriority,
+	"tag":         FieldTag,
+	"tags":        FieldTag,
+	"title":       FieldTitle,
+	"desc":        FieldDescription,
+	"description": FieldDescription,
+	"due":         FieldDue,
+	"created":     FieldCreated,
+	"updated":     FieldUpdated,
+	"completed":   FieldCompleted,
+	"is":          FieldIs,
+}
+
+// fieldNames はエラーメッセージに表示するフィールド名
+var fieldNames = []Field{FieldStatus, FieldPriority, FieldTag, FieldTitle, FieldDescription,
+	FieldDue, FieldCreated, FieldUpdated, FieldCompleted, FieldIs}
+
+// operators は比較演算子（長いものから順に照合する）
+var operators = []Operator{OpLessEq, OpGreaterE, OpNotEqual, OpMatch, OpEqual, OpLess, OpGreater}
+
+// parseWord は単語を比較か語句として解析する
+// 英字の後に演算子が続く単語を比較とし、それ以外と引用符で始まる単語は語句とする
+// status と priority の値は正規化した名前で保持する
+func (p *parser) parseWord(tok token) (Node, error) {
+	if tok.quoted {
+		return &Text{Text: tok.value}, nil
+	}
+
+	raw := []rune(tok.raw)
+	nameEnd := 0
+	for nameEnd < len(raw) && raw[nameEnd] <= unicode.MaxASCII && unicode.IsLetter(raw[nameEnd]) {
+		nameEnd++
+	}
+	var op Operator
+	for _, candidate := range operators {
+		if nameEnd > 0 && strings.HasPrefix(string(raw[nameEnd:]), string(candidate)) {
+			op = candidate
+			break
+		}
+	}
+	// "https://..." のような URL はフィールド指定として扱わない
+	if op == "" || (op == OpMatch && strings.HasPrefix(string(raw[nameEnd+1:]), "/")) {
+		return &Text{Text: tok.value}, nil
+	}
+
+	name := string(raw[:nameEnd])
+	field, ok := fieldAliases[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, len(fieldNames))
+		for i, f := range fieldNames {
+			names[i] = string(f)
+		}
+		return nil, &SyntaxError{Column: tok.column, Token: name,
+			Message: fmt.Sprintf("unknown field (expected one of %s; quote the word to search for it)", strings.Join(names, ", "))}
+	}
+
+	valueStart := nameEnd + len([]rune(string(op)))
+	values, err := splitValues(raw[valueStart:], tok.column+valueStart)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, &SyntaxError{Column: tok.column, Token: tok.raw, Message: fmt.Sprintf("missing value after %s%s", name, op)}
+	}
+	return p.newComparison(field, op, values, tok)
+}
+
+// value はカンマ区切りの値の1つとその位置
+type value struct {
+	text   string
+	raw    string
+	column int
+}
+
+// splitValues は値をカンマで区切る（引用符で囲んだカンマは区切りとしない）
+func splitValues(raw []rune, column int) ([]value, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var (
+		values []value
+		text   strings.Builder
+		start  int
+		quoted bool
+	)
+	flush := func(end int) error {
+		if text.Len() == 0 && !quoted {
+			return &SyntaxError{Column: column + start, Token: string(raw), Message: "empty value in list"}
+		}
+		values = append(values, value{text: text.String(), raw: string(raw[start:end]), column: column + start})
+		text.Reset()
+		quoted = false
+		return nil
+	}
+
+	inQuote := false
+	for i := 0; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case c == '\\' && inQuote && i+1 < len(raw):
+			i++
+			text.WriteRune(raw[i])
+		case c == '"':
+			inQuote = !inQuote
+			quoted = true
+		case c == ',' && !inQuote:
+			if err := flush(i); err != nil {
+				return nil, err
+			}
+			start = i + 1
+		default:
+			text.WriteRune(c)
+		}
+	}
+	if err := flush(len(raw)); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// newComparison はフィールドに応じて値を検証し、比較のノードを作成する
+func (p *parser) newComparison(field Field, op Operator, values []value, tok token) (Node, error) {
+	node := &Comparison{Field: field, Operator: op}
+	for _, v := range values {
+		node.Values = append(node.Values, v.text)
+	}
+
+	ordered := op.isOrdered()
+	switch field {
+	case FieldPriority, FieldDue, FieldCreated, FieldUpdated, FieldCompleted:
+		if ordered && len(values) > 1 {
+			return nil, &SyntaxError{Column: values[1].column, Token: tok.raw, Message: fmt.Sprintf("%s compares with a single value", op)}
+		}
+	default:
+		if ordered {
+			return nil, &SyntaxError{Column: tok.column, Token: tok.raw,
+				Message: fmt.Sprintf("%s cannot be compared with %s (use :, = or !=)", field, op)}
+		}
+	}
+
+	for i, v := range values {
+		invalid := func(message string) error {
+			return &SyntaxError{Column: v.column, Token: v.raw, Message: message}
+		}
+
+		switch field {
+		case FieldStatus:
+			status, err := model.ParseStatus(v.text)
+			if err != nil {
+				return nil, invalid(err.Error())
+			}
+			node.statuses = append(node.statuses, status)
+			node.Values[i] = string(status)
+		case FieldPriority:
+			priority, err := model.ParsePriority(v.text)
+			if err != nil {
+				return nil, invalid(err.Error())
+			}
+			node.priorities = append(node.priorities, priority)
+			node.Values[i] = string(priority)
+		case FieldDue, FieldCreated, FieldUpdated, FieldCompleted:
+			if strings.EqualFold(v.text, "none") {
+				if ordered {
+					return nil, invalid(fmt.Sprintf("none cannot be compared with %s", op))
+				}
+				node.dates = append(node.dates, nil)
+				continue
+			}
+			date, err := dateparse.Parse(v.text, p.now)
+			if err != nil {
+				return nil, invalid(fmt.Sprintf("invalid date: expected none, %s", dateparse.Help))
+			}
+			node.dates = append(node.dates, &date)
+		case FieldIs:
+			state := strings.ToLower(v.text)
+			known := false
+			for _, s := range states {
+				known = known || s == state
+			}
+			if !known {
+				return nil, invalid(fmt.Sprintf("unknown state (expected one of %s)", strings.Join(states, ", ")))
+			}
+			node.Values[i] = state
+		}
+	}
+	return node, nil
+}
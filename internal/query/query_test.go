@@ -0,0 +1,103 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 2026-10-14 (水) 15:04 を基準にする
+var now = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+
+func TestParse_ShouldBuildSyntaxTree(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: ""},
+		{input: "status:todo,in_progress", want: "status:todo,in_progress"},
+		{input: "STATUS:Done prio>=m", want: "status:completed priority>=medium"},
+		{input: `tag:backend -tag:blocked "exact phrase"`, want: `tag:backend -tag:blocked "exact phrase"`},
+		{input: "bug OR is:overdue", want: "(bug OR is:overdue)"},
+		{input: "a b OR c", want: "(a b OR c)"},
+		{input: "-(tag:a OR tag:b) and due:none", want: "-(tag:a OR tag:b) due:none"},
+		{input: `title:"login page" desc=x`, want: `title:"login page" desc=x`},
+		{input: "10:30 https://example.com", want: `"10:30" "https://example.com"`},
+		{input: `tag:"a,b",c`, want: `tag:"a,b",c`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// When
+			q, err := Parse(tt.input, now)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, q.String())
+			assert.Equal(t, tt.input, q.Source())
+
+			// 文字列表現は同じ条件として解析し直せる
+			reparsed, err := Parse(q.String(), now)
+			require.NoError(t, err)
+			assert.Equal(t, q.String(), reparsed.String())
+		})
+	}
+}
+
+func TestParse_WithInvalidQuery_ShouldPointAtBadToken(t *testing.T) {
+	tests := []struct {
+		input   string
+		column  int
+		token   string
+		message string
+	}{
+		{input: "status:todo stauts:done", column: 13, token: "stauts", message: "unknown field"},
+		{input: "status:todo,finished", column: 13, token: "finished", message: `invalid status "finished"`},
+		{input: "priority>=urgent", column: 11, token: "urgent", message: `invalid priority "urgent"`},
+		{input: "due<someday", column: 5, token: "someday", message: "invalid date"},
+		{input: "due<none", column: 5, token: "none", message: "none cannot be compared with <"},
+		{input: "tag>backend", column: 1, token: "tag>backend", message: "tag cannot be compared with >"},
+		{input: "priority>low,medium", column: 14, token: "priority>low,medium", message: "> compares with a single value"},
+		{input: "is:stuck", column: 4, token: "stuck", message: "unknown state"},
+		{input: "status:", column: 1, token: "status:", message: "missing value after status:"},
+		{input: "tag:a,,b", column: 7, token: "a,,b", message: "empty value in list"},
+		{input: "(tag:a OR tag:b", column: 1, token: "(", message: "missing closing parenthesis"},
+		{input: "tag:a)", column: 6, token: ")", message: "unexpected closing parenthesis"},
+		{input: "tag:a ()", column: 8, token: ")", message: "empty parentheses"},
+		{input: "OR tag:a", column: 1, token: "OR", message: "expected a condition before OR"},
+		{input: "tag:a OR", column: 9, message: "expected a condition at the end of the query"},
+		{input: "tag:a and OR b", column: 7, token: "and", message: "expected a condition after AND"},
+		{input: `title:"open`, column: 7, token: `"open`, message: "unterminated quote"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// When
+			_, err := Parse(tt.input, now)
+
+			// Then
+			assert.ErrorIs(t, err, ErrInvalidQuery)
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.column, syntaxErr.Column)
+			assert.Equal(t, tt.token, syntaxErr.Token)
+			assert.Contains(t, syntaxErr.Message, tt.message)
+		})
+	}
+}
+
+func TestSyntaxError_Pointer_ShouldMarkTokenUnderInput(t *testing.T) {
+	// Given
+	input := "status:todo stauts:done"
+	_, err := Parse(input, now)
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+
+	// When
+	pointer := syntaxErr.Pointer(input)
+
+	// Then
+	assert.Equal(t, "status:todo stauts:done\n            ^~~~~~", pointer)
+}
@@ -0,0 +1,226 @@
+// Package quickadd は1行の入力からタスク作成リクエストを組み立てる
+// "Fix login bug #auth !high due:fri @work est:2h" のように、タイトルの中に属性を書き込める
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"task-cli/internal/dateparse"
+	"task-cli/internal/model"
+	"task-cli/internal/service"
+)
+
+var (
+	// ErrEmptyTitle はタイトルになる単語がないことを表す
+	ErrEmptyTitle = errors.New("title is required")
+	// ErrUnknownKey は "key:value" 形式のキーが認識できないことを表す
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrInvalidValue は属性の値が解釈できないことを表す
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrDuplicate は同じ属性が2回以上指定されたことを表す
+	ErrDuplicate = errors.New("duplicate attribute")
+	// ErrUnterminatedQuote は閉じられていない引用符を表す
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+)
+
+// Help は受け付ける構文の説明
+const Help = `#tag, @context, !priority (low, medium, high), due:<date>, est:<duration>, repeat:<rule>; ` +
+	`quote values with spaces (due:"next fri") and prefix a word with \ to keep it in the title`
+
+// keys は "key:value" 形式で受け付けるキー
+var keys = []string{"due", "est", "estimate", "repeat"}
+
+// TokenError は入力中の特定のトークンに関するエラー
+type TokenError struct {
+	Token  string // 入力に書かれたままのトークン
+	Column int    // トークンの開始位置（1始まりの文字単位）
+	Err    error
+}
+
+// Error はエラーメッセージを返す
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("column %d: %q: %v", e.Column, e.Token, e.Err)
+}
+
+// Unwrap は原因となったエラーを返す
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
+// token は入力を空白で区切った単語
+type token struct {
+	raw     string // 入力に書かれたままの文字列
+	text    string // 引用符とエスケープを取り除いた文字列
+	column  int
+	literal bool // タイトルの単語としてそのまま扱う
+}
+
+// Parse は line を now を基準に解釈し、タスク作成リクエストを返す
+// 認識できないトークンはすべて TokenError として errors.Join でまとめて返す
+func Parse(line string, now time.Time) (service.CreateTaskRequest, error) {
+	request := service.CreateTaskRequest{Priority: model.PriorityMedium}
+
+	tokens, err := tokenize(line)
+	if err != nil {
+		return service.CreateTaskRequest{}, err
+	}
+
+	var (
+		errs  []error
+		title []string
+		seen  = make(map[string]token)
+	)
+	// once は同じ属性が2回指定されていないことを確認する
+	once := func(attribute string, tok token) bool {
+		if first, ok := seen[attribute]; ok {
+			errs = append(errs, &TokenError{Token: tok.raw, Column: tok.column,
+				Err: fmt.Errorf("%w: %s already set by %q at column %d", ErrDuplicate, attribute, first.raw, first.column)})
+			return false
+		}
+		seen[attribute] = tok
+		return true
+	}
+	invalid := func(tok token, err error) {
+		errs = append(errs, &TokenError{Token: tok.raw, Column: tok.column, Err: fmt.Errorf("%w: %w", ErrInvalidValue, err)})
+	}
+
+	for _, tok := range tokens {
+		if tok.literal {
+			title = append(title, tok.text)
+			continue
+		}
+
+		switch {
+		case len(tok.text) > 1 && tok.text[0] == '#':
+			request.Tags = appendTag(request.Tags, tok.text[1:])
+		case len(tok.text) > 1 && tok.text[0] == '@':
+			request.Tags = appendTag(request.Tags, tok.text)
+		case len(tok.text) > 1 && tok.text[0] == '!':
+			priority, err := model.ParsePriority(tok.text[1:])
+			if err != nil {
+				invalid(tok, err)
+			} else if once("priority", tok) {
+				request.Priority = priority
+			}
+		default:
+			key, value, ok := splitKeyValue(tok.text)
+			if !ok {
+				title = append(title, tok.text)
+				continue
+			}
+			switch key {
+			case "due":
+				due, err := dateparse.Parse(value, now)
+				if err != nil {
+					invalid(tok, err)
+				} else if once("due date", tok) {
+					request.DueDate = &due
+				}
+			case "est", "estimate":
+				minutes, err := model.ParseEstimate(value)
+				if err != nil {
+					invalid(tok, err)
+				} else if once("estimate", tok) {
+					request.Estimate = minutes
+				}
+			case "repeat":
+				rule, err := model.ParseRecurrence(value)
+				if err != nil {
+					invalid(tok, err)
+				} else if once("repeat", tok) {
+					request.Recurrence = rule
+				}
+			default:
+				errs = append(errs, &TokenError{Token: tok.raw, Column: tok.column,
+					Err: fmt.Errorf("%w %q: expected one of %s (prefix with \\ to keep it in the title)", ErrUnknownKey, key, strings.Join(keys, ", "))})
+			}
+		}
+	}
+
+	request.Title = strings.Join(title, " ")
+	if request.Title == "" && len(errs) == 0 {
+		errs = append(errs, ErrEmptyTitle)
+	}
+	if len(errs) > 0 {
+		return service.CreateTaskRequest{}, errors.Join(errs...)
+	}
+	return request, nil
+}
+
+// tokenize は line を空白で区切る
+// 引用符で囲んだ部分は空白を含めて1つの単語とし、\ で始まる単語はそのまま扱う
+func tokenize(line string) ([]token, error) {
+	runes := []rune(line)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		var text strings.Builder
+		tok := token{column: start + 1}
+
+		if runes[i] == '\\' {
+			// エスケープした単語は引用符も含めて空白まで文字どおりに扱う
+			tok.literal = true
+			for i++; i < len(runes) && !unicode.IsSpace(runes[i]); i++ {
+				text.WriteRune(runes[i])
+			}
+		} else {
+			tok.literal = runes[i] == '"'
+			quoteStart := -1
+			for ; i < len(runes) && (quoteStart >= 0 || !unicode.IsSpace(runes[i])); i++ {
+				switch {
+				case runes[i] != '"':
+					text.WriteRune(runes[i])
+				case quoteStart >= 0:
+					quoteStart = -1
+				default:
+					quoteStart = i
+				}
+			}
+			if quoteStart >= 0 {
+				return nil, &TokenError{Token: string(runes[start:]), Column: quoteStart + 1, Err: ErrUnterminatedQuote}
+			}
+		}
+
+		tok.raw = string(runes[start:i])
+		tok.text = text.String()
+		if tok.text != "" {
+			tokens = append(tokens, tok)
+		}
+	}
+	return tokens, nil
+}
+
+// splitKeyValue は "key:value" 形式の単語をキーと値に分ける
+// キーが英字のみで値が空でない場合に限り、"10:30" や "https://..." はタイトルの単語として扱う
+func splitKeyValue(text string) (string, string, bool) {
+	key, value, found := strings.Cut(text, ":")
+	if !found || key == "" || value == "" || strings.HasPrefix(value, "/") {
+		return "", "", false
+	}
+	for _, r := range key {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return "", "", false
+		}
+	}
+	return strings.ToLower(key), value, true
+}
+
+// appendTag は重複しないようにタグを追加する
+func appendTag(tags []string, tag string) []string {
+	for _, existing := range tags {
+		if existing == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
@@ -0,0 +1,154 @@
+package quickadd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+	"task-cli/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 2026-10-14 (水) 15:04 を基準にする
+var now = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestParse_ShouldExtractAttributesFromLine(t *testing.T) {
+	// When
+	request, err := Parse("Fix login bug #auth #backend !high due:fri @work est:2h", now)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, service.CreateTaskRequest{
+		Title:    "Fix login bug",
+		Priority: model.PriorityHigh,
+		Tags:     []string{"auth", "backend", "@work"},
+		DueDate:  date(2026, 10, 16),
+		Estimate: 120,
+	}, request)
+}
+
+func TestParse_ShouldAcceptTokensAnywhereInLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  service.CreateTaskRequest
+	}{
+		{
+			name:  "plain title defaults to medium priority",
+			input: "  Write   release notes ",
+			want:  service.CreateTaskRequest{Title: "Write release notes", Priority: model.PriorityMedium},
+		},
+		{
+			name:  "attributes between title words",
+			input: "#ops Renew !l certificates due:+3d",
+			want:  service.CreateTaskRequest{Title: "Renew certificates", Priority: model.PriorityLow, Tags: []string{"ops"}, DueDate: date(2026, 10, 17)},
+		},
+		{
+			name:  "quoted values may contain spaces",
+			input: `Standup due:"next mon" repeat:"every 2 weeks on mon"`,
+			want: service.CreateTaskRequest{
+				Title: "Standup", Priority: model.PriorityMedium, DueDate: date(2026, 10, 19),
+				Recurrence: &model.Recurrence{Frequency: model.FrequencyWeekly, Interval: 2, Weekdays: []time.Weekday{time.Monday}},
+			},
+		},
+		{
+			name:  "escaped and quoted words stay in the title",
+			input: `Reply to \#42 about "due:friday" \!important`,
+			want:  service.CreateTaskRequest{Title: "Reply to #42 about due:friday !important", Priority: model.PriorityMedium},
+		},
+		{
+			name:  "times, urls and lone symbols are title words",
+			input: "Call at 10:30 re: https://example.com/x # ! @",
+			want:  service.CreateTaskRequest{Title: "Call at 10:30 re: https://example.com/x # ! @", Priority: model.PriorityMedium},
+		},
+		{
+			name:  "keys are case insensitive and tags are not duplicated",
+			input: "Plan DUE:tomorrow Estimate:90 #plan #plan",
+			want:  service.CreateTaskRequest{Title: "Plan", Priority: model.PriorityMedium, Tags: []string{"plan"}, DueDate: date(2026, 10, 15), Estimate: 90},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			request, err := Parse(tt.input, now)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, request)
+		})
+	}
+}
+
+func TestParse_WithInvalidTokens_ShouldReportEachTokenAndColumn(t *testing.T) {
+	// Given - 列は1始まりの文字単位で数える
+	input := "Fix bug !urgent foo:bar due:someday est:2h est:3h"
+
+	// When
+	_, err := Parse(input, now)
+
+	// Then
+	require.Error(t, err)
+	var tokenErrors []*TokenError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var tokenErr *TokenError
+		require.True(t, errors.As(e, &tokenErr), e)
+		tokenErrors = append(tokenErrors, tokenErr)
+	}
+	require.Len(t, tokenErrors, 4)
+
+	assert.Equal(t, "!urgent", tokenErrors[0].Token)
+	assert.Equal(t, 9, tokenErrors[0].Column)
+	assert.ErrorIs(t, tokenErrors[0], ErrInvalidValue)
+
+	assert.Equal(t, "foo:bar", tokenErrors[1].Token)
+	assert.Equal(t, 17, tokenErrors[1].Column)
+	assert.ErrorIs(t, tokenErrors[1], ErrUnknownKey)
+
+	assert.Equal(t, "due:someday", tokenErrors[2].Token)
+	assert.Equal(t, 25, tokenErrors[2].Column)
+	assert.ErrorIs(t, tokenErrors[2], ErrInvalidValue)
+
+	assert.Equal(t, "est:3h", tokenErrors[3].Token)
+	assert.Equal(t, 44, tokenErrors[3].Column)
+	assert.ErrorIs(t, tokenErrors[3], ErrDuplicate)
+	assert.Contains(t, tokenErrors[3].Error(), `"est:2h" at column 37`)
+}
+
+func TestParse_ShouldCountColumnsInCharacters(t *testing.T) {
+	// When
+	_, err := Parse("バグ修正 !max", now)
+
+	// Then
+	var tokenErr *TokenError
+	require.ErrorAs(t, err, &tokenErr)
+	assert.Equal(t, 6, tokenErr.Column)
+	assert.Equal(t, `column 6: "!max": invalid value: invalid priority "max": must be one of low, medium, high`, tokenErr.Error())
+}
+
+func TestParse_WithOnlyAttributes_ShouldReturnEmptyTitleError(t *testing.T) {
+	// When
+	_, err := Parse("#auth !high due:fri", now)
+
+	// Then
+	assert.ErrorIs(t, err, ErrEmptyTitle)
+}
+
+func TestParse_WithUnterminatedQuote_ShouldReportQuoteColumn(t *testing.T) {
+	// When
+	_, err := Parse(`Meet due:"next fri`, now)
+
+	// Then
+	var tokenErr *TokenError
+	require.ErrorAs(t, err, &tokenErr)
+	assert.ErrorIs(t, err, ErrUnterminatedQuote)
+	assert.Equal(t, 10, tokenErr.Column)
+}
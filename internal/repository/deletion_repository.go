@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"task-cli/internal/model"
+)
+
+// deletionsFileName は削除したタスクの記録のファイル名
+const deletionsFileName = "deletions.json"
+
+// LoadDeletions はファイルから削除したタスクの記録を読み込む
+// ファイルが存在しない場合は空の記録を返す
+func (f *FileRepository) LoadDeletions(ctx context.Context) ([]model.Deletion, error) {
+	filePath := f.getDeletionsFilePath()
+
+	jsonData, err := f.fs.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return []model.Deletion{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deletions: %w", err)
+	}
+
+	deletions := []model.Deletion{}
+	if err := json.Unmarshal(jsonData, &deletions); err != nil {
+		return nil, &CorruptError{Path: filePath, Err: err}
+	}
+	if deletions == nil {
+		deletions = []model.Deletion{}
+	}
+	return deletions, nil
+}
+
+// SaveDeletions は削除したタスクの記録をファイルに保存する
+func (f *FileRepository) SaveDeletions(ctx context.Context, deletions []model.Deletion) error {
+	if err := f.ensureDataDir(); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(deletions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal deletions: %w", err)
+	}
+
+	if err := atomicWriteFile(f.fs, f.getDeletionsFilePath(), jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write deletions: %w", err)
+	}
+	return nil
+}
+
+// getDeletionsFilePath は削除したタスクの記録のファイルのフルパスを返す
+func (f *FileRepository) getDeletionsFilePath() string {
+	return filepath.Join(f.dataDir, deletionsFileName)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalRepository_ShouldImplementDeletionRepository(t *testing.T) {
+	// When
+	repo := NewJournalRepository(t.TempDir())
+
+	// Then
+	assert.Implements(t, (*DeletionRepository)(nil), repo)
+}
+
+func TestFileRepository_SaveDeletions_ShouldRoundTrip(t *testing.T) {
+	// Given
+	dataDir := filepath.Join(t.TempDir(), "data")
+	repo := newFileRepositoryWithFS(dataDir, osFileSystem{})
+	at := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	deletions := []model.Deletion{{
+		TaskID: "1", Title: "Write docs", ProjectID: model.DefaultProjectID,
+		Change: model.ChangeEntry{At: at, Actor: "alice", Action: model.ChangeDeleted},
+	}}
+
+	// When
+	empty, err := repo.LoadDeletions(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveDeletions(context.Background(), deletions))
+	loaded, err := repo.LoadDeletions(context.Background())
+
+	// Then
+	assert.Empty(t, empty)
+	require.NoError(t, err)
+	assert.Equal(t, deletions, loaded)
+	assert.FileExists(t, filepath.Join(dataDir, "deletions.json"))
+}
+
+func TestFileRepository_LoadDeletions_WithCorruptFile_ShouldReturnCorruptError(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "deletions.json"), []byte("[not json"), 0644))
+	repo := newFileRepositoryWithFS(dataDir, osFileSystem{})
+
+	// When
+	_, err := repo.LoadDeletions(context.Background())
+
+	// Then
+	assert.True(t, errors.Is(err, ErrCorrupt))
+	assert.Contains(t, err.Error(), "deletions.json")
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound はデータファイルが存在しないことを表す
+	ErrNotFound = errors.New("data file does not exist")
+
+	// ErrCorrupt はデータファイルを解析できないことを表す
+	ErrCorrupt = errors.New("data file is corrupt")
+
+	// ErrNoBackups はバックアップが1つも存在しないことを表す
+	ErrNoBackups = errors.New("no backups available")
+
+	// ErrNewerSchema はデータファイルがより新しいバージョンのツールで書き込まれたことを表す
+	ErrNewerSchema = errors.New("data file was written by a newer version of task-cli")
+)
+
+// CorruptError は破損したデータファイルの詳細を表す
+// errors.Is(err, ErrCorrupt) で判定できる
+type CorruptError struct {
+	Path           string // 破損していたファイル
+	QuarantinePath string // 退避したコピーのパス（退避に失敗した場合は空）
+	Err            error  // 解析時のエラー
+}
+
+// Error はエラーメッセージを返す
+func (e *CorruptError) Error() string {
+	msg := fmt.Sprintf("%s: %s: %v", ErrCorrupt, e.Path, e.Err)
+	if e.QuarantinePath != "" {
+		msg += fmt.Sprintf(" (a copy was saved to %s)", e.QuarantinePath)
+	}
+	return msg
+}
+
+// Unwrap は元の解析エラーを返す
+func (e *CorruptError) Unwrap() error {
+	return e.Err
+}
+
+// Is はErrCorruptとの比較を可能にする
+func (e *CorruptError) Is(target error) bool {
+	return target == ErrCorrupt
+}
+
+// SchemaVersionError は対応していないスキーマバージョンの詳細を表す
+// errors.Is(err, ErrNewerSchema) で判定できる
+type SchemaVersionError struct {
+	Path      string // データファイル
+	Version   int    // ファイルのスキーマバージョン
+	Supported int    // このバージョンが対応する最新のスキーマバージョン
+}
+
+// Error はエラーメッセージを返す
+func (e *SchemaVersionError) Error() string {
+	msg := fmt.Sprintf("%s (schema version %d, this version supports up to %d); please upgrade task-cli",
+		ErrNewerSchema, e.Version, e.Supported)
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	return msg
+}
+
+// Is はErrNewerSchemaとの比較を可能にする
+func (e *SchemaVersionError) Is(target error) bool {
+	return target == ErrNewerSchema
+}
@@ -1,33 +1,101 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"task-cli/internal/model"
 )
 
+const (
+	// backupFilePrefix はバックアップファイル名の接頭辞
+	backupFilePrefix = "tasks_backup_"
+
+	// backupTimestampLayout はバックアップファイル名に含めるタイムスタンプの形式
+	// 同じ秒に複数のバックアップを作成しても上書きしないようにマイクロ秒まで含める
+	backupTimestampLayout = "20060102_150405.000000"
+
+	// legacyBackupTimestampLayout は秒単位だった以前のタイムスタンプの形式
+	legacyBackupTimestampLayout = "20060102_150405"
+
+	// migrationBackupPrefix はスキーマの移行前に保存するバックアップのファイル名の接頭辞（保持方針による削除の対象外）
+	migrationBackupPrefix = backupFilePrefix + "schema"
+)
+
 // FileRepository はファイルベースのRepository実装
 type FileRepository struct {
-	dataDir  string
-	fileName string
+	dataDir     string
+	fileName    string
+	fs          fileSystem
+	lockTimeout time.Duration
+	retention   RetentionPolicy
+
+	// compactThreshold はJournalRepositoryがスナップショットにまとめるまでに追記するイベント数
+	compactThreshold int
+
+	// verifiedMu は verified を保護する
+	verifiedMu sync.Mutex
+	// verified は最後に読み込みまたは保存に成功したデータファイルの状態
+	// 保存のたびにファイル全体を読み直して解析しないよう、変わっていなければ上書きできるものとして扱う
+	verified fileStamp
+}
+
+// fileStamp はデータファイルが変更されたかを判定するための大きさと更新日時
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// newFileStamp はファイル情報からfileStampを作成する
+func newFileStamp(info os.FileInfo) fileStamp {
+	return fileStamp{size: info.Size(), modTime: info.ModTime()}
+}
+
+// Option はFileRepositoryの設定を変更する
+type Option func(*FileRepository)
+
+// WithLockTimeout はロック取得を待つ時間を設定する
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(f *FileRepository) {
+		f.lockTimeout = timeout
+	}
 }
 
 // NewFileRepository は新しいFileRepositoryを作成する
-func NewFileRepository(dataDir string) Repository {
-	return &FileRepository{
-		dataDir:  dataDir,
-		fileName: "tasks.json",
+func NewFileRepository(dataDir string, opts ...Option) Repository {
+	return newFileRepositoryWithFS(dataDir, osFileSystem{}, opts...)
+}
+
+// newFileRepositoryWithFS は指定されたファイルシステムを使用するFileRepositoryを作成する
+func newFileRepositoryWithFS(dataDir string, fs fileSystem, opts ...Option) *FileRepository {
+	repo := &FileRepository{
+		dataDir:     dataDir,
+		fileName:    "tasks.json",
+		fs:          fs,
+		lockTimeout: defaultLockTimeout,
+		retention:   DefaultRetentionPolicy,
+
+		compactThreshold: DefaultCompactThreshold,
 	}
+	for _, opt := range opts {
+		opt(repo)
+	}
+	return repo
 }
 
 // Save はAppDataをファイルに保存する
+// 既存のファイルが破損していて退避されていない場合は上書きを拒否する
 func (f *FileRepository) Save(ctx context.Context, data *model.AppData) error {
 	if data == nil {
 		return errors.New("data cannot be nil")
@@ -38,72 +106,132 @@ func (f *FileRepository) Save(ctx context.Context, data *model.AppData) error {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	// JSONにエンコード
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	// 破損したファイルを退避前に上書きしないようにする
+	if err := f.checkOverwritable(); err != nil {
+		return err
+	}
+
+	// JSONにエンコード（常に現在のスキーマバージョンで書き込む。呼び出し元のデータは変更しない）
+	versioned := *data
+	versioned.SchemaVersion = CurrentSchemaVersion
+	jsonData, err := json.MarshalIndent(&versioned, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal data: %w", err)
 	}
 
-	// ファイルに書き込み
+	// 一時ファイル経由で原子的に書き込み（失敗しても既存のファイルは残る）
 	filePath := f.getDataFilePath()
-	err = ioutil.WriteFile(filePath, jsonData, 0644)
+	err = atomicWriteFile(f.fs, filePath, jsonData, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
+	f.recordVerified(filePath)
 
 	return nil
 }
 
 // Load はファイルからAppDataを読み込む
+// ファイルが存在しない場合はErrNotFound、解析できない場合は退避した上でCorruptErrorを返す
 func (f *FileRepository) Load(ctx context.Context) (*model.AppData, error) {
 	filePath := f.getDataFilePath()
 
 	// ファイルが存在するかチェック
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, errors.New("data file does not exist")
+	info, err := f.fs.Stat(filePath)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
 	}
 
 	// ファイルを読み込み
-	jsonData, err := ioutil.ReadFile(filePath)
+	jsonData, err := f.fs.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// JSONをデコード
-	var appData model.AppData
-	err = json.Unmarshal(jsonData, &appData)
+	// JSONをデコードし、古いスキーマの場合は移行する
+	appData, version, err := decodeAppData(jsonData)
+	var schemaErr *SchemaVersionError
+	if errors.As(err, &schemaErr) {
+		schemaErr.Path = filePath
+		return nil, schemaErr
+	}
+	if errors.Is(err, errMigration) {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
+		corruptErr := &CorruptError{Path: filePath, Err: err}
+		if quarantinePath, qerr := f.quarantine(jsonData); qerr == nil {
+			corruptErr.QuarantinePath = quarantinePath
+		}
+		return nil, corruptErr
+	}
+
+	// 移行した場合は元のファイルをバックアップしておく（次回の保存で新しいスキーマになる）
+	if version < CurrentSchemaVersion {
+		if err := f.backupBeforeMigration(jsonData, version); err != nil {
+			return nil, fmt.Errorf("failed to back up data before migration: %w", err)
+		}
+	}
+
+	// 読み込む前の状態を記録する（読み込み中に変更された場合は次の保存で改めて確認する）
+	if info != nil {
+		f.setVerified(newFileStamp(info))
 	}
 
-	return &appData, nil
+	return appData, nil
 }
 
-// CreateBackup はデータのバックアップを作成する
+// CreateBackup はデータのバックアップを作成し、保持方針に従って古いバックアップを削除する
 func (f *FileRepository) CreateBackup(ctx context.Context, data *model.AppData) (string, error) {
+	backupPath, err := f.writeBackup(data)
+	if err != nil {
+		return "", err
+	}
+
+	// 作成したバックアップは最新なので削除対象にならない
+	backups, err := f.ListBackups(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := f.pruneBackups(backups); err != nil {
+		return "", err
+	}
+
+	return backupPath, nil
+}
+
+// CreateSafetyBackup は古いバックアップを削除せずにデータのバックアップを作成する
+// 復元に使うバックアップが保持方針で削除されないよう、復元前の退避に使う
+func (f *FileRepository) CreateSafetyBackup(ctx context.Context, data *model.AppData) (string, error) {
+	return f.writeBackup(data)
+}
+
+// writeBackup はタイムスタンプを含む名前でデータのバックアップを書き込む
+func (f *FileRepository) writeBackup(data *model.AppData) (string, error) {
 	if data == nil {
 		return "", errors.New("data cannot be nil")
 	}
 
 	// バックアップディレクトリを確保
-	backupDir := filepath.Join(f.dataDir, "backups")
-	if err := os.MkdirAll(backupDir, 0755); err != nil {
+	backupDir := f.getBackupDir()
+	if err := f.fs.MkdirAll(backupDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
 	// タイムスタンプを含むバックアップファイル名を生成
-	timestamp := time.Now().Format("20060102_150405")
-	backupFileName := fmt.Sprintf("tasks_backup_%s.json", timestamp)
+	timestamp := time.Now().Format(backupTimestampLayout)
+	backupFileName := fmt.Sprintf("%s%s.json", backupFilePrefix, timestamp)
 	backupPath := filepath.Join(backupDir, backupFileName)
 
-	// JSONにエンコード
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	// JSONにエンコード（呼び出し元のデータは変更しない）
+	versioned := *data
+	versioned.SchemaVersion = CurrentSchemaVersion
+	jsonData, err := json.MarshalIndent(&versioned, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal backup data: %w", err)
 	}
 
 	// バックアップファイルに書き込み
-	err = ioutil.WriteFile(backupPath, jsonData, 0644)
+	err = atomicWriteFile(f.fs, backupPath, jsonData, 0644)
 	if err != nil {
 		return "", fmt.Errorf("failed to write backup file: %w", err)
 	}
@@ -118,32 +246,236 @@ func (f *FileRepository) RestoreFromBackup(ctx context.Context, backupPath strin
 	}
 
 	// バックアップファイルが存在するかチェック
-	if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+	if _, err := f.fs.Stat(backupPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("backup file does not exist: %s", backupPath)
 	}
 
 	// バックアップファイルを読み込み
-	jsonData, err := ioutil.ReadFile(backupPath)
+	jsonData, err := f.fs.ReadFile(backupPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read backup file: %w", err)
 	}
 
-	// JSONをデコード
-	var appData model.AppData
-	err = json.Unmarshal(jsonData, &appData)
+	// JSONをデコード（古いスキーマのバックアップは移行する）
+	appData, _, err := decodeAppData(jsonData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal backup data: %w", err)
 	}
 
-	return &appData, nil
+	return appData, nil
+}
+
+// ListBackups はバックアップを新しい順に返す
+func (f *FileRepository) ListBackups(ctx context.Context) ([]BackupInfo, error) {
+	entries, err := f.fs.ReadDir(f.getBackupDir())
+	if os.IsNotExist(err) {
+		return []BackupInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := make([]BackupInfo, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, backupFilePrefix) || filepath.Ext(name) != ".json" {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		// ファイル名のタイムスタンプを優先し、解析できなければ更新日時を使う
+		createdAt := info.ModTime()
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, backupFilePrefix), ".json")
+		if parsed, ok := parseBackupTimestamp(timestamp); ok {
+			createdAt = parsed
+		}
+
+		backups = append(backups, BackupInfo{
+			Name:      name,
+			Path:      filepath.Join(f.getBackupDir(), name),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		if backups[i].CreatedAt.Equal(backups[j].CreatedAt) {
+			return backups[i].Name > backups[j].Name
+		}
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// parseBackupTimestamp はバックアップファイル名のタイムスタンプを解析する
+func parseBackupTimestamp(timestamp string) (time.Time, bool) {
+	for _, layout := range []string{backupTimestampLayout, legacyBackupTimestampLayout} {
+		if parsed, err := time.ParseInLocation(layout, timestamp, time.Local); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// checkOverwritable は既存のデータファイルを上書きしてよいかを確認する
+// 最後に読み込みまたは保存したときから変わっていないファイルは、読み直さずに上書きを許可する
+// 解析できないファイルは、退避済みのコピーが存在する場合のみ上書きを許可する
+func (f *FileRepository) checkOverwritable() error {
+	filePath := f.getDataFilePath()
+	info, err := f.fs.Stat(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err == nil && f.isVerified(newFileStamp(info)) {
+		return nil
+	}
+
+	jsonData, err := f.fs.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	_, _, err = decodeAppData(jsonData)
+	if err == nil {
+		return nil
+	}
+
+	// 新しいバージョンで書き込まれたデータは上書きすると失われるため保存しない
+	var schemaErr *SchemaVersionError
+	if errors.As(err, &schemaErr) {
+		schemaErr.Path = filePath
+		return fmt.Errorf("refusing to overwrite: %w", schemaErr)
+	}
+	if _, statErr := f.fs.Stat(f.quarantinePath(jsonData)); statErr != nil {
+		return &CorruptError{Path: filePath, Err: fmt.Errorf("refusing to overwrite: %w", err)}
+	}
+	return nil
+}
+
+// recordVerified は保存したデータファイルの状態を記録する（取得できない場合は記録を消す）
+func (f *FileRepository) recordVerified(filePath string) {
+	info, err := f.fs.Stat(filePath)
+	if err != nil {
+		f.setVerified(fileStamp{})
+		return
+	}
+	f.setVerified(newFileStamp(info))
+}
+
+// setVerified は上書きしてよいと確認したデータファイルの状態を記録する
+func (f *FileRepository) setVerified(stamp fileStamp) {
+	f.verifiedMu.Lock()
+	defer f.verifiedMu.Unlock()
+	f.verified = stamp
+}
+
+// isVerified はデータファイルが最後に確認したときから変わっていないかを返す
+func (f *FileRepository) isVerified(stamp fileStamp) bool {
+	f.verifiedMu.Lock()
+	defer f.verifiedMu.Unlock()
+	return !f.verified.modTime.IsZero() && f.verified.size == stamp.size && f.verified.modTime.Equal(stamp.modTime)
+}
+
+// quarantine は破損したデータのコピーを quarantine ディレクトリに退避し、そのパスを返す
+// 同じ内容が退避済みの場合は再度書き込まない
+func (f *FileRepository) quarantine(jsonData []byte) (string, error) {
+	return f.writeQuarantine(f.quarantinePath(jsonData), jsonData)
+}
+
+// writeQuarantine は破損したファイルの内容を path に退避する（既に存在する場合は書き込まない）
+func (f *FileRepository) writeQuarantine(path string, jsonData []byte) (string, error) {
+	if _, err := f.fs.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := f.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+	if err := atomicWriteFile(f.fs, path, jsonData, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// quarantinePath は破損したデータの退避先パスを内容のハッシュから決定する
+func (f *FileRepository) quarantinePath(jsonData []byte) string {
+	sum := sha256.Sum256(jsonData)
+	name := fmt.Sprintf("tasks_corrupt_%s.json", hex.EncodeToString(sum[:6]))
+	return filepath.Join(f.dataDir, "quarantine", name)
+}
+
+// decodeAppData はJSONからAppDataをデコードし、元のスキーマバージョンを返す
+// 古いスキーマは現在のスキーマに移行し、新しいスキーマの場合はSchemaVersionErrorを返す
+func decodeAppData(jsonData []byte) (*model.AppData, int, error) {
+	if len(bytes.TrimSpace(jsonData)) == 0 {
+		return nil, 0, errors.New("file is empty")
+	}
+
+	doc, err := unmarshalDocument(jsonData)
+	if err != nil {
+		return nil, 0, err
+	}
+	version, err := documentSchemaVersion(doc)
+	if err != nil {
+		return nil, 0, err
+	}
+	if version > CurrentSchemaVersion {
+		return nil, version, &SchemaVersionError{Version: version, Supported: CurrentSchemaVersion}
+	}
+
+	// 現在のスキーマであればそのままデコードする
+	if version < CurrentSchemaVersion {
+		if err := migrateDocument(doc, version); err != nil {
+			return nil, version, err
+		}
+		if jsonData, err = json.Marshal(doc); err != nil {
+			return nil, version, err
+		}
+	}
+
+	var appData model.AppData
+	if err := json.Unmarshal(jsonData, &appData); err != nil {
+		return nil, version, err
+	}
+	appData.EnsureDefaultProject()
+	return &appData, version, nil
+}
+
+// backupBeforeMigration は移行前のファイルの内容をそのままバックアップする
+// 同じ内容のバックアップが既にある場合は再度書き込まない
+func (f *FileRepository) backupBeforeMigration(jsonData []byte, version int) error {
+	sum := sha256.Sum256(jsonData)
+	name := fmt.Sprintf("%s%d_%s.json", migrationBackupPrefix, version, hex.EncodeToString(sum[:6]))
+	path := filepath.Join(f.getBackupDir(), name)
+	if _, err := f.fs.Stat(path); err == nil {
+		return nil
+	}
+
+	if err := f.fs.MkdirAll(f.getBackupDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	return atomicWriteFile(f.fs, path, jsonData, 0644)
 }
 
 // ensureDataDir はデータディレクトリが存在することを確認し、必要に応じて作成する
 func (f *FileRepository) ensureDataDir() error {
-	return os.MkdirAll(f.dataDir, 0755)
+	return f.fs.MkdirAll(f.dataDir, 0755)
 }
 
 // getDataFilePath はデータファイルのフルパスを返す
 func (f *FileRepository) getDataFilePath() string {
 	return filepath.Join(f.dataDir, f.fileName)
-}
\ No newline at end of file
+}
+
+// getBackupDir はバックアップディレクトリのパスを返す
+func (f *FileRepository) getBackupDir() string {
+	return filepath.Join(f.dataDir, "backups")
+}
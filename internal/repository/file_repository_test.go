@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"task-cli/internal/model"
 
@@ -194,4 +195,182 @@ func TestFileRepository_CreateBackup_WithNilData_ShouldReturnError(t *testing.T)
 	// Then
 	assert.Error(t, err)
 	assert.Empty(t, backupPath)
-}
\ No newline at end of file
+}
+
+func TestFileRepository_Load_WithNonexistentFile_ShouldReturnErrNotFound(t *testing.T) {
+	// Given
+	repo := NewFileRepository(t.TempDir())
+
+	// When
+	_, err := repo.Load(context.Background())
+
+	// Then
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFileRepository_Load_WithCorruptedFile_ShouldQuarantineCopy(t *testing.T) {
+	// Given
+	tempDir := t.TempDir()
+	dataFile := filepath.Join(tempDir, "tasks.json")
+	corrupt := []byte(`{"id": "x", "tasks": [`)
+	assert.NoError(t, os.WriteFile(dataFile, corrupt, 0644))
+
+	repo := NewFileRepository(tempDir)
+
+	// When
+	_, err := repo.Load(context.Background())
+
+	// Then
+	assert.ErrorIs(t, err, ErrCorrupt)
+	var corruptErr *CorruptError
+	assert.ErrorAs(t, err, &corruptErr)
+	assert.NotEmpty(t, corruptErr.QuarantinePath)
+
+	quarantined, readErr := os.ReadFile(corruptErr.QuarantinePath)
+	assert.NoError(t, readErr)
+	assert.Equal(t, corrupt, quarantined)
+
+	// 元のファイルはそのまま残っている
+	current, _ := os.ReadFile(dataFile)
+	assert.Equal(t, corrupt, current)
+}
+
+func TestFileRepository_Save_OverUnquarantinedCorruptFile_ShouldRefuse(t *testing.T) {
+	// Given
+	tempDir := t.TempDir()
+	dataFile := filepath.Join(tempDir, "tasks.json")
+	corrupt := []byte("not json at all")
+	assert.NoError(t, os.WriteFile(dataFile, corrupt, 0644))
+
+	repo := NewFileRepository(tempDir)
+
+	// When
+	err := repo.Save(context.Background(), model.NewAppData())
+
+	// Then
+	assert.ErrorIs(t, err, ErrCorrupt)
+	current, _ := os.ReadFile(dataFile)
+	assert.Equal(t, corrupt, current)
+}
+
+func TestFileRepository_Save_AfterCorruptFileWasQuarantined_ShouldSucceed(t *testing.T) {
+	// Given
+	tempDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "tasks.json"), []byte("garbage"), 0644))
+
+	repo := NewFileRepository(tempDir)
+	ctx := context.Background()
+	_, loadErr := repo.Load(ctx)
+	assert.ErrorIs(t, loadErr, ErrCorrupt)
+
+	// When
+	err := repo.Save(ctx, model.NewAppData())
+
+	// Then
+	assert.NoError(t, err)
+	_, err = repo.Load(ctx)
+	assert.NoError(t, err)
+}
+
+func TestFileRepository_Save_AfterLoad_ShouldNotReadDataFileAgain(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	fs := &countingFileSystem{}
+	repo := newFileRepositoryWithFS(t.TempDir(), fs)
+	assert.NoError(t, repo.Save(ctx, model.NewAppData()))
+	loaded, err := repo.Load(ctx)
+	assert.NoError(t, err)
+
+	// When
+	fs.reads = nil
+	err = repo.Save(ctx, loaded)
+	secondErr := repo.Save(ctx, loaded)
+
+	// Then - 変わっていないファイルは上書きできるか確認するために読み直さない
+	assert.NoError(t, err)
+	assert.NoError(t, secondErr)
+	assert.NotContains(t, fs.reads, "tasks.json")
+}
+
+func TestFileRepository_Save_WhenFileBecameCorruptAfterLoad_ShouldRefuse(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	dataFile := filepath.Join(tempDir, "tasks.json")
+	repo := NewFileRepository(tempDir)
+	assert.NoError(t, repo.Save(ctx, model.NewAppData()))
+	loaded, err := repo.Load(ctx)
+	assert.NoError(t, err)
+
+	// 他のプロセスが壊れた内容で書き換える
+	corrupt := []byte("not json at all")
+	assert.NoError(t, os.WriteFile(dataFile, corrupt, 0644))
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(dataFile, later, later))
+
+	// When
+	err = repo.Save(ctx, loaded)
+
+	// Then
+	assert.ErrorIs(t, err, ErrCorrupt)
+	current, _ := os.ReadFile(dataFile)
+	assert.Equal(t, corrupt, current)
+}
+
+func TestFileRepository_ListBackups_ShouldReturnNewestFirst(t *testing.T) {
+	// Given
+	tempDir := t.TempDir()
+	backupDir := filepath.Join(tempDir, "backups")
+	assert.NoError(t, os.MkdirAll(backupDir, 0755))
+	for _, name := range []string{
+		"tasks_backup_20260101_120000.json",
+		"tasks_backup_20260301_120000.json",
+		"tasks_backup_20260201_120000.json",
+		"unrelated.txt",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(backupDir, name), []byte("{}"), 0644))
+	}
+
+	repo := NewFileRepository(tempDir)
+
+	// When
+	backups, err := repo.ListBackups(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, backups, 3)
+	assert.Equal(t, "tasks_backup_20260301_120000.json", backups[0].Name)
+	assert.Equal(t, "tasks_backup_20260101_120000.json", backups[2].Name)
+	assert.Equal(t, int64(2), backups[0].Size)
+}
+
+func TestFileRepository_ListBackups_WithoutBackupDir_ShouldReturnEmpty(t *testing.T) {
+	// Given
+	repo := NewFileRepository(t.TempDir())
+
+	// When
+	backups, err := repo.ListBackups(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, backups)
+}
+
+func TestFileRepository_SaveAndCreateBackup_ShouldNotChangeCallersData(t *testing.T) {
+	// Given - 移行前のバージョンを持つデータ
+	ctx := context.Background()
+	repo := NewFileRepository(t.TempDir())
+	appData := model.NewAppData()
+	appData.SchemaVersion = 1
+
+	// When
+	assert.NoError(t, repo.Save(ctx, appData))
+	_, err := repo.CreateBackup(ctx, appData)
+	assert.NoError(t, err)
+
+	// Then - 書き込んだファイルは現在のバージョンになる
+	assert.Equal(t, 1, appData.SchemaVersion)
+	loaded, err := repo.Load(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, CurrentSchemaVersion, loaded.SchemaVersion)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// fileSystem はFileRepositoryが使用するファイルシステム操作を抽象化する
+// テストでは書き込み途中の失敗などを再現する実装に差し替える
+type fileSystem interface {
+	MkdirAll(path string, perm os.FileMode) error
+	ReadFile(name string) ([]byte, error)
+	ReadDir(name string) ([]os.DirEntry, error)
+	Stat(name string) (os.FileInfo, error)
+	CreateTemp(dir, pattern string) (writableFile, error)
+	OpenFile(name string, flag int, perm os.FileMode) (writableFile, error)
+	Rename(oldPath, newPath string) error
+	Remove(name string) error
+	SyncDir(dir string) error
+}
+
+// writableFile は一時ファイルへの書き込みに必要な操作
+type writableFile interface {
+	io.Writer
+	Name() string
+	Chmod(mode os.FileMode) error
+	Sync() error
+	Close() error
+}
+
+// osFileSystem はosパッケージによるfileSystemの実装
+type osFileSystem struct{}
+
+func (osFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFileSystem) CreateTemp(dir, pattern string) (writableFile, error) {
+	return os.CreateTemp(dir, pattern)
+}
+
+func (osFileSystem) OpenFile(name string, flag int, perm os.FileMode) (writableFile, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+func (osFileSystem) Rename(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+func (osFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// SyncDir はディレクトリエントリ（renameの結果）をディスクに反映する
+func (osFileSystem) SyncDir(dir string) error {
+	// Windowsではディレクトリをfsyncできないため何もしない
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// WriteFileAtomic はファイルを一時ファイル経由で原子的に書き込む
+// 設定ファイルなど、Repositoryの管理外のファイルをデータファイルと同じ方法で書き込むために使う
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return atomicWriteFile(osFileSystem{}, path, data, perm)
+}
+
+// atomicWriteFile はファイルを一時ファイル経由で原子的に書き込む
+// 一時ファイルへの書き込み・fsync・renameのいずれかが失敗した場合、既存のファイルは変更されない
+// renameの後はファイルが置き換わっているため、ディレクトリのfsyncに失敗しても成功として扱う
+func atomicWriteFile(fs fileSystem, path string, data []byte, perm os.FileMode) (err error) {
+	dir := filepath.Dir(path)
+
+	tmp, err := fs.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	// 失敗時は一時ファイルを必ず削除する
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			fs.Remove(tmpPath)
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err = fs.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+
+	// renameをディスクに反映する（失敗しても新しいファイル自体は完全な状態のため、エラーにはしない）
+	_ = fs.SyncDir(dir)
+	return nil
+}
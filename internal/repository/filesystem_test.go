@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errDiskFull は書き込み途中でディスクが一杯になった状況を表すテスト用エラー
+var errDiskFull = errors.New("no space left on device")
+
+// faultyFileSystem は指定した操作を失敗させるfileSystemのテスト実装
+type faultyFileSystem struct {
+	osFileSystem
+	writeLimit  int // 0以上の場合、一時ファイルにこのバイト数だけ書いて失敗する
+	failSync    bool
+	failRename  bool
+	failSyncDir bool
+	syncedDirs  []string
+	createdTemp []string
+}
+
+func newFaultyFileSystem() *faultyFileSystem {
+	return &faultyFileSystem{writeLimit: -1}
+}
+
+func (f *faultyFileSystem) CreateTemp(dir, pattern string) (writableFile, error) {
+	file, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	f.createdTemp = append(f.createdTemp, file.Name())
+	return &faultyFile{File: file, fs: f}, nil
+}
+
+func (f *faultyFileSystem) Rename(oldPath, newPath string) error {
+	if f.failRename {
+		return errors.New("rename failed")
+	}
+	return os.Rename(oldPath, newPath)
+}
+
+func (f *faultyFileSystem) SyncDir(dir string) error {
+	f.syncedDirs = append(f.syncedDirs, dir)
+	if f.failSyncDir {
+		return errors.New("directory fsync failed")
+	}
+	return nil
+}
+
+// faultyFile は書き込みやfsyncを失敗させるwritableFile
+type faultyFile struct {
+	*os.File
+	fs *faultyFileSystem
+}
+
+func (f *faultyFile) Write(p []byte) (int, error) {
+	if f.fs.writeLimit >= 0 && len(p) > f.fs.writeLimit {
+		n, _ := f.File.Write(p[:f.fs.writeLimit])
+		return n, errDiskFull
+	}
+	return f.File.Write(p)
+}
+
+func (f *faultyFile) Sync() error {
+	if f.fs.failSync {
+		return errors.New("fsync failed")
+	}
+	return f.File.Sync()
+}
+
+// saveInitialData は正常なデータを保存し、その内容を返す
+func saveInitialData(t *testing.T, dataDir string) []byte {
+	t.Helper()
+
+	repo := NewFileRepository(dataDir)
+	appData := model.NewAppData()
+	task, _ := model.NewTask("Good Task", "Description", model.PriorityMedium, []string{"safe"})
+	appData.AddTask(task)
+	require.NoError(t, repo.Save(context.Background(), appData))
+
+	data, err := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, err)
+	return data
+}
+
+// assertNoTempFiles は一時ファイルが残っていないことを確認する
+func assertNoTempFiles(t *testing.T, dataDir string) {
+	t.Helper()
+
+	matches, err := filepath.Glob(filepath.Join(dataDir, ".tasks.json.tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches, "temp files should be cleaned up")
+}
+
+// newLargeAppData は書き込みが複数回に分かれる程度の大きさのデータを作成する
+func newLargeAppData() *model.AppData {
+	appData := model.NewAppData()
+	for i := 0; i < 50; i++ {
+		task, _ := model.NewTask("Replacement Task", "Replacement description", model.PriorityHigh, []string{"new"})
+		appData.AddTask(task)
+	}
+	return appData
+}
+
+func TestFileRepository_Save_WithPartialWrite_ShouldKeepPreviousFile(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	original := saveInitialData(t, dataDir)
+
+	fs := newFaultyFileSystem()
+	fs.writeLimit = 100
+	repo := newFileRepositoryWithFS(dataDir, fs)
+
+	// When
+	err := repo.Save(context.Background(), newLargeAppData())
+
+	// Then
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, errDiskFull)
+	current, readErr := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, readErr)
+	assert.Equal(t, original, current)
+	assertNoTempFiles(t, dataDir)
+}
+
+func TestFileRepository_Save_WithSyncFailure_ShouldKeepPreviousFile(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	original := saveInitialData(t, dataDir)
+
+	fs := newFaultyFileSystem()
+	fs.failSync = true
+	repo := newFileRepositoryWithFS(dataDir, fs)
+
+	// When
+	err := repo.Save(context.Background(), newLargeAppData())
+
+	// Then
+	assert.Error(t, err)
+	current, readErr := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, readErr)
+	assert.Equal(t, original, current)
+	assertNoTempFiles(t, dataDir)
+}
+
+func TestFileRepository_Save_WithRenameFailure_ShouldKeepPreviousFile(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	original := saveInitialData(t, dataDir)
+
+	fs := newFaultyFileSystem()
+	fs.failRename = true
+	repo := newFileRepositoryWithFS(dataDir, fs)
+
+	// When
+	err := repo.Save(context.Background(), newLargeAppData())
+
+	// Then
+	assert.Error(t, err)
+	current, readErr := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, readErr)
+	assert.Equal(t, original, current)
+	assertNoTempFiles(t, dataDir)
+}
+
+func TestFileRepository_Save_ShouldWriteThroughTempFileAndSyncDirectory(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	fs := newFaultyFileSystem()
+	repo := newFileRepositoryWithFS(dataDir, fs)
+
+	// When
+	err := repo.Save(context.Background(), newLargeAppData())
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, fs.createdTemp, 1)
+	assert.Equal(t, dataDir, filepath.Dir(fs.createdTemp[0]))
+	assert.Equal(t, []string{dataDir}, fs.syncedDirs)
+	assertNoTempFiles(t, dataDir)
+
+	info, err := os.Stat(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	loaded, err := repo.Load(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, loaded.Tasks, 50)
+}
+
+func TestFileRepository_Save_WhenDirectorySyncFails_ShouldSucceed(t *testing.T) {
+	// Given - renameの後のディレクトリのfsyncだけが失敗する
+	dataDir := t.TempDir()
+	fs := newFaultyFileSystem()
+	fs.failSyncDir = true
+	repo := newFileRepositoryWithFS(dataDir, fs)
+
+	// When
+	err := repo.Save(context.Background(), newLargeAppData())
+
+	// Then - ファイルは置き換わっているため成功として扱う
+	require.NoError(t, err)
+	assert.Equal(t, []string{dataDir}, fs.syncedDirs)
+	loaded, err := repo.Load(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, loaded.Tasks, 50)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"task-cli/internal/model"
+)
+
+// historyFileName は元に戻す操作の履歴のファイル名
+const historyFileName = "undo.json"
+
+// LoadHistory はファイルから操作の履歴を読み込む
+// ファイルが存在しない場合は空の履歴を返す
+func (f *FileRepository) LoadHistory(ctx context.Context) (*model.History, error) {
+	filePath := f.getHistoryFilePath()
+
+	jsonData, err := f.fs.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return model.NewHistory(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read undo history: %w", err)
+	}
+
+	history := model.NewHistory()
+	if err := json.Unmarshal(jsonData, history); err != nil {
+		return nil, &CorruptError{Path: filePath, Err: err}
+	}
+	if history.Undo == nil {
+		history.Undo = []model.Operation{}
+	}
+	if history.Redo == nil {
+		history.Redo = []model.Operation{}
+	}
+	return history, nil
+}
+
+// SaveHistory は操作の履歴をファイルに保存する
+func (f *FileRepository) SaveHistory(ctx context.Context, history *model.History) error {
+	if err := f.ensureDataDir(); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal undo history: %w", err)
+	}
+
+	if err := atomicWriteFile(f.fs, f.getHistoryFilePath(), jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write undo history: %w", err)
+	}
+	return nil
+}
+
+// getHistoryFilePath は操作の履歴のファイルのフルパスを返す
+func (f *FileRepository) getHistoryFilePath() string {
+	return filepath.Join(f.dataDir, historyFileName)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRepository_ShouldImplementHistoryRepository(t *testing.T) {
+	// When
+	repo := NewFileRepository(t.TempDir())
+
+	// Then
+	assert.Implements(t, (*HistoryRepository)(nil), repo)
+}
+
+func TestFileRepository_LoadHistory_WithoutFile_ShouldReturnEmpty(t *testing.T) {
+	// Given
+	repo := newFileRepositoryWithFS(t.TempDir(), osFileSystem{})
+
+	// When
+	history, err := repo.LoadHistory(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, model.NewHistory(), history)
+}
+
+func TestFileRepository_SaveHistory_ShouldRoundTrip(t *testing.T) {
+	// Given
+	dataDir := filepath.Join(t.TempDir(), "data")
+	repo := newFileRepositoryWithFS(dataDir, osFileSystem{})
+	at := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	task := &model.Task{ID: "1", Title: "Write docs", Status: model.StatusTodo, Priority: model.PriorityLow, Tags: []string{"docs"}, CreatedAt: at, UpdatedAt: at, Revision: 1}
+	history := model.NewHistory()
+	history.Record(model.Operation{Description: `delete "Write docs"`, At: at, Before: []*model.Task{task}, After: []*model.Task{}}, 0)
+
+	// When
+	require.NoError(t, repo.SaveHistory(context.Background(), history))
+	loaded, err := repo.LoadHistory(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, history, loaded)
+	assert.FileExists(t, filepath.Join(dataDir, "undo.json"))
+}
+
+func TestFileRepository_LoadHistory_WithCorruptFile_ShouldReturnCorruptError(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "undo.json"), []byte("{not json"), 0644))
+	repo := newFileRepositoryWithFS(dataDir, osFileSystem{})
+
+	// When
+	_, err := repo.LoadHistory(context.Background())
+
+	// Then
+	assert.True(t, errors.Is(err, ErrCorrupt))
+	assert.Contains(t, err.Error(), "undo.json")
+}
@@ -0,0 +1,565 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"task-cli/internal/model"
+)
+
+const (
+	// journalFileName はタスクの変更を追記するジャーナルのファイル名
+	journalFileName = "journal.jsonl"
+
+	// journalArchiveDir はスナップショットにまとめたジャーナルを保管するディレクトリ名
+	journalArchiveDir = "journal"
+
+	// DefaultCompactThreshold はスナップショットにまとめるまでにジャーナルに追記するイベント数の既定値
+	DefaultCompactThreshold = 500
+)
+
+// EventType はジャーナルに記録するタスクの変更の種類
+type EventType string
+
+const (
+	EventTaskCreated   EventType = "task_created"
+	EventTaskUpdated   EventType = "task_updated"
+	EventTaskCompleted EventType = "task_completed"
+	EventTaskReopened  EventType = "task_reopened"
+	EventTaskDeleted   EventType = "task_deleted"
+)
+
+// Event はジャーナルに1行ずつ追記するタスクの変更
+type Event struct {
+	Type     EventType   `json:"type"`
+	At       time.Time   `json:"at"`
+	Revision int64       `json:"revision"` // 変更後のデータ全体のリビジョン
+	TaskID   string      `json:"task_id"`
+	Task     *model.Task `json:"task,omitempty"` // 変更後のタスク（削除の場合は含まない）
+}
+
+// WithCompactThreshold はJournalRepositoryがスナップショットにまとめるまでに追記するイベント数を設定する
+func WithCompactThreshold(events int) Option {
+	return func(f *FileRepository) {
+		f.compactThreshold = events
+	}
+}
+
+// JournalRepository はタスクの変更をイベントとしてジャーナルに追記するRepository実装
+// tasks.json をスナップショットとして使い、読み込み時はスナップショットより新しいイベントを再生する
+// イベントが一定数に達するとスナップショットにまとめ、それまでのジャーナルは journal ディレクトリに保管する
+// 保管したジャーナルはバックアップと同じ保持方針で削除する
+// 再生した状態はファイルが変わらない限り再利用し、保存ではタスクごとのハッシュと比べて変わったタスクだけを追記する
+// バックアップ、ロック、ビュー、操作の履歴はFileRepositoryと共通
+type JournalRepository struct {
+	*FileRepository
+
+	mu    sync.Mutex
+	cache *journalCache // 最後に読み書きした状態（nil の場合はファイルから読み込む）
+}
+
+// journalState はスナップショットにジャーナルを再生した結果
+type journalState struct {
+	data   *model.AppData
+	events []Event // ジャーナルに記録されているイベント
+	torn   bool    // 最後の行が書き込み途中で途切れている
+}
+
+// taskDigest はタスクのJSON表現のハッシュ
+type taskDigest [sha256.Size]byte
+
+// journalCache は再生した状態と、その時点のスナップショットとジャーナルのファイル情報
+// 他のプロセスが書き込むとファイルの実体、大きさ、更新日時のいずれかが変わるため、その場合は読み込み直す
+type journalCache struct {
+	snapshot os.FileInfo // 存在しない場合は nil
+	journal  os.FileInfo // 存在しない場合は nil
+	state    *journalState
+	digests  map[string]taskDigest // タスクのIDごとのハッシュ（最初の保存時に計算する）
+}
+
+// NewJournalRepository はデータディレクトリのジャーナルを正とするRepositoryを作成する
+// 既存の tasks.json はそのままスナップショットとして使用する
+func NewJournalRepository(dataDir string, opts ...Option) Repository {
+	return newJournalRepositoryWithFS(dataDir, osFileSystem{}, opts...)
+}
+
+// newJournalRepositoryWithFS は指定されたファイルシステムを使用するJournalRepositoryを作成する
+func newJournalRepositoryWithFS(dataDir string, fs fileSystem, opts ...Option) *JournalRepository {
+	return &JournalRepository{FileRepository: newFileRepositoryWithFS(dataDir, fs, opts...)}
+}
+
+// Load はスナップショットを読み込み、ジャーナルのイベントを再生したAppDataを返す
+// どちらも存在しない場合はErrNotFound、解析できない場合は退避した上でCorruptErrorを返す
+func (j *JournalRepository) Load(ctx context.Context) (*model.AppData, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	state, err := j.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 再利用する状態を呼び出し側の変更から守る
+	return state.data.Clone(), nil
+}
+
+// Save はスナップショットとの差分をイベントとしてジャーナルに追記する
+// イベント数が上限に達した場合や追記では表せない変更（タスクの並び替えやプロジェクトの変更など）は、追記した後にスナップショットにまとめる
+// 保存済みのデータがない、または破損して退避済みの場合はスナップショットとして書き込む
+func (j *JournalRepository) Save(ctx context.Context, data *model.AppData) error {
+	if data == nil {
+		return errors.New("data cannot be nil")
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	state, err := j.load(ctx)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrCorrupt) {
+		return j.compact(ctx, data)
+	}
+	if err != nil {
+		return err
+	}
+	cache := j.cache
+	if cache.digests == nil {
+		if cache.digests, err = digestTasks(state.data.Tasks); err != nil {
+			return err
+		}
+	}
+
+	// リビジョンは常に進めて、スナップショットより古いイベントを再生しないようにする
+	// 呼び出し元のデータは変更せず、複製に設定する
+	if data.Revision <= state.data.Revision {
+		revised := *data
+		revised.Revision = state.data.Revision + 1
+		data = &revised
+	}
+
+	events, digests, err := diffEvents(state.data, cache.digests, data, time.Now())
+	if err != nil {
+		return err
+	}
+	projectsChanged := !projectsEqual(state.data.Projects, data.Projects)
+	if len(events) == 0 && !projectsChanged {
+		return nil
+	}
+	if len(events) > 0 {
+		// 途切れた行の後ろには追記せず、読み込めたイベントと合わせて書き直す
+		if state.torn {
+			err = j.writeEvents(append(state.events, events...))
+		} else {
+			err = j.appendEvents(events)
+		}
+		if err != nil {
+			j.cache = nil
+			return err
+		}
+	}
+
+	// 書き込んだイベントを再利用する状態にも適用する
+	for _, event := range events {
+		applyEvent(state.data, event)
+		if event.Type == EventTaskDeleted {
+			delete(cache.digests, event.TaskID)
+		} else {
+			cache.digests[event.TaskID] = digests[event.TaskID]
+		}
+	}
+	state.events = append(state.events, events...)
+	state.torn = false
+	cache.journal = j.stat(j.getJournalFilePath())
+
+	if len(state.events) >= j.compactThreshold || !sameTaskOrder(state.data, data) || projectsChanged {
+		return j.compact(ctx, data)
+	}
+	return nil
+}
+
+// Events は保管したジャーナルと現在のジャーナルのイベントを古い順に返す
+// スナップショットにまとめた変更もジャーナルを保管している限り参照できる
+func (j *JournalRepository) Events(ctx context.Context) ([]Event, error) {
+	entries, err := j.fs.ReadDir(j.getJournalArchiveDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read journal archive: %w", err)
+	}
+
+	// 保管したジャーナルのファイル名は日時順に並ぶ
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".jsonl") {
+			paths = append(paths, filepath.Join(j.getJournalArchiveDir(), entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	paths = append(paths, j.getJournalFilePath())
+
+	var events []Event
+	for _, path := range paths {
+		fileEvents, _, err := j.readJournal(path)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, fileEvents...)
+	}
+	return events, nil
+}
+
+// load はスナップショットにジャーナルのイベントを再生した状態を返す
+// 前回読み書きした後にファイルが変わっていなければ、その状態を再利用する
+func (j *JournalRepository) load(ctx context.Context) (*journalState, error) {
+	snapshotInfo, journalInfo := j.stat(j.getDataFilePath()), j.stat(j.getJournalFilePath())
+	if cache := j.cache; cache != nil && sameFileInfo(cache.snapshot, snapshotInfo) && sameFileInfo(cache.journal, journalInfo) {
+		return cache.state, nil
+	}
+
+	j.cache = nil
+	state, err := j.replay(ctx)
+	if err != nil {
+		return nil, err
+	}
+	j.cache = &journalCache{snapshot: snapshotInfo, journal: journalInfo, state: state}
+	return state, nil
+}
+
+// replay はスナップショットを読み込み、ジャーナルのイベントを再生する
+// スナップショットがなくジャーナルだけが存在する場合は空のデータに再生する
+func (j *JournalRepository) replay(ctx context.Context) (*journalState, error) {
+	snapshot, err := j.FileRepository.Load(ctx)
+	missing := errors.Is(err, ErrNotFound)
+	if err != nil && !missing {
+		return nil, err
+	}
+
+	events, torn, err := j.readJournal(j.getJournalFilePath())
+	if err != nil {
+		return nil, err
+	}
+	if missing {
+		if len(events) == 0 {
+			return nil, ErrNotFound
+		}
+		snapshot = model.NewAppData()
+	}
+
+	// スナップショットにまとめ済みのイベントは再生しない
+	base := snapshot.Revision
+	for _, event := range events {
+		if event.Revision > base {
+			applyEvent(snapshot, event)
+		}
+	}
+	// プロジェクト導入前に記録されたイベントのタスクは既定のプロジェクトに入れる
+	snapshot.EnsureDefaultProject()
+	return &journalState{data: snapshot, events: events, torn: torn}, nil
+}
+
+// readJournal はジャーナルのファイルからイベントを読み込む
+// 最後の行が書き込み途中で途切れている場合は torn に true を返す（解析できない場合はその行を無視する）
+// それ以外の行を解析できない場合は退避した上でCorruptErrorを返す
+func (j *JournalRepository) readJournal(path string) ([]Event, bool, error) {
+	content, err := j.fs.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	lines := bytes.Split(content, []byte("\n"))
+	events := make([]Event, 0, len(lines))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			if i == len(lines)-1 {
+				return events, true, nil
+			}
+			corruptErr := &CorruptError{Path: path, Err: fmt.Errorf("line %d: %w", i+1, err)}
+			if quarantinePath, qerr := j.writeQuarantine(j.journalQuarantinePath(content), content); qerr == nil {
+				corruptErr.QuarantinePath = quarantinePath
+			}
+			return nil, false, corruptErr
+		}
+		events = append(events, event)
+	}
+	return events, !bytes.HasSuffix(content, []byte("\n")), nil
+}
+
+// appendEvents はイベントを1行ずつジャーナルに追記してディスクに反映する
+func (j *JournalRepository) appendEvents(events []Event) error {
+	lines, err := encodeEvents(events)
+	if err != nil {
+		return err
+	}
+
+	if err := j.ensureDataDir(); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	file, err := j.fs.OpenFile(j.getJournalFilePath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	if _, err := file.Write(lines); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to append to journal: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return file.Close()
+}
+
+// writeEvents はジャーナルをイベントの一覧で原子的に置き換える
+func (j *JournalRepository) writeEvents(events []Event) error {
+	lines, err := encodeEvents(events)
+	if err != nil {
+		return err
+	}
+	if err := atomicWriteFile(j.fs, j.getJournalFilePath(), lines, 0644); err != nil {
+		return fmt.Errorf("failed to rewrite journal: %w", err)
+	}
+	return nil
+}
+
+// compact はデータをスナップショットとして書き込み、それまでのジャーナルを保管する
+// 保管に失敗してもジャーナルのイベントはスナップショットより古いため再生されない
+// 再利用する状態は破棄し、次の読み込みで書き込んだスナップショットから読み込む
+func (j *JournalRepository) compact(ctx context.Context, data *model.AppData) error {
+	j.cache = nil
+	if err := j.FileRepository.Save(ctx, data); err != nil {
+		return err
+	}
+
+	journalPath := j.getJournalFilePath()
+	if _, err := j.fs.Stat(journalPath); os.IsNotExist(err) {
+		return nil
+	}
+	// 破損したジャーナルは退避済みのため保管しない
+	if _, _, err := j.readJournal(journalPath); errors.Is(err, ErrCorrupt) {
+		if err := j.fs.Remove(journalPath); err != nil {
+			return fmt.Errorf("failed to remove corrupt journal: %w", err)
+		}
+		return nil
+	}
+	archiveDir := j.getJournalArchiveDir()
+	if err := j.fs.MkdirAll(archiveDir, 0755); err != nil {
+		return fmt.Errorf("failed to create journal archive: %w", err)
+	}
+	archivePath := filepath.Join(archiveDir, fmt.Sprintf("journal_%s.jsonl", time.Now().Format(backupTimestampLayout)))
+	if err := j.fs.Rename(journalPath, archivePath); err != nil {
+		return fmt.Errorf("failed to archive journal: %w", err)
+	}
+
+	// スナップショットは保存済みのため、古いジャーナルを削除できなくても次にまとめるときに再び試す
+	j.pruneJournalArchive()
+	return nil
+}
+
+// pruneJournalArchive は保管したジャーナルのうち、バックアップの保持方針で期限切れのものを削除する
+func (j *JournalRepository) pruneJournalArchive() {
+	entries, err := j.fs.ReadDir(j.getJournalArchiveDir())
+	if err != nil {
+		return
+	}
+
+	archives := make([]BackupInfo, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "journal_") || filepath.Ext(name) != ".jsonl" {
+			continue
+		}
+		createdAt, ok := parseBackupTimestamp(strings.TrimSuffix(strings.TrimPrefix(name, "journal_"), ".jsonl"))
+		if !ok {
+			continue
+		}
+		archives = append(archives, BackupInfo{Name: name, Path: filepath.Join(j.getJournalArchiveDir(), name), CreatedAt: createdAt})
+	}
+	sort.SliceStable(archives, func(a, b int) bool {
+		return archives[a].CreatedAt.After(archives[b].CreatedAt)
+	})
+
+	for _, archive := range j.retention.Expired(archives) {
+		j.fs.Remove(archive.Path)
+	}
+}
+
+// stat はファイルの情報を返す（存在しないか読み取れない場合は nil）
+func (j *JournalRepository) stat(path string) os.FileInfo {
+	info, err := j.fs.Stat(path)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// sameFileInfo は2つのファイル情報が同じファイルの同じ内容を指しているとみなせるかを返す
+// 原子的な書き込みは別のファイルに置き換え、追記は大きさを変えるため、どちらの変更も検出できる
+func sameFileInfo(a, b os.FileInfo) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return os.SameFile(a, b) && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
+
+// getJournalFilePath はジャーナルのフルパスを返す
+func (j *JournalRepository) getJournalFilePath() string {
+	return filepath.Join(j.dataDir, journalFileName)
+}
+
+// getJournalArchiveDir は保管したジャーナルのディレクトリを返す
+func (j *JournalRepository) getJournalArchiveDir() string {
+	return filepath.Join(j.dataDir, journalArchiveDir)
+}
+
+// journalQuarantinePath は破損したジャーナルの退避先パスを内容のハッシュから決定する
+func (j *JournalRepository) journalQuarantinePath(content []byte) string {
+	sum := sha256.Sum256(content)
+	name := fmt.Sprintf("journal_corrupt_%s.jsonl", hex.EncodeToString(sum[:6]))
+	return filepath.Join(j.dataDir, "quarantine", name)
+}
+
+// encodeEvents はイベントをJSON Lines形式にエンコードする
+func encodeEvents(events []Event) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// diffEvents は before から after への変更をイベントとして返し、作成・変更したタスクのハッシュも返す
+// before のタスクは digests のハッシュと比べるため、after のタスクだけをエンコードする
+// イベントのタスクはJSONを経由した複製で、読み込み直した場合と同じ値になり、after のその後の変更の影響を受けない
+// 作成と変更は after の順序、削除は before の順序で返す
+func diffEvents(before *model.AppData, digests map[string]taskDigest, after *model.AppData, now time.Time) ([]Event, map[string]taskDigest, error) {
+	beforeByID := make(map[string]*model.Task, len(before.Tasks))
+	for _, task := range before.Tasks {
+		beforeByID[task.ID] = task
+	}
+
+	var events []Event
+	changed := make(map[string]taskDigest)
+	afterIDs := make(map[string]bool, len(after.Tasks))
+	for _, task := range after.Tasks {
+		afterIDs[task.ID] = true
+		encoded, err := json.Marshal(task)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal task: %w", err)
+		}
+		digest := taskDigest(sha256.Sum256(encoded))
+
+		eventType := EventTaskCreated
+		if old, ok := beforeByID[task.ID]; ok {
+			if digests[task.ID] == digest {
+				continue
+			}
+			eventType = updateEventType(old, task)
+		}
+		written := &model.Task{}
+		if err := json.Unmarshal(encoded, written); err != nil {
+			return nil, nil, fmt.Errorf("failed to copy task: %w", err)
+		}
+		changed[task.ID] = digest
+		events = append(events, Event{Type: eventType, At: now, Revision: after.Revision, TaskID: task.ID, Task: written})
+	}
+
+	for _, task := range before.Tasks {
+		if !afterIDs[task.ID] {
+			events = append(events, Event{Type: EventTaskDeleted, At: now, Revision: after.Revision, TaskID: task.ID})
+		}
+	}
+	return events, changed, nil
+}
+
+// digestTasks はタスクのIDごとのハッシュを返す
+func digestTasks(tasks []*model.Task) (map[string]taskDigest, error) {
+	digests := make(map[string]taskDigest, len(tasks))
+	for _, task := range tasks {
+		encoded, err := json.Marshal(task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal task: %w", err)
+		}
+		digests[task.ID] = sha256.Sum256(encoded)
+	}
+	return digests, nil
+}
+
+// updateEventType は変更前後のステータスから変更の種類を決定する
+func updateEventType(before, after *model.Task) EventType {
+	switch {
+	case !before.IsCompleted() && after.IsCompleted():
+		return EventTaskCompleted
+	case before.IsCompleted() && !after.IsCompleted():
+		return EventTaskReopened
+	default:
+		return EventTaskUpdated
+	}
+}
+
+// applyEvent はイベントをデータに適用する
+// 変更したタスクは元の位置で置き換え、作成したタスクは末尾に追加する
+func applyEvent(data *model.AppData, event Event) {
+	index := -1
+	for i, task := range data.Tasks {
+		if task.ID == event.TaskID {
+			index = i
+			break
+		}
+	}
+
+	switch {
+	case event.Type == EventTaskDeleted:
+		if index >= 0 {
+			data.Tasks = append(data.Tasks[:index], data.Tasks[index+1:]...)
+		}
+	case event.Task == nil:
+		// 変更後のタスクを含まないイベントは適用できない
+	case index >= 0:
+		data.Tasks[index] = event.Task
+	default:
+		data.Tasks = append(data.Tasks, event.Task)
+	}
+
+	data.Revision = event.Revision
+	data.UpdatedAt = event.At
+}
+
+// projectsEqual は2つのプロジェクトの一覧をJSONの表現で比較する
+func projectsEqual(a, b []*model.Project) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// sameTaskOrder は2つのデータのタスクが同じ順序で並んでいるかを返す
+func sameTaskOrder(a, b *model.AppData) bool {
+	if len(a.Tasks) != len(b.Tasks) {
+		return false
+	}
+	for i := range a.Tasks {
+		if a.Tasks[i].ID != b.Tasks[i].ID {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,408 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJournalTask はジャーナルのテスト用のタスクを既定のプロジェクトに作成する
+func newJournalTask(t *testing.T, title string) *model.Task {
+	t.Helper()
+	task, err := model.NewTask(title, "", model.PriorityMedium, nil)
+	require.NoError(t, err)
+	task.ProjectID = model.DefaultProjectID
+	return task
+}
+
+// readJournalLines はジャーナルの行を読み込む
+func readJournalLines(t *testing.T, dataDir string) []string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dataDir, journalFileName))
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+func TestJournalRepository_New_ShouldShareFileRepositoryFeatures(t *testing.T) {
+	// When
+	repo := NewJournalRepository(t.TempDir())
+
+	// Then
+	assert.Implements(t, (*Repository)(nil), repo)
+	assert.Implements(t, (*Locker)(nil), repo)
+	assert.Implements(t, (*HistoryRepository)(nil), repo)
+}
+
+func TestJournalRepository_Load_WithoutData_ShouldReturnNotFound(t *testing.T) {
+	// Given
+	repo := NewJournalRepository(t.TempDir())
+
+	// When
+	_, err := repo.Load(context.Background())
+
+	// Then
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestJournalRepository_Save_ShouldAppendEventsAfterSnapshot(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir)
+	data := model.NewAppData()
+	first := newJournalTask(t, "First")
+	require.NoError(t, data.AddTask(first))
+	require.NoError(t, repo.Save(ctx, data))
+	snapshot, err := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, err)
+
+	// When - 作成、完了、削除を順に保存する
+	second := newJournalTask(t, "Second")
+	require.NoError(t, data.AddTask(second))
+	require.NoError(t, repo.Save(ctx, data))
+	completed := *first
+	completed.Status = model.StatusCompleted
+	require.NoError(t, data.UpdateTask(&completed))
+	require.NoError(t, repo.Save(ctx, data))
+	require.NoError(t, data.DeleteTask(second.ID))
+	require.NoError(t, repo.Save(ctx, data))
+
+	// Then - スナップショットは書き換えずにジャーナルに追記する
+	unchanged, err := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, unchanged)
+	lines := readJournalLines(t, dataDir)
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"type":"task_created"`)
+	assert.Contains(t, lines[1], `"type":"task_completed"`)
+	assert.Contains(t, lines[2], `"type":"task_deleted"`)
+
+	loaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, loaded.Tasks, 1)
+	assert.Equal(t, first.ID, loaded.Tasks[0].ID)
+	assert.Equal(t, model.StatusCompleted, loaded.Tasks[0].Status)
+	assert.Equal(t, data.Revision, loaded.Revision)
+}
+
+func TestJournalRepository_Save_AtThreshold_ShouldCompactIntoSnapshot(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir, WithCompactThreshold(2))
+	data := model.NewAppData()
+	require.NoError(t, repo.Save(ctx, data))
+
+	// When
+	for _, title := range []string{"First", "Second", "Third"} {
+		require.NoError(t, data.AddTask(newJournalTask(t, title)))
+		require.NoError(t, repo.Save(ctx, data))
+	}
+
+	// Then - 2件目を追記した後にまとめられ、3件目だけがジャーナルに残る
+	assert.Len(t, readJournalLines(t, dataDir), 1)
+	archived, err := os.ReadDir(filepath.Join(dataDir, "journal"))
+	require.NoError(t, err)
+	assert.Len(t, archived, 1)
+
+	snapshot, err := NewFileRepository(dataDir).Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, snapshot.Tasks, 2)
+	loaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, loaded.Tasks, 3)
+
+	// 保管したジャーナルを含めて全ての変更を参照できる
+	events, err := repo.(*JournalRepository).Events(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, "Third", events[2].Task.Title)
+}
+
+func TestJournalRepository_Save_WithProjectChange_ShouldCompactIntoSnapshot(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir)
+	data := model.NewAppData()
+	require.NoError(t, repo.Save(ctx, data))
+	require.NoError(t, data.AddTask(newJournalTask(t, "First")))
+	require.NoError(t, repo.Save(ctx, data))
+
+	// When - プロジェクトの追加はイベントで表せない
+	project, err := model.NewProject("Work", "blue", []string{"job"})
+	require.NoError(t, err)
+	require.NoError(t, data.AddProject(project))
+	require.NoError(t, repo.Save(ctx, data))
+
+	// Then
+	snapshot, err := NewFileRepository(dataDir).Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshot.Projects, 2)
+	assert.Equal(t, "Work", snapshot.Projects[1].Name)
+	assert.Len(t, snapshot.Tasks, 1)
+	assert.NoFileExists(t, filepath.Join(dataDir, journalFileName))
+}
+
+func TestJournalRepository_Load_WithEventsBeforeProjects_ShouldUseDefaultProject(t *testing.T) {
+	// Given - プロジェクト導入前のタスクを記録したジャーナル
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	line := `{"type":"task_created","at":"2025-01-01T10:00:00Z","revision":1,"task_id":"task-1",` +
+		`"task":{"id":"task-1","title":"Legacy","status":"todo","priority":"low","revision":1}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, journalFileName), []byte(line+"\n"), 0644))
+	repo := NewJournalRepository(dataDir)
+
+	// When
+	loaded, err := repo.Load(ctx)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, loaded.Tasks, 1)
+	assert.Equal(t, model.DefaultProjectID, loaded.Tasks[0].ProjectID)
+	_, err = loaded.GetProjectByID(model.DefaultProjectID)
+	assert.NoError(t, err)
+}
+
+func TestJournalRepository_Load_WithTornLastLine_ShouldIgnoreItAndCompactOnSave(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir)
+	data := model.NewAppData()
+	require.NoError(t, repo.Save(ctx, data))
+	require.NoError(t, data.AddTask(newJournalTask(t, "Kept")))
+	require.NoError(t, repo.Save(ctx, data))
+
+	// 書き込み途中で終了した行
+	journal, err := os.OpenFile(filepath.Join(dataDir, journalFileName), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = journal.WriteString(`{"type":"task_created","task":{"id":`)
+	require.NoError(t, err)
+	require.NoError(t, journal.Close())
+
+	// When
+	loaded, err := repo.Load(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Len(t, loaded.Tasks, 1)
+
+	// When - 途切れた行を除いて書き直す
+	require.NoError(t, loaded.AddTask(newJournalTask(t, "Added")))
+	require.NoError(t, repo.Save(ctx, loaded))
+
+	// Then
+	lines := readJournalLines(t, dataDir)
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "Added")
+	reloaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.Tasks, 2)
+}
+
+func TestJournalRepository_Load_WithCorruptLine_ShouldQuarantineJournal(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir)
+	require.NoError(t, repo.Save(ctx, model.NewAppData()))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, journalFileName), []byte("not json\n{}\n"), 0644))
+
+	// When
+	_, err := repo.Load(ctx)
+
+	// Then
+	var corruptErr *CorruptError
+	require.True(t, errors.As(err, &corruptErr))
+	assert.Contains(t, corruptErr.Path, journalFileName)
+	assert.Contains(t, err.Error(), "line 1")
+	assert.FileExists(t, corruptErr.QuarantinePath)
+
+	// When - 復元などで保存し直すとジャーナルを置き換える
+	restored := model.NewAppData()
+	require.NoError(t, restored.AddTask(newJournalTask(t, "Restored")))
+	require.NoError(t, repo.Save(ctx, restored))
+
+	// Then
+	loaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, loaded.Tasks, 1)
+}
+
+func TestJournalRepository_Load_ShouldSkipEventsAlreadyInSnapshot(t *testing.T) {
+	// Given - スナップショットを書き込んだ後、ジャーナルの保管前に終了した状態
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir)
+	data := model.NewAppData()
+	require.NoError(t, repo.Save(ctx, data))
+	task := newJournalTask(t, "Once")
+	require.NoError(t, data.AddTask(task))
+	require.NoError(t, repo.Save(ctx, data))
+	require.NoError(t, NewFileRepository(dataDir).Save(ctx, data))
+
+	// When
+	loaded, err := repo.Load(ctx)
+
+	// Then - 同じタスクを二重に追加しない
+	require.NoError(t, err)
+	assert.Len(t, loaded.Tasks, 1)
+}
+
+func TestDiffEvents_ShouldDescribeChanges(t *testing.T) {
+	// Given
+	kept := &model.Task{ID: "1", Title: "Kept", Status: model.StatusTodo}
+	reopened := &model.Task{ID: "2", Title: "Reopened", Status: model.StatusCompleted}
+	removed := &model.Task{ID: "3", Title: "Removed", Status: model.StatusTodo}
+	before := &model.AppData{Tasks: []*model.Task{kept, reopened, removed}}
+	renamed := *kept
+	renamed.Title = "Renamed"
+	opened := *reopened
+	opened.Status = model.StatusTodo
+	unchanged := &model.Task{ID: "5", Title: "Unchanged"}
+	before.Tasks = append(before.Tasks, unchanged)
+	after := &model.AppData{Revision: 7, Tasks: []*model.Task{&renamed, &opened, {ID: "4", Title: "Created"}, unchanged}}
+	digests, err := digestTasks(before.Tasks)
+	require.NoError(t, err)
+
+	// When
+	events, changed, err := diffEvents(before, digests, after, after.UpdatedAt)
+
+	// Then - 変わっていないタスクはイベントにしない
+	require.NoError(t, err)
+	assert.Len(t, changed, 3)
+	assert.NotSame(t, &renamed, events[0].Task)
+	var types []EventType
+	for _, event := range events {
+		types = append(types, event.Type)
+		assert.Equal(t, int64(7), event.Revision)
+	}
+	assert.Equal(t, []EventType{EventTaskUpdated, EventTaskReopened, EventTaskCreated, EventTaskDeleted}, types)
+	assert.Nil(t, events[3].Task)
+}
+
+// countingFileSystem は読み込んだファイルを記録するfileSystemのテスト実装
+type countingFileSystem struct {
+	osFileSystem
+	reads []string
+}
+
+func (c *countingFileSystem) ReadFile(name string) ([]byte, error) {
+	c.reads = append(c.reads, filepath.Base(name))
+	return c.osFileSystem.ReadFile(name)
+}
+
+func TestJournalRepository_Save_AfterLoad_ShouldNotReadDataFilesAgain(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	fs := &countingFileSystem{}
+	repo := newJournalRepositoryWithFS(dataDir, fs)
+	data := model.NewAppData()
+	require.NoError(t, data.AddTask(newJournalTask(t, "First")))
+	require.NoError(t, repo.Save(ctx, data))
+	loaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+
+	// When
+	fs.reads = nil
+	require.NoError(t, loaded.AddTask(newJournalTask(t, "Second")))
+	require.NoError(t, repo.Save(ctx, loaded))
+	reloaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+
+	// Then - 前回の状態を再利用し、スナップショットもジャーナルも読み込まない
+	assert.NotContains(t, fs.reads, "tasks.json")
+	assert.NotContains(t, fs.reads, journalFileName)
+	require.Len(t, reloaded.Tasks, 2)
+	assert.Equal(t, "Second", reloaded.Tasks[1].Title)
+	assert.Len(t, readJournalLines(t, dataDir), 1)
+
+	// 読み込んだデータを変更しても再利用する状態には影響しない
+	reloaded.Tasks[0].Title = "Changed without saving"
+	again, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "First", again.Tasks[0].Title)
+}
+
+func TestJournalRepository_Load_AfterAnotherProcessWrites_ShouldReadAgain(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir)
+	other := NewJournalRepository(dataDir)
+	data := model.NewAppData()
+	require.NoError(t, data.AddTask(newJournalTask(t, "First")))
+	require.NoError(t, repo.Save(ctx, data))
+	_, err := repo.Load(ctx)
+	require.NoError(t, err)
+
+	// When - 別のリポジトリ（別のプロセス）が追記する
+	otherData, err := other.Load(ctx)
+	require.NoError(t, err)
+	require.NoError(t, otherData.AddTask(newJournalTask(t, "From other")))
+	require.NoError(t, other.Save(ctx, otherData))
+	loaded, err := repo.Load(ctx)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, loaded.Tasks, 2)
+	assert.Equal(t, "From other", loaded.Tasks[1].Title)
+}
+
+func TestJournalRepository_Compact_ShouldPruneArchiveByRetentionPolicy(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir, WithCompactThreshold(1), WithRetentionPolicy(RetentionPolicy{KeepLast: 2}))
+	data := model.NewAppData()
+	require.NoError(t, repo.Save(ctx, data))
+
+	// When - 保存のたびにまとめてジャーナルを保管する
+	for _, title := range []string{"First", "Second", "Third", "Fourth"} {
+		require.NoError(t, data.AddTask(newJournalTask(t, title)))
+		require.NoError(t, repo.Save(ctx, data))
+	}
+
+	// Then - 保持方針に従って新しい2件だけが残る
+	archived, err := os.ReadDir(filepath.Join(dataDir, "journal"))
+	require.NoError(t, err)
+	assert.Len(t, archived, 2)
+	loaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, loaded.Tasks, 4)
+}
+
+func TestJournalRepository_Save_ShouldNotChangeCallersData(t *testing.T) {
+	// Given - 保存済みのデータより古いリビジョンのデータ
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	repo := NewJournalRepository(dataDir)
+	data := model.NewAppData()
+	require.NoError(t, data.AddTask(newJournalTask(t, "First")))
+	require.NoError(t, repo.Save(ctx, data))
+	stale := data.Clone()
+	stale.Revision = 0
+	require.NoError(t, stale.AddTask(newJournalTask(t, "Second")))
+	revision := stale.Revision
+
+	// When
+	err := repo.Save(ctx, stale)
+
+	// Then - 保存したデータのリビジョンは進むが、呼び出し元のデータは変わらない
+	require.NoError(t, err)
+	assert.Equal(t, revision, stale.Revision)
+	loaded, err := repo.Load(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, loaded.Revision, data.Revision)
+	assert.Len(t, loaded.Tasks, 2)
+}
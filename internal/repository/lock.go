@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// lockFileName はデータディレクトリのロックファイル名
+	lockFileName = "tasks.lock"
+
+	// defaultLockTimeout はロック取得を待つ既定の時間
+	defaultLockTimeout = 5 * time.Second
+
+	// lockRetryInterval はロック取得を再試行する間隔
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+// ErrLocked はデータディレクトリが他のプロセスにロックされていることを表す
+var ErrLocked = errors.New("data directory is locked")
+
+// ErrLockUnsupported はこのプラットフォームではデータディレクトリをロックできないことを表す
+var ErrLockUnsupported = errors.New("file locking is not supported on this platform")
+
+// errWouldBlock はロックが他のプロセスに保持されていることを表す内部エラー
+var errWouldBlock = errors.New("lock is held by another process")
+
+// Locker はデータディレクトリの排他ロックを提供するRepositoryが実装する
+// 読み込み・変更・保存の一連の処理をプロセス間で直列化するために使用する
+type Locker interface {
+	// Lock はロックを取得し、解放用の関数を返す
+	Lock(ctx context.Context) (unlock func() error, err error)
+}
+
+// LockedError はロックの保持者を含むロック取得失敗の詳細を表す
+// errors.Is(err, ErrLocked) で判定できる
+type LockedError struct {
+	PID     int           // ロックを保持しているプロセスID（不明な場合は0）
+	Timeout time.Duration // 待機した時間
+}
+
+// Error はエラーメッセージを返す
+func (e *LockedError) Error() string {
+	if e.PID > 0 {
+		return fmt.Sprintf("%s by PID %d (waited %s)", ErrLocked, e.PID, e.Timeout)
+	}
+	return fmt.Sprintf("%s by another process (waited %s)", ErrLocked, e.Timeout)
+}
+
+// Is はErrLockedとの比較を可能にする
+func (e *LockedError) Is(target error) bool {
+	return target == ErrLocked
+}
+
+// Lock はデータディレクトリのロックファイルにadvisoryロックを取得する
+// タイムアウトまでに取得できない場合はLockedErrorを返す
+func (f *FileRepository) Lock(ctx context.Context) (func() error, error) {
+	if err := f.ensureDataDir(); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	lockPath := filepath.Join(f.dataDir, lockFileName)
+	file, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(f.lockTimeout)
+	for {
+		err := tryLockFile(file)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errWouldBlock) {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock data directory: %w", err)
+		}
+		if time.Now().After(deadline) {
+			pid := readLockOwner(lockPath)
+			file.Close()
+			return nil, &LockedError{PID: pid, Timeout: f.lockTimeout}
+		}
+
+		select {
+		case <-ctx.Done():
+			file.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	// 保持者を示すため自プロセスのPIDを書き込む
+	if err := file.Truncate(0); err == nil {
+		file.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+
+	unlock := func() error {
+		file.Truncate(0)
+		unlockErr := unlockFile(file)
+		closeErr := file.Close()
+		if unlockErr != nil {
+			return unlockErr
+		}
+		return closeErr
+	}
+	return unlock, nil
+}
+
+// readLockOwner はロックファイルに記録されたPIDを読み取る（読み取れない場合は0）
+func readLockOwner(lockPath string) int {
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
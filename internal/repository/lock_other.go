@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package repository
+
+import "os"
+
+// tryLockFile はファイルロックが利用できない環境ではErrLockUnsupportedを返す
+// ロックせずに書き込むと他のプロセスの変更を上書きするおそれがあるため、黙って成功させない
+func tryLockFile(file *os.File) error {
+	return ErrLockUnsupported
+}
+
+// unlockFile はファイルロックが利用できない環境では何もしない（ロックは取得されていない）
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRepository_Lock_WhenHeldByAnother_ShouldReturnLockedErrorWithPID(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	ctx := context.Background()
+	holder := newFileRepositoryWithFS(dataDir, osFileSystem{})
+	contender := newFileRepositoryWithFS(dataDir, osFileSystem{}, WithLockTimeout(100*time.Millisecond))
+
+	unlock, err := holder.Lock(ctx)
+	require.NoError(t, err)
+	defer unlock()
+
+	// When
+	_, err = contender.Lock(ctx)
+
+	// Then
+	assert.ErrorIs(t, err, ErrLocked)
+	var lockedErr *LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, os.Getpid(), lockedErr.PID)
+	assert.Contains(t, err.Error(), "data directory is locked by PID")
+}
+
+func TestFileRepository_Lock_AfterUnlock_ShouldSucceed(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	ctx := context.Background()
+	first := newFileRepositoryWithFS(dataDir, osFileSystem{})
+	second := newFileRepositoryWithFS(dataDir, osFileSystem{}, WithLockTimeout(time.Second))
+
+	unlock, err := first.Lock(ctx)
+	require.NoError(t, err)
+
+	// When
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		unlock()
+	}()
+	unlockSecond, err := second.Lock(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.NoError(t, unlockSecond())
+}
+
+func TestFileRepository_Lock_WithCancelledContext_ShouldStopWaiting(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	holder := newFileRepositoryWithFS(dataDir, osFileSystem{})
+	contender := newFileRepositoryWithFS(dataDir, osFileSystem{}, WithLockTimeout(time.Minute))
+
+	unlock, err := holder.Lock(context.Background())
+	require.NoError(t, err)
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// When
+	_, err = contender.Lock(ctx)
+
+	// Then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package repository
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile はファイルに非ブロッキングで排他flockを取得する
+func tryLockFile(file *os.File) error {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errWouldBlock
+	}
+	return err
+}
+
+// unlockFile はflockを解放する
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package repository
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockOverlapped はロックする範囲の位置
+// PIDを書き込む先頭から離れた範囲をロックし、他のプロセスが保持者のPIDを読み取れるようにする
+func lockOverlapped() *windows.Overlapped {
+	return &windows.Overlapped{OffsetHigh: 1 << 30}
+}
+
+// tryLockFile はファイルに非ブロッキングで排他ロック（LockFileEx）を取得する
+func tryLockFile(file *os.File) error {
+	err := windows.LockFileEx(windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, lockOverlapped())
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errWouldBlock
+	}
+	return err
+}
+
+// unlockFile はLockFileExで取得したロックを解放する
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, lockOverlapped())
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"task-cli/internal/model"
+)
+
+// CurrentSchemaVersion はこのバージョンが読み書きするデータのスキーマバージョン
+// スキーマを変更する場合は migrations に移行処理を追加してから値を増やす
+const CurrentSchemaVersion = 2
+
+// document はJSONをデコードした汎用的なオブジェクト
+// 古いスキーマは現在の構造体に直接デコードできない場合があるため、移行処理はこの形式で行う
+type document map[string]interface{}
+
+// migration はあるスキーマバージョンから次のバージョンへの移行処理
+type migration struct {
+	from        int
+	description string
+	migrate     func(doc document) error
+}
+
+// migrations はスキーマバージョン順の移行処理の一覧（migrations[i].from == i）
+var migrations = []migration{
+	{
+		from:        0,
+		description: "initialize task revisions",
+		migrate:     initializeRevisions,
+	},
+	{
+		from:        1,
+		description: "move tasks into the default project",
+		migrate:     addDefaultProject,
+	},
+}
+
+// errMigration は移行処理の失敗を表す
+var errMigration = errors.New("schema migration failed")
+
+// documentSchemaVersion はドキュメントのスキーマバージョンを返す（バージョン導入前のファイルは0）
+func documentSchemaVersion(doc document) (int, error) {
+	raw, ok := doc["schema_version"]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+	version, ok := raw.(float64)
+	if !ok || version < 0 || version != float64(int(version)) {
+		return 0, fmt.Errorf("invalid schema_version %v", raw)
+	}
+	return int(version), nil
+}
+
+// migrateDocument はドキュメントを from から CurrentSchemaVersion まで1段階ずつ移行する
+func migrateDocument(doc document, from int) error {
+	for version := from; version < CurrentSchemaVersion; version++ {
+		step := migrations[version]
+		if err := step.migrate(doc); err != nil {
+			return fmt.Errorf("%w: %d -> %d (%s): %v", errMigration, version, version+1, step.description, err)
+		}
+		doc["schema_version"] = version + 1
+	}
+	return nil
+}
+
+// documentTasks はドキュメントのタスク一覧を返す
+func documentTasks(doc document) ([]document, error) {
+	raw, ok := doc["tasks"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, errors.New("tasks is not an array")
+	}
+
+	tasks := make([]document, 0, len(items))
+	for _, item := range items {
+		task, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("task is not an object")
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// initializeRevisions はリビジョン導入前のタスクにリビジョン1を設定する
+func initializeRevisions(doc document) error {
+	tasks, err := documentTasks(doc)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if revision, _ := task["revision"].(float64); revision == 0 {
+			task["revision"] = 1
+		}
+	}
+	return nil
+}
+
+// addDefaultProject は既定のプロジェクトを追加し、全てのタスクをそのプロジェクトに入れる
+func addDefaultProject(doc document) error {
+	tasks, err := documentTasks(doc)
+	if err != nil {
+		return err
+	}
+	if raw, ok := doc["projects"]; ok && raw != nil {
+		return errors.New("projects already exist")
+	}
+
+	project := document{"id": model.DefaultProjectID, "name": model.DefaultProjectName}
+	if createdAt, ok := doc["created_at"]; ok {
+		project["created_at"] = createdAt
+	}
+	doc["projects"] = []interface{}{map[string]interface{}(project)}
+	for _, task := range tasks {
+		task["project_id"] = model.DefaultProjectID
+	}
+	return nil
+}
+
+// unmarshalDocument はJSONをドキュメントとしてデコードする
+func unmarshalDocument(jsonData []byte) (document, error) {
+	var doc document
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("data is not a JSON object")
+	}
+	return doc, nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyTasksJSON はスキーマバージョン導入前の tasks.json
+const legacyTasksJSON = `{
+  "id": "app-id",
+  "tasks": [
+    {
+      "id": "task-1",
+      "title": "Legacy task",
+      "description": "",
+      "status": "todo",
+      "priority": "medium",
+      "tags": ["old"],
+      "created_at": "2025-01-01T10:00:00Z",
+      "updated_at": "2025-01-01T10:00:00Z"
+    }
+  ],
+  "created_at": "2025-01-01T10:00:00Z",
+  "updated_at": "2025-01-01T10:00:00Z"
+}`
+
+// writeDataFile はデータディレクトリに tasks.json を書き込む
+func writeDataFile(t *testing.T, dataDir, content string) string {
+	t.Helper()
+	path := filepath.Join(dataDir, "tasks.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestMigrations_ShouldCoverEveryVersionInOrder(t *testing.T) {
+	// Then
+	require.Len(t, migrations, CurrentSchemaVersion)
+	for i, m := range migrations {
+		assert.Equal(t, i, m.from, "migration %d (%s)", i, m.description)
+	}
+}
+
+func TestFileRepository_Load_WithLegacyFile_ShouldMigrateAndBackUpOriginal(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	writeDataFile(t, dataDir, legacyTasksJSON)
+	repo := NewFileRepository(dataDir)
+	ctx := context.Background()
+
+	// When - 2回読み込んでもバックアップは1つだけ作成される
+	appData, err := repo.Load(ctx)
+	require.NoError(t, err)
+	_, err = repo.Load(ctx)
+	require.NoError(t, err)
+
+	// Then
+	require.Len(t, appData.Tasks, 1)
+	assert.Equal(t, "Legacy task", appData.Tasks[0].Title)
+	assert.Equal(t, int64(1), appData.Tasks[0].Revision)
+	assert.Equal(t, model.DefaultProjectID, appData.Tasks[0].ProjectID)
+	require.Len(t, appData.Projects, 1)
+	assert.Equal(t, model.DefaultProjectName, appData.Projects[0].Name)
+	assert.Equal(t, appData.CreatedAt, appData.Projects[0].CreatedAt)
+	assert.Equal(t, CurrentSchemaVersion, appData.SchemaVersion)
+
+	backups, err := repo.ListBackups(ctx)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	original, err := os.ReadFile(backups[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, legacyTasksJSON, string(original))
+}
+
+func TestAddDefaultProject_WithSchemaOneFile_ShouldMoveTasksIntoDefaultProject(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	writeDataFile(t, dataDir, `{"schema_version": 1, "id": "app-id", "tasks": [
+  {"id": "task-1", "title": "Old", "status": "todo", "priority": "low", "revision": 3}
+]}`)
+	repo := NewFileRepository(dataDir)
+
+	// When
+	appData, err := repo.Load(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, appData.Projects, 1)
+	assert.True(t, appData.Projects[0].IsDefault())
+	assert.Equal(t, model.DefaultProjectID, appData.Tasks[0].ProjectID)
+	assert.Equal(t, int64(3), appData.Tasks[0].Revision)
+}
+
+func TestFileRepository_Save_AfterMigration_ShouldWriteCurrentSchemaVersion(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	writeDataFile(t, dataDir, legacyTasksJSON)
+	repo := NewFileRepository(dataDir)
+	ctx := context.Background()
+	appData, err := repo.Load(ctx)
+	require.NoError(t, err)
+
+	// When
+	err = repo.Save(ctx, appData)
+
+	// Then
+	require.NoError(t, err)
+	doc, err := os.ReadFile(filepath.Join(dataDir, "tasks.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(doc), `"schema_version": 2`)
+}
+
+func TestFileRepository_Load_WithNewerSchema_ShouldRefuseWithoutQuarantine(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	content := `{"schema_version": 99, "id": "app-id", "tasks": []}`
+	path := writeDataFile(t, dataDir, content)
+	repo := NewFileRepository(dataDir)
+	ctx := context.Background()
+
+	// When
+	_, loadErr := repo.Load(ctx)
+	saveErr := repo.Save(ctx, model.NewAppData())
+
+	// Then
+	assert.ErrorIs(t, loadErr, ErrNewerSchema)
+	assert.NotErrorIs(t, loadErr, ErrCorrupt)
+	assert.Contains(t, loadErr.Error(), "schema version 99")
+	assert.Contains(t, loadErr.Error(), "please upgrade task-cli")
+	assert.ErrorIs(t, saveErr, ErrNewerSchema)
+
+	unchanged, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(unchanged))
+	assert.NoDirExists(t, filepath.Join(dataDir, "quarantine"))
+}
+
+func TestFileRepository_RestoreFromBackup_WithLegacyBackup_ShouldMigrate(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	backupPath := filepath.Join(dataDir, "tasks_backup_20250101_100000.json")
+	require.NoError(t, os.WriteFile(backupPath, []byte(legacyTasksJSON), 0644))
+	repo := NewFileRepository(dataDir)
+
+	// When
+	appData, err := repo.RestoreFromBackup(context.Background(), backupPath)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), appData.Tasks[0].Revision)
+}
@@ -2,10 +2,19 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"task-cli/internal/model"
 )
 
+// BackupInfo はバックアップファイルの情報
+type BackupInfo struct {
+	Name      string    // ファイル名
+	Path      string    // フルパス
+	CreatedAt time.Time // 作成日時
+	Size      int64     // ファイルサイズ（バイト）
+}
+
 // Repository はデータの永続化を担当するインターフェース
 type Repository interface {
 	// Save はAppDataを保存する
@@ -19,4 +28,40 @@ type Repository interface {
 
 	// RestoreFromBackup はバックアップからデータを復元する
 	RestoreFromBackup(ctx context.Context, backupPath string) (*model.AppData, error)
-}
\ No newline at end of file
+
+	// ListBackups はバックアップを新しい順に返す
+	ListBackups(ctx context.Context) ([]BackupInfo, error)
+}
+
+// SafetyBackupCreator は保持方針による削除を行わずにバックアップを作成できるRepository
+type SafetyBackupCreator interface {
+	// CreateSafetyBackup は古いバックアップを削除せずにデータのバックアップを作成する
+	CreateSafetyBackup(ctx context.Context, data *model.AppData) (string, error)
+}
+
+// ViewRepository は保存したビューの永続化を担当するインターフェース
+type ViewRepository interface {
+	// LoadViews は保存されたビューを読み込む（保存されていない場合は空）
+	LoadViews(ctx context.Context) ([]model.View, error)
+
+	// SaveViews はビューの一覧を保存する
+	SaveViews(ctx context.Context, views []model.View) error
+}
+
+// HistoryRepository は元に戻す操作の履歴の永続化を担当するインターフェース
+type HistoryRepository interface {
+	// LoadHistory は保存された履歴を読み込む（保存されていない場合は空）
+	LoadHistory(ctx context.Context) (*model.History, error)
+
+	// SaveHistory は履歴を保存する
+	SaveHistory(ctx context.Context, history *model.History) error
+}
+
+// DeletionRepository は削除したタスクの記録の永続化を担当するインターフェース
+type DeletionRepository interface {
+	// LoadDeletions は削除したタスクの記録を古い順に読み込む（保存されていない場合は空）
+	LoadDeletions(ctx context.Context) ([]model.Deletion, error)
+
+	// SaveDeletions は削除したタスクの記録を保存する
+	SaveDeletions(ctx context.Context, deletions []model.Deletion) error
+}
@@ -129,4 +129,8 @@ func (m *mockRepository) RestoreFromBackup(ctx context.Context, backupPath strin
 	}
 	// テスト用のダミーデータを返す
 	return model.NewAppData(), nil
-}
\ No newline at end of file
+}
+
+func (m *mockRepository) ListBackups(ctx context.Context) ([]BackupInfo, error) {
+	return []BackupInfo{}, nil
+}
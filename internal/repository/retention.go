@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy はバックアップの保持方針を表す
+// 新しい順に KeepLast 件を残し、さらに直近 KeepDaily 日・KeepWeekly 週について
+// それぞれの日・週の最新のバックアップを1件ずつ残す
+// すべて0の場合は削除を行わない
+type RetentionPolicy struct {
+	KeepLast   int
+	KeepDaily  int
+	KeepWeekly int
+}
+
+// DefaultRetentionPolicy は既定のバックアップ保持方針
+var DefaultRetentionPolicy = RetentionPolicy{
+	KeepLast:   10,
+	KeepDaily:  7,
+	KeepWeekly: 4,
+}
+
+// WithRetentionPolicy はバックアップ作成時とジャーナルの保管時に適用する保持方針を設定する
+func WithRetentionPolicy(policy RetentionPolicy) Option {
+	return func(f *FileRepository) {
+		f.retention = policy
+	}
+}
+
+// IsZero は削除を行わない方針かを返す
+func (p RetentionPolicy) IsZero() bool {
+	return p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0
+}
+
+// Expired は保持方針に従って削除すべきバックアップを返す
+// backups は新しい順に並んでいる必要がある
+func (p RetentionPolicy) Expired(backups []BackupInfo) []BackupInfo {
+	if p.IsZero() {
+		return nil
+	}
+
+	keep := make([]bool, len(backups))
+	for i := range backups {
+		if i < p.KeepLast {
+			keep[i] = true
+		}
+	}
+	p.keepNewestPerPeriod(backups, keep, p.KeepDaily, func(t time.Time) string {
+		return t.Format("2006-01-02")
+	})
+	p.keepNewestPerPeriod(backups, keep, p.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+
+	var expired []BackupInfo
+	for i, backup := range backups {
+		if !keep[i] {
+			expired = append(expired, backup)
+		}
+	}
+	return expired
+}
+
+// keepNewestPerPeriod は期間ごとの最新のバックアップを、新しい期間から limit 個分残す
+func (p RetentionPolicy) keepNewestPerPeriod(backups []BackupInfo, keep []bool, limit int, period func(time.Time) string) {
+	seen := make(map[string]bool)
+	for i, backup := range backups {
+		if len(seen) >= limit {
+			return
+		}
+		key := period(backup.CreatedAt)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keep[i] = true
+	}
+}
+
+// pruneBackups は保持方針に従って古いバックアップを削除する
+// スキーマの移行前のバックアップは元のデータの唯一のコピーのため、削除も件数の計算もしない
+func (f *FileRepository) pruneBackups(backups []BackupInfo) error {
+	candidates := make([]BackupInfo, 0, len(backups))
+	for _, backup := range backups {
+		if !strings.HasPrefix(backup.Name, migrationBackupPrefix) {
+			candidates = append(candidates, backup)
+		}
+	}
+
+	for _, backup := range f.retention.Expired(candidates) {
+		if err := f.fs.Remove(backup.Path); err != nil {
+			return fmt.Errorf("failed to remove expired backup %s: %w", backup.Name, err)
+		}
+	}
+	return nil
+}
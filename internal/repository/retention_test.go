@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backupsAt は指定した日時のバックアップ一覧を新しい順に作成する
+func backupsAt(times ...time.Time) []BackupInfo {
+	backups := make([]BackupInfo, len(times))
+	for i, createdAt := range times {
+		backups[i] = BackupInfo{Name: createdAt.Format(backupTimestampLayout), CreatedAt: createdAt}
+	}
+	return backups
+}
+
+func TestRetentionPolicy_Expired_WithKeepLast_ShouldKeepNewest(t *testing.T) {
+	// Given
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	backups := backupsAt(now, now.Add(-time.Minute), now.Add(-2*time.Minute), now.Add(-3*time.Minute))
+	policy := RetentionPolicy{KeepLast: 2}
+
+	// When
+	expired := policy.Expired(backups)
+
+	// Then
+	assert.Equal(t, backups[2:], expired)
+}
+
+func TestRetentionPolicy_Expired_WithDailyAndWeekly_ShouldKeepNewestPerPeriod(t *testing.T) {
+	// Given - 2026-10-16 は金曜日
+	day := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.Local) }
+	backups := backupsAt(
+		day(16, 12), // 最新
+		day(16, 9),  // 同じ日の古いもの
+		day(15, 18), // 前日の最新
+		day(15, 8),
+		day(8, 10), // 前週
+		day(1, 10), // 2週前
+	)
+	policy := RetentionPolicy{KeepLast: 1, KeepDaily: 2, KeepWeekly: 2}
+
+	// When
+	expired := policy.Expired(backups)
+
+	// Then
+	assert.Equal(t, []BackupInfo{backups[1], backups[3], backups[5]}, expired)
+}
+
+func TestRetentionPolicy_Expired_WithZeroPolicy_ShouldKeepEverything(t *testing.T) {
+	// Given
+	now := time.Now()
+	backups := backupsAt(now, now.Add(-time.Hour))
+
+	// When
+	expired := RetentionPolicy{}.Expired(backups)
+
+	// Then
+	assert.Empty(t, expired)
+}
+
+func TestFileRepository_CreateBackup_ShouldPruneExpiredBackups(t *testing.T) {
+	// Given
+	ctx := context.Background()
+	repo := NewFileRepository(t.TempDir(), WithRetentionPolicy(RetentionPolicy{KeepLast: 2}))
+	appData := model.NewAppData()
+
+	// When - 同じ秒に連続で作成しても別々のファイルになる
+	var paths []string
+	for i := 0; i < 4; i++ {
+		path, err := repo.CreateBackup(ctx, appData)
+		require.NoError(t, err)
+		paths = append(paths, path)
+	}
+
+	// Then
+	backups, err := repo.ListBackups(ctx)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, paths[3], backups[0].Path)
+	assert.Equal(t, paths[2], backups[1].Path)
+}
+
+func TestFileRepository_CreateBackup_ShouldKeepMigrationBackups(t *testing.T) {
+	// Given - 移行前のバックアップがある
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	writeDataFile(t, dataDir, legacyTasksJSON)
+	repo := NewFileRepository(dataDir, WithRetentionPolicy(RetentionPolicy{KeepLast: 1}))
+	appData, err := repo.Load(ctx)
+	require.NoError(t, err)
+
+	// When
+	for i := 0; i < 3; i++ {
+		_, err := repo.CreateBackup(ctx, appData)
+		require.NoError(t, err)
+	}
+
+	// Then - 移行前のバックアップは削除されず、残す件数にも数えない
+	backups, err := repo.ListBackups(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	require.Len(t, names, 2)
+	assert.Contains(t, strings.Join(names, " "), "tasks_backup_schema0_")
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"task-cli/internal/model"
+)
+
+// viewsFileName は保存したビューのファイル名
+const viewsFileName = "views.json"
+
+// viewFile は views.json の形式
+type viewFile struct {
+	Views []model.View `json:"views"`
+}
+
+// NewFileViewRepository はデータディレクトリの views.json にビューを保存するViewRepositoryを作成する
+// タスクのデータと同じロックファイルを使用するLockerも実装する
+func NewFileViewRepository(dataDir string, opts ...Option) ViewRepository {
+	return newFileRepositoryWithFS(dataDir, osFileSystem{}, opts...)
+}
+
+// LoadViews はファイルからビューを読み込む
+// ファイルが存在しない場合は空の一覧を返す
+func (f *FileRepository) LoadViews(ctx context.Context) ([]model.View, error) {
+	filePath := f.getViewsFilePath()
+
+	jsonData, err := f.fs.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return []model.View{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read views: %w", err)
+	}
+
+	var file viewFile
+	if err := json.Unmarshal(jsonData, &file); err != nil {
+		return nil, &CorruptError{Path: filePath, Err: err}
+	}
+	if file.Views == nil {
+		file.Views = []model.View{}
+	}
+	return file.Views, nil
+}
+
+// SaveViews はビューの一覧をファイルに保存する
+func (f *FileRepository) SaveViews(ctx context.Context, views []model.View) error {
+	if err := f.ensureDataDir(); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	if views == nil {
+		views = []model.View{}
+	}
+	jsonData, err := json.MarshalIndent(viewFile{Views: views}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal views: %w", err)
+	}
+
+	if err := atomicWriteFile(f.fs, f.getViewsFilePath(), jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write views: %w", err)
+	}
+	return nil
+}
+
+// getViewsFilePath はビューのファイルのフルパスを返す
+func (f *FileRepository) getViewsFilePath() string {
+	return filepath.Join(f.dataDir, viewsFileName)
+}
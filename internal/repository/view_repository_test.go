@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileViewRepository_New_ShouldImplementLocker(t *testing.T) {
+	// When
+	repo := NewFileViewRepository(t.TempDir())
+
+	// Then
+	assert.Implements(t, (*ViewRepository)(nil), repo)
+	assert.Implements(t, (*Locker)(nil), repo)
+}
+
+func TestFileViewRepository_LoadViews_WithoutFile_ShouldReturnEmpty(t *testing.T) {
+	// Given
+	repo := NewFileViewRepository(t.TempDir())
+
+	// When
+	views, err := repo.LoadViews(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, views)
+	assert.NotNil(t, views)
+}
+
+func TestFileViewRepository_SaveViews_ShouldRoundTrip(t *testing.T) {
+	// Given
+	dataDir := filepath.Join(t.TempDir(), "data")
+	repo := NewFileViewRepository(dataDir)
+	views := []model.View{
+		{Name: "My backend work", Query: "tag:backend is:open"},
+		{Name: "Due this week", Query: "due<=+7d is:open"},
+	}
+
+	// When
+	require.NoError(t, repo.SaveViews(context.Background(), views))
+	loaded, err := repo.LoadViews(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, views, loaded)
+	assert.FileExists(t, filepath.Join(dataDir, "views.json"))
+	assert.NoFileExists(t, filepath.Join(dataDir, "tasks.json"))
+}
+
+func TestFileViewRepository_LoadViews_WithCorruptFile_ShouldReturnCorruptError(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "views.json"), []byte("{not json"), 0644))
+	repo := NewFileViewRepository(dataDir)
+
+	// When
+	_, err := repo.LoadViews(context.Background())
+
+	// Then
+	assert.True(t, errors.Is(err, ErrCorrupt))
+	assert.Contains(t, err.Error(), "views.json")
+}
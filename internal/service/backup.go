@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"task-cli/internal/model"
+	"task-cli/internal/repository"
+)
+
+// LatestBackup は最新のバックアップを指定するための名前
+const LatestBackup = "latest"
+
+// CreateBackup は現在のデータのバックアップを作成し、そのパスを返す
+func (s *TaskService) CreateBackup(ctx context.Context) (string, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	appData, err := s.loadAppData(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load data: %w", err)
+	}
+
+	path, err := s.repo.CreateBackup(ctx, appData)
+	if err != nil {
+		return "", fmt.Errorf("failed to create backup: %w", err)
+	}
+	return path, nil
+}
+
+// FindBackup は名前でバックアップを特定する
+// 完全なファイル名、拡張子なしの名前、一意な前方一致、または "latest" を受け付ける
+func (s *TaskService) FindBackup(ctx context.Context, name string) (*repository.BackupInfo, error) {
+	backups, err := s.repo.ListBackups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	if len(backups) == 0 {
+		return nil, repository.ErrNoBackups
+	}
+	if name == LatestBackup {
+		return &backups[0], nil
+	}
+
+	name = filepath.Base(name)
+	var matches []repository.BackupInfo
+	for _, backup := range backups {
+		if backup.Name == name || strings.TrimSuffix(backup.Name, ".json") == name {
+			return &backup, nil
+		}
+		if strings.HasPrefix(backup.Name, name) {
+			matches = append(matches, backup)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no backup matches %q", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("backup name %q is ambiguous: matches %d backups", name, len(matches))
+	}
+}
+
+// LoadBackup は名前で指定したバックアップの内容を読み込む
+func (s *TaskService) LoadBackup(ctx context.Context, name string) (*model.AppData, *repository.BackupInfo, error) {
+	backup, err := s.FindBackup(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appData, err := s.repo.RestoreFromBackup(ctx, backup.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read backup %s: %w", backup.Name, err)
+	}
+	return appData, backup, nil
+}
+
+// LoadCurrent は現在のデータ全体を読み込む
+func (s *TaskService) LoadCurrent(ctx context.Context) (*model.AppData, error) {
+	appData, err := s.loadAppData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %w", err)
+	}
+	return appData, nil
+}
+
+// RestoreBackup は名前で指定したバックアップからデータを復元する
+// 現在のデータは復元前にバックアップし、その結果を返す
+func (s *TaskService) RestoreBackup(ctx context.Context, name string) (*RestoreResult, error) {
+	backup, err := s.FindBackup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.restoreBackup(ctx, *backup)
+}
+
+// RestoreResult はバックアップからの復元結果
+type RestoreResult struct {
+	Restored   repository.BackupInfo // 復元に使用したバックアップ
+	TaskCount  int                   // 復元したタスク数
+	SafetyPath string                // 復元前のデータのバックアップ先（バックアップしなかった場合は空）
+}
+
+// restoreBackup はバックアップを読み込み、現在のデータを退避してから保存する
+// 現在のデータが破損している場合は退避済みのコピーがあるため、バックアップせずに上書きする
+func (s *TaskService) restoreBackup(ctx context.Context, backup repository.BackupInfo) (*RestoreResult, error) {
+	// 読み込んでから保存するまでの間に、他のプロセスがバックアップを削除しないようにする
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	appData, err := s.repo.RestoreFromBackup(ctx, backup.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore backup %s: %w", backup.Name, err)
+	}
+
+	result := &RestoreResult{Restored: backup, TaskCount: len(appData.Tasks)}
+
+	current, err := s.repo.Load(ctx)
+	switch {
+	case err == nil:
+		if result.SafetyPath, err = s.createSafetyBackup(ctx, current); err != nil {
+			return nil, fmt.Errorf("failed to back up current data: %w", err)
+		}
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, repository.ErrCorrupt):
+		// 失われるデータがない、または既に退避済み
+	default:
+		return nil, fmt.Errorf("failed to load data: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, appData); err != nil {
+		return nil, fmt.Errorf("failed to save restored data: %w", err)
+	}
+	return result, nil
+}
+
+// createSafetyBackup は復元前のデータをバックアップする
+// 復元に使うバックアップを保持方針で削除しないよう、可能な場合は古いバックアップを削除せずに作成する
+func (s *TaskService) createSafetyBackup(ctx context.Context, data *model.AppData) (string, error) {
+	if creator, ok := s.repo.(repository.SafetyBackupCreator); ok {
+		return creator.CreateSafetyBackup(ctx, data)
+	}
+	return s.repo.CreateBackup(ctx, data)
+}
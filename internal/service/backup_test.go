@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/model"
+	"task-cli/internal/repository"
+	"task-cli/internal/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFileTaskService は一時ディレクトリを使う実ファイルのTaskServiceを作成する
+func newFileTaskService(t *testing.T) *TaskService {
+	t.Helper()
+	return NewTaskService(repository.NewFileRepository(t.TempDir()), validator.New())
+}
+
+func TestTaskService_RestoreBackup_ShouldBackUpCurrentDataFirst(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	_, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Original", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	backupPath, err := service.CreateBackup(ctx)
+	require.NoError(t, err)
+	_, err = service.CreateTask(ctx, CreateTaskRequest{Title: "Added later", Priority: model.PriorityLow})
+	require.NoError(t, err)
+
+	// When
+	result, err := service.RestoreBackup(ctx, filepath.Base(backupPath))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TaskCount)
+	require.NotEmpty(t, result.SafetyPath)
+
+	tasks, err := service.GetAllTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Original", tasks[0].Title)
+
+	safety, _, err := service.LoadBackup(ctx, filepath.Base(result.SafetyPath))
+	require.NoError(t, err)
+	assert.Len(t, safety.Tasks, 2)
+}
+
+func TestTaskService_RestoreBackup_ShouldNotPruneRestoredBackup(t *testing.T) {
+	// Given - 2件だけ残す保持方針で、古い方のバックアップから復元する
+	repo := repository.NewFileRepository(t.TempDir(), repository.WithRetentionPolicy(repository.RetentionPolicy{KeepLast: 2}))
+	service := NewTaskService(repo, validator.New())
+	ctx := context.Background()
+	_, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Original", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	oldest, err := service.CreateBackup(ctx)
+	require.NoError(t, err)
+	_, err = service.CreateBackup(ctx)
+	require.NoError(t, err)
+
+	// When
+	result, err := service.RestoreBackup(ctx, filepath.Base(oldest))
+
+	// Then - 復元前の退避で復元したバックアップは削除されない
+	require.NoError(t, err)
+	require.NotEmpty(t, result.SafetyPath)
+	assert.FileExists(t, oldest)
+	backups, err := service.ListBackups(ctx)
+	require.NoError(t, err)
+	assert.Len(t, backups, 3)
+}
+
+func TestTaskService_FindBackup_ShouldResolveLatestAndPrefix(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	first, err := service.CreateBackup(ctx)
+	require.NoError(t, err)
+	second, err := service.CreateBackup(ctx)
+	require.NoError(t, err)
+
+	// When
+	latest, latestErr := service.FindBackup(ctx, LatestBackup)
+	byName, nameErr := service.FindBackup(ctx, filepath.Base(first))
+	_, ambiguousErr := service.FindBackup(ctx, "tasks_backup_")
+	_, missingErr := service.FindBackup(ctx, "nope")
+
+	// Then
+	require.NoError(t, latestErr)
+	assert.Equal(t, second, latest.Path)
+	require.NoError(t, nameErr)
+	assert.Equal(t, first, byName.Path)
+	assert.ErrorContains(t, ambiguousErr, "ambiguous")
+	assert.ErrorContains(t, missingErr, "no backup matches")
+}
+
+func TestTaskService_FindBackup_WithoutBackups_ShouldReturnErrNoBackups(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+
+	// When
+	_, err := service.FindBackup(context.Background(), LatestBackup)
+
+	// Then
+	assert.ErrorIs(t, err, repository.ErrNoBackups)
+}
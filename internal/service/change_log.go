@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"time"
+
+	"task-cli/internal/model"
+	"task-cli/internal/repository"
+)
+
+// unknownActor は変更したユーザーを特定できない場合に記録する名前
+const unknownActor = "unknown"
+
+const (
+	// maxTaskChanges はタスクごとに保持する変更履歴の件数
+	// 変更履歴はジャーナルのイベントや元に戻す操作の記録にも含まれるため、上限を設ける
+	maxTaskChanges = 50
+
+	// maxDeletions は保持する削除したタスクの記録の件数
+	maxDeletions = 1000
+)
+
+// Activity はタスクの変更履歴の1件と、変更されたタスク
+type Activity struct {
+	Task   *model.Task
+	Change model.ChangeEntry
+}
+
+// SetActor はタスクの変更履歴に記録するユーザー名を設定する
+func (s *TaskService) SetActor(actor string) {
+	s.actor = actor
+}
+
+// GetActor はタスクの変更履歴に記録するユーザー名を取得する
+func (s *TaskService) GetActor() string {
+	return s.actor
+}
+
+// GetActivity は since 以降に行われたタスクの変更を古い順に取得する
+// taskID が空の場合は全タスク、since がゼロ値の場合は全期間の変更を返す
+// 削除したタスクは変更履歴が失われるため、削除の記録だけを返す
+func (s *TaskService) GetActivity(ctx context.Context, taskID string, since time.Time) ([]Activity, error) {
+	tasks, err := s.GetAllTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deletions, err := s.GetDeletions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	activities := []Activity{}
+	for _, task := range tasks {
+		if taskID != "" && task.ID != taskID {
+			continue
+		}
+		for _, change := range task.Changes {
+			if change.At.Before(since) {
+				continue
+			}
+			activities = append(activities, Activity{Task: task, Change: change})
+		}
+	}
+	for _, deletion := range deletions {
+		if (taskID != "" && deletion.TaskID != taskID) || deletion.Change.At.Before(since) {
+			continue
+		}
+		task := &model.Task{ID: deletion.TaskID, Title: deletion.Title, ProjectID: deletion.ProjectID}
+		activities = append(activities, Activity{Task: task, Change: deletion.Change})
+	}
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].Change.At.Before(activities[j].Change.At)
+	})
+	return activities, nil
+}
+
+// recordChanges は before から after への変更を after のタスクの変更履歴に追加する
+// before にないタスクは created として、フィールドが変わったタスクは updated として記録する
+func (s *TaskService) recordChanges(before, after []*model.Task, created, updated model.ChangeAction, now time.Time) {
+	beforeByID := make(map[string]*model.Task, len(before))
+	for _, task := range before {
+		beforeByID[task.ID] = task
+	}
+
+	for _, task := range after {
+		entry := model.ChangeEntry{At: now, Actor: s.actor}
+		if old, ok := beforeByID[task.ID]; ok {
+			entry.Action, entry.Fields = updated, DiffTaskFields(old, task)
+			if len(entry.Fields) == 0 {
+				continue
+			}
+		} else {
+			entry.Action = created
+		}
+		task.AddChange(entry, maxTaskChanges)
+	}
+}
+
+// recordDeletions は before にあって after にないタスクの削除を記録する
+// Repositoryが削除の記録に対応していない場合は記録しない
+// 記録のファイルが破損している場合は新しい記録で置き換え、上限を超えた古い記録は捨てる
+func (s *TaskService) recordDeletions(ctx context.Context, before, after []*model.Task, now time.Time) error {
+	deletionRepo, ok := s.repo.(repository.DeletionRepository)
+	if !ok {
+		return nil
+	}
+
+	afterIDs := make(map[string]bool, len(after))
+	for _, task := range after {
+		afterIDs[task.ID] = true
+	}
+	var deleted []model.Deletion
+	for _, task := range before {
+		if !afterIDs[task.ID] {
+			deleted = append(deleted, model.Deletion{
+				TaskID:    task.ID,
+				Title:     task.Title,
+				ProjectID: task.ProjectID,
+				Change:    model.ChangeEntry{At: now, Actor: s.actor, Action: model.ChangeDeleted},
+			})
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	deletions, err := deletionRepo.LoadDeletions(ctx)
+	if errors.Is(err, repository.ErrCorrupt) {
+		deletions, err = []model.Deletion{}, nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load deletions: %w", err)
+	}
+
+	deletions = append(deletions, deleted...)
+	if len(deletions) > maxDeletions {
+		deletions = append([]model.Deletion{}, deletions[len(deletions)-maxDeletions:]...)
+	}
+	if err := deletionRepo.SaveDeletions(ctx, deletions); err != nil {
+		return fmt.Errorf("failed to save deletions: %w", err)
+	}
+	return nil
+}
+
+// GetDeletions は削除したタスクの記録を古い順に取得する
+// Repositoryが削除の記録に対応していない場合や、記録のファイルが破損している場合は空の記録を返す
+func (s *TaskService) GetDeletions(ctx context.Context) ([]model.Deletion, error) {
+	deletionRepo, ok := s.repo.(repository.DeletionRepository)
+	if !ok {
+		return nil, nil
+	}
+	deletions, err := deletionRepo.LoadDeletions(ctx)
+	if errors.Is(err, repository.ErrCorrupt) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deletions: %w", err)
+	}
+	return deletions, nil
+}
+
+// defaultActor はOSのユーザー名を返す（取得できない場合は $USER、それもなければ unknown）
+func defaultActor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return unknownActor
+}
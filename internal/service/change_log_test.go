@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskService_UpdateTask_ShouldRecordFieldChanges(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	service.SetActor("alice")
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Draft", Priority: model.PriorityLow})
+	require.NoError(t, err)
+
+	// When
+	updated, err := service.UpdateTask(ctx, UpdateTaskRequest{
+		ID:       task.ID,
+		Title:    "Final",
+		Priority: model.PriorityLow,
+		Status:   model.StatusInProgress,
+		Tags:     []string{"docs", "review"},
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, updated.Changes, 2)
+	assert.Equal(t, model.ChangeCreated, updated.Changes[0].Action)
+	assert.Equal(t, "alice", updated.Changes[0].Actor)
+	assert.Empty(t, updated.Changes[0].Fields)
+
+	change := updated.Changes[1]
+	assert.Equal(t, model.ChangeUpdated, change.Action)
+	assert.Equal(t, "alice", change.Actor)
+	assert.False(t, change.At.IsZero())
+	assert.Equal(t, []model.FieldChange{
+		{Field: "title", Old: "Draft", New: "Final"},
+		{Field: "status", Old: "todo", New: "in_progress"},
+		{Field: "tags", Old: "", New: "docs, review"},
+	}, change.Fields)
+
+	stored, err := service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Changes, 2)
+	assert.Equal(t, change.Fields, stored.Changes[1].Fields)
+}
+
+func TestTaskService_UpdateTask_WithoutChanges_ShouldNotRecordEntry(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Same", Priority: model.PriorityLow})
+	require.NoError(t, err)
+
+	// When
+	updated, err := service.UpdateTask(ctx, UpdateTaskRequest{
+		ID:       task.ID,
+		Title:    "Same",
+		Priority: model.PriorityLow,
+		Status:   model.StatusTodo,
+	})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, updated.Changes, 1)
+	assert.Equal(t, model.ChangeCreated, updated.Changes[0].Action)
+}
+
+func TestTaskService_Undo_ShouldKeepChangesAndRecordUndone(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Fix login bug", Priority: model.PriorityHigh})
+	require.NoError(t, err)
+	_, err = service.ToggleTaskStatus(ctx, task.ID)
+	require.NoError(t, err)
+
+	// When
+	_, err = service.Undo(ctx)
+
+	// Then - 元に戻しても変更履歴は失われず、元に戻したことが記録される
+	require.NoError(t, err)
+	current, err := service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, current.Changes, 3)
+	assert.Equal(t, model.ChangeCreated, current.Changes[0].Action)
+	assert.Equal(t, model.ChangeUpdated, current.Changes[1].Action)
+	undone := current.Changes[2]
+	assert.Equal(t, model.ChangeUndone, undone.Action)
+	assert.Contains(t, undone.Fields, model.FieldChange{Field: "status", Old: "completed", New: "todo"})
+
+	// When - やり直す
+	_, err = service.Redo(ctx)
+
+	// Then
+	require.NoError(t, err)
+	current, err = service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, current.Changes, 4)
+	assert.Equal(t, model.ChangeRedone, current.Changes[3].Action)
+}
+
+func TestTaskService_GetActivity_ShouldFilterByTaskAndSince(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	first, err := service.CreateTask(ctx, CreateTaskRequest{Title: "First", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	second, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Second", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	_, err = service.ToggleTaskStatus(ctx, first.ID)
+	require.NoError(t, err)
+
+	// When
+	all, err := service.GetActivity(ctx, "", time.Time{})
+	require.NoError(t, err)
+	forSecond, err := service.GetActivity(ctx, second.ID, time.Time{})
+	require.NoError(t, err)
+	future, err := service.GetActivity(ctx, "", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// Then - 全タスクの変更が古い順に並ぶ
+	require.Len(t, all, 3)
+	assert.Equal(t, "First", all[0].Task.Title)
+	assert.Equal(t, model.ChangeCreated, all[0].Change.Action)
+	assert.Equal(t, "Second", all[1].Task.Title)
+	assert.Equal(t, "First", all[2].Task.Title)
+	assert.Equal(t, model.ChangeUpdated, all[2].Change.Action)
+
+	require.Len(t, forSecond, 1)
+	assert.Equal(t, second.ID, forSecond[0].Task.ID)
+	assert.Empty(t, future)
+}
+
+func TestTaskService_GetActivity_ShouldIncludeDeletedTasks(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	service.SetActor("alice")
+	ctx := context.Background()
+	kept, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Kept", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	deleted, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Deleted", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	since := time.Now()
+
+	// When
+	require.NoError(t, service.DeleteTask(ctx, deleted.ID))
+	recent, err := service.GetActivity(ctx, "", since)
+	require.NoError(t, err)
+	forDeleted, err := service.GetActivity(ctx, deleted.ID, time.Time{})
+	require.NoError(t, err)
+	forKept, err := service.GetActivity(ctx, kept.ID, time.Time{})
+	require.NoError(t, err)
+
+	// Then - タスクとともに変更履歴が失われても削除は残る
+	require.Len(t, recent, 1)
+	assert.Equal(t, deleted.ID, recent[0].Task.ID)
+	assert.Equal(t, "Deleted", recent[0].Task.Title)
+	assert.Equal(t, model.DefaultProjectID, recent[0].Task.ProjectID)
+	assert.Equal(t, model.ChangeDeleted, recent[0].Change.Action)
+	assert.Equal(t, "alice", recent[0].Change.Actor)
+
+	require.Len(t, forDeleted, 1)
+	assert.Equal(t, model.ChangeDeleted, forDeleted[0].Change.Action)
+	require.Len(t, forKept, 1)
+	assert.Equal(t, model.ChangeCreated, forKept[0].Change.Action)
+}
+
+func TestTaskService_UpdateTask_ShouldKeepOnlyNewestChanges(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Title 0", Priority: model.PriorityLow})
+	require.NoError(t, err)
+
+	// When
+	for i := 1; i <= maxTaskChanges; i++ {
+		task, err = service.UpdateTask(ctx, UpdateTaskRequest{
+			ID: task.ID, Title: fmt.Sprintf("Title %d", i), Priority: model.PriorityLow, Status: model.StatusTodo,
+		})
+		require.NoError(t, err)
+	}
+
+	// Then - 上限を超えた古い変更（作成）は捨てられる
+	require.Len(t, task.Changes, maxTaskChanges)
+	assert.Equal(t, model.ChangeUpdated, task.Changes[0].Action)
+	assert.Equal(t, "Title 1", task.Changes[0].Fields[0].New)
+}
+
+func TestDiffTaskFields_ShouldFormatValues(t *testing.T) {
+	// Given
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := &model.Task{Title: "Task", Priority: model.PriorityLow}
+	after := &model.Task{
+		Title:      "Task",
+		Priority:   model.PriorityHigh,
+		DueDate:    &due,
+		Recurrence: &model.Recurrence{Frequency: model.FrequencyDaily, Interval: 1},
+		Estimate:   90,
+		Revision:   2,
+	}
+
+	// When
+	changes := DiffTaskFields(before, after)
+
+	// Then - リビジョンなどの管理用フィールドは含めない
+	assert.Equal(t, []model.FieldChange{
+		{Field: "priority", Old: "low", New: "high"},
+		{Field: "due_date", Old: "", New: "2024-03-01"},
+		{Field: "recurrence", Old: "", New: after.Recurrence.String()},
+		{Field: "estimate_minutes", Old: "0", New: "90"},
+	}, changes)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"task-cli/internal/model"
+)
+
+// ErrConflict はタスクが読み込まれた後に別の操作で変更されたことを表す
+var ErrConflict = errors.New("task changed since it was loaded")
+
+// ConflictError はリビジョンの不一致の詳細を表す
+// errors.Is(err, ErrConflict) で判定できる
+type ConflictError struct {
+	TaskID           string
+	ExpectedRevision int64
+	ActualRevision   int64
+}
+
+// Error はエラーメッセージを返す
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: task %s is at revision %d, expected %d",
+		ErrConflict, e.TaskID, e.ActualRevision, e.ExpectedRevision)
+}
+
+// Is はErrConflictとの比較を可能にする
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ErrBlocked は未完了のタスクにブロックされているタスクを完了しようとしたことを表す
+var ErrBlocked = errors.New("task is blocked")
+
+// BlockedError は完了を拒否したタスクとブロックしている未完了のタスクを表す
+// errors.Is(err, ErrBlocked) で判定できる
+type BlockedError struct {
+	TaskID   string
+	Blockers []*model.Task
+}
+
+// Error はエラーメッセージを返す
+func (e *BlockedError) Error() string {
+	titles := make([]string, len(e.Blockers))
+	for i, blocker := range e.Blockers {
+		titles[i] = blocker.Title
+	}
+	return fmt.Sprintf("%s: task %s is blocked by %d open tasks (%s)",
+		ErrBlocked, e.TaskID, len(e.Blockers), strings.Join(titles, ", "))
+}
+
+// Is はErrBlockedとの比較を可能にする
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
+
+// ErrViewNotFound は指定した名前のビューが保存されていないことを表す
+var ErrViewNotFound = errors.New("view not found")
+
+// ErrNothingToUndo は元に戻せる操作がないことを表す
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// ErrNothingToRedo はやり直せる操作がないことを表す
+var ErrNothingToRedo = errors.New("nothing to redo")
+
+// ErrProjectMismatch はサブタスクを親タスクと異なるプロジェクトに置こうとしたことを表す
+var ErrProjectMismatch = errors.New("a subtask must be in the same project as its parent")
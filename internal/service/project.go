@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"task-cli/internal/model"
+)
+
+// actionMove はタスクを別のプロジェクトに移動する操作の種類
+const actionMove = "move"
+
+// CreateProjectRequest はプロジェクト作成のリクエスト
+type CreateProjectRequest struct {
+	Name        string
+	Color       string   // 色名または #rrggbb（空の場合は既定の色）
+	DefaultTags []string // このプロジェクトに作成するタスクに付けるタグ
+}
+
+// ProjectStats はプロジェクトごとのタスクの集計
+type ProjectStats struct {
+	Project    *model.Project
+	Total      int
+	Todo       int
+	InProgress int
+	Completed  int
+	Overdue    int // 期限切れの未完了タスクの数
+}
+
+// ListProjects はプロジェクトの一覧を作成順に取得する
+func (s *TaskService) ListProjects(ctx context.Context) ([]*model.Project, error) {
+	appData, err := s.loadAppData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %w", err)
+	}
+	return appData.Projects, nil
+}
+
+// FindProject は名前（大文字小文字は区別しない）またはIDでプロジェクトを取得する
+func (s *TaskService) FindProject(ctx context.Context, nameOrID string) (*model.Project, error) {
+	appData, err := s.loadAppData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %w", err)
+	}
+	return appData.FindProject(nameOrID)
+}
+
+// CreateProject は新しいプロジェクトを作成する
+func (s *TaskService) CreateProject(ctx context.Context, request CreateProjectRequest) (*model.Project, error) {
+	project, err := model.NewProject(request.Name, request.Color, request.DefaultTags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mutate(ctx, actionCreate, func(appData *model.AppData) error {
+		return appData.AddProject(project)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject はタスクのないプロジェクトを削除する（既定のプロジェクトは削除できない）
+func (s *TaskService) DeleteProject(ctx context.Context, projectID string) error {
+	return s.mutate(ctx, actionDelete, func(appData *model.AppData) error {
+		return appData.DeleteProject(projectID)
+	})
+}
+
+// MoveTasks はタスクをサブタスクごと別のプロジェクトに移動し、移動したタスクを返す
+// 親タスクを一緒に移動しないサブタスクは親から外す
+func (s *TaskService) MoveTasks(ctx context.Context, projectID string, taskIDs ...string) ([]*model.Task, error) {
+	if len(taskIDs) == 0 {
+		return nil, errors.New("no tasks to move")
+	}
+
+	var moved []*model.Task
+	err := s.mutate(ctx, actionMove, func(appData *model.AppData) error {
+		project, err := appData.GetProjectByID(projectID)
+		if err != nil {
+			return err
+		}
+
+		moving := make(map[string]bool)
+		for _, id := range uniqueIDs(taskIDs) {
+			if _, err := appData.GetTaskByID(id); err != nil {
+				return fmt.Errorf("task not found: %w", err)
+			}
+			moving[id] = true
+			for _, descendant := range appData.GetDescendants(id) {
+				moving[descendant.ID] = true
+			}
+		}
+
+		now := time.Now()
+		moved = nil
+		for _, task := range appData.Tasks {
+			if !moving[task.ID] || task.ProjectID == project.ID {
+				continue
+			}
+			if task.ParentID != "" && !moving[task.ParentID] {
+				task.ParentID = ""
+			}
+			task.ProjectID = project.ID
+			task.UpdatedAt = now
+			if err := appData.UpdateTask(task); err != nil {
+				return fmt.Errorf("failed to update task: %w", err)
+			}
+			moved = append(moved, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
+
+// GetProjectStats はプロジェクトごとのタスクの集計をプロジェクトの作成順に取得する
+func (s *TaskService) GetProjectStats(ctx context.Context) ([]ProjectStats, error) {
+	appData, err := s.loadAppData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %w", err)
+	}
+	return CollectProjectStats(appData, time.Now()), nil
+}
+
+// CollectProjectStats は now 時点でのプロジェクトごとのタスクの集計を返す
+func CollectProjectStats(appData *model.AppData, now time.Time) []ProjectStats {
+	stats := make([]ProjectStats, len(appData.Projects))
+	indexes := make(map[string]int, len(appData.Projects))
+	for i, project := range appData.Projects {
+		stats[i].Project = project
+		indexes[project.ID] = i
+	}
+
+	for _, task := range appData.Tasks {
+		i, ok := indexes[task.ProjectID]
+		if !ok {
+			continue
+		}
+		stats[i].Total++
+		switch task.Status {
+		case model.StatusTodo:
+			stats[i].Todo++
+		case model.StatusInProgress:
+			stats[i].InProgress++
+		case model.StatusCompleted:
+			stats[i].Completed++
+		}
+		if task.DueStatus(now) == model.DueOverdue {
+			stats[i].Overdue++
+		}
+	}
+	return stats
+}
+
+// taskProject は作成するタスクのプロジェクトを決める
+// サブタスクは親タスクと同じプロジェクトでなければならない
+func taskProject(appData *model.AppData, projectID, parentID string) (*model.Project, error) {
+	var parent *model.Task
+	if parentID != "" {
+		var err error
+		if parent, err = appData.GetTaskByID(parentID); err != nil {
+			return nil, fmt.Errorf("parent task not found: %w", err)
+		}
+	}
+
+	switch {
+	case projectID == "" && parent != nil:
+		projectID = parent.ProjectID
+	case projectID == "":
+		projectID = model.DefaultProjectID
+	case parent != nil && parent.ProjectID != projectID:
+		return nil, ErrProjectMismatch
+	}
+	return appData.GetProjectByID(projectID)
+}
+
+// mergeTags は既定のタグの後ろに、まだ含まれていないタグを追加したタグを返す
+func mergeTags(defaults, tags []string) []string {
+	if len(defaults) == 0 {
+		return tags
+	}
+
+	merged := append([]string{}, defaults...)
+	for _, tag := range tags {
+		duplicate := false
+		for _, existing := range merged {
+			if strings.EqualFold(existing, tag) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskService_CreateTask_WithProject_ShouldAddDefaultTags(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	work, err := service.CreateProject(ctx, CreateProjectRequest{Name: "Work", Color: "blue", DefaultTags: []string{"job"}})
+	require.NoError(t, err)
+
+	// When
+	task, err := service.CreateTask(ctx, CreateTaskRequest{
+		Title:     "Report",
+		Priority:  model.PriorityLow,
+		Tags:      []string{"writing", "JOB"},
+		ProjectID: work.ID,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, work.ID, task.ProjectID)
+	assert.Equal(t, []string{"job", "writing"}, task.Tags)
+	projects, err := service.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, model.DefaultProjectName, projects[0].Name)
+	assert.Equal(t, "Work", projects[1].Name)
+}
+
+func TestTaskService_CreateTask_WithoutProject_ShouldUseParentOrDefaultProject(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	work, err := service.CreateProject(ctx, CreateProjectRequest{Name: "Work"})
+	require.NoError(t, err)
+	parent, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Release", Priority: model.PriorityLow, ProjectID: work.ID})
+	require.NoError(t, err)
+
+	// When
+	child, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Build", Priority: model.PriorityLow, ParentID: parent.ID})
+	require.NoError(t, err)
+	loose, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Groceries", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	_, mismatchErr := service.CreateTask(ctx, CreateTaskRequest{
+		Title:     "Elsewhere",
+		Priority:  model.PriorityLow,
+		ParentID:  parent.ID,
+		ProjectID: model.DefaultProjectID,
+	})
+
+	// Then
+	assert.Equal(t, work.ID, child.ProjectID)
+	assert.Equal(t, model.DefaultProjectID, loose.ProjectID)
+	assert.ErrorContains(t, mismatchErr, "same project as its parent")
+}
+
+func TestTaskService_CreateProject_WithDuplicateName_ShouldFail(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	_, err := service.CreateProject(ctx, CreateProjectRequest{Name: "Work"})
+	require.NoError(t, err)
+
+	// When
+	_, err = service.CreateProject(ctx, CreateProjectRequest{Name: "work"})
+
+	// Then
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestTaskService_MoveTasks_ShouldMoveSubtasksAndDetachFromStayingParent(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	work, err := service.CreateProject(ctx, CreateProjectRequest{Name: "Work"})
+	require.NoError(t, err)
+	parent, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Release", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	child, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Build", Priority: model.PriorityLow, ParentID: parent.ID})
+	require.NoError(t, err)
+	grandchild, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Compile", Priority: model.PriorityLow, ParentID: child.ID})
+	require.NoError(t, err)
+
+	// When
+	moved, err := service.MoveTasks(ctx, work.ID, child.ID)
+
+	// Then - サブタスクも一緒に移動し、残る親からは外れる
+	require.NoError(t, err)
+	assert.Len(t, moved, 2)
+	movedChild, err := service.GetTaskByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, work.ID, movedChild.ProjectID)
+	assert.Empty(t, movedChild.ParentID)
+	movedGrandchild, err := service.GetTaskByID(ctx, grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, work.ID, movedGrandchild.ProjectID)
+	assert.Equal(t, child.ID, movedGrandchild.ParentID)
+	staying, err := service.GetTaskByID(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultProjectID, staying.ProjectID)
+
+	// 移動は元に戻せる
+	operation, err := service.Undo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `move "Build" and 1 more`, operation.Description)
+	restored, err := service.GetTaskByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultProjectID, restored.ProjectID)
+	assert.Equal(t, parent.ID, restored.ParentID)
+}
+
+func TestTaskService_DeleteProject_ShouldRefuseProjectWithTasks(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	work, err := service.CreateProject(ctx, CreateProjectRequest{Name: "Work"})
+	require.NoError(t, err)
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Report", Priority: model.PriorityLow, ProjectID: work.ID})
+	require.NoError(t, err)
+
+	// When
+	refused := service.DeleteProject(ctx, work.ID)
+	require.NoError(t, service.DeleteTask(ctx, task.ID))
+	deleted := service.DeleteProject(ctx, work.ID)
+
+	// Then
+	assert.ErrorContains(t, refused, "still has 1 tasks")
+	assert.NoError(t, deleted)
+	_, err = service.FindProject(ctx, "Work")
+	assert.Error(t, err)
+}
+
+func TestCollectProjectStats_ShouldCountTasksPerProject(t *testing.T) {
+	// Given
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	data := model.NewAppData()
+	work, _ := model.NewProject("Work", "", nil)
+	require.NoError(t, data.AddProject(work))
+	yesterday := now.AddDate(0, 0, -1)
+	for _, spec := range []struct {
+		project string
+		status  model.Status
+		due     *time.Time
+	}{
+		{model.DefaultProjectID, model.StatusTodo, nil},
+		{work.ID, model.StatusTodo, &yesterday},
+		{work.ID, model.StatusInProgress, nil},
+		{work.ID, model.StatusCompleted, &yesterday},
+	} {
+		task, _ := model.NewTask("Task", "", model.PriorityLow, nil)
+		task.ProjectID, task.Status, task.DueDate = spec.project, spec.status, spec.due
+		require.NoError(t, data.AddTask(task))
+	}
+
+	// When
+	stats := CollectProjectStats(data, now)
+
+	// Then - 完了済みのタスクは期限切れに数えない
+	require.Len(t, stats, 2)
+	assert.Equal(t, ProjectStats{Project: data.Projects[0], Total: 1, Todo: 1}, stats[0])
+	assert.Equal(t, ProjectStats{Project: work, Total: 3, Todo: 1, InProgress: 1, Completed: 1, Overdue: 1}, stats[1])
+}
+
+func TestFilterTasks_WithProject_ShouldKeepProjectTasks(t *testing.T) {
+	// Given
+	inDefault, _ := model.NewTask("Default", "", model.PriorityLow, nil)
+	inDefault.ProjectID = model.DefaultProjectID
+	inWork, _ := model.NewTask("Work", "", model.PriorityLow, nil)
+	inWork.ProjectID = "work"
+
+	// When
+	filtered := FilterTasks([]*model.Task{inDefault, inWork}, TaskFilter{ProjectID: "work"})
+
+	// Then
+	assert.Equal(t, []*model.Task{inWork}, filtered)
+}
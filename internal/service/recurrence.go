@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"task-cli/internal/model"
+)
+
+// maxRecurrenceSteps は期限切れの繰り返しタスクの次回を探す際に進める回数の上限
+const maxRecurrenceSteps = 10000
+
+// scheduleNextOccurrences は完了にした繰り返しタスクの次の回を作成して追加する
+// 繰り返しルールは新しい回に引き継ぎ、完了したタスクは履歴として残す
+func (s *TaskService) scheduleNextOccurrences(appData *model.AppData, completed []*model.Task) ([]*model.Task, error) {
+	var created []*model.Task
+	for _, task := range completed {
+		if task.Recurrence == nil || task.CompletedAt == nil {
+			continue
+		}
+
+		next, err := nextOccurrence(task, *task.CompletedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.validator.ValidateTask(next); err != nil {
+			return nil, fmt.Errorf("next occurrence validation failed: %w", err)
+		}
+		if err := appData.AddTask(next); err != nil {
+			return nil, fmt.Errorf("failed to add next occurrence: %w", err)
+		}
+
+		// 完了を取り消して再度完了にしても次の回が重複しないようにする
+		task.SeriesID = next.SeriesID
+		task.Recurrence = nil
+		created = append(created, next)
+	}
+	return created, nil
+}
+
+// nextOccurrence は完了した繰り返しタスクから次の回のタスクを作成する
+// 期限日から数えるルールでは、完了日より後になるまで期限日を進める
+func nextOccurrence(task *model.Task, completedAt time.Time) (*model.Task, error) {
+	rule := task.Recurrence
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	// 期限日から数えるルールは最初の期限日の日を引き継ぎ、月末に丸めた日から数えてもずれないようにする
+	if task.DueDate != nil {
+		rule = rule.Anchor(*task.DueDate)
+	}
+
+	var due time.Time
+	switch {
+	case task.DueDate == nil:
+		due = rule.Next(completedAt)
+	case rule.FromCompletion:
+		// 完了日を基準にしつつ、期限日の時刻は保つ
+		year, month, day := completedAt.In(task.DueDate.Location()).Date()
+		base := time.Date(year, month, day, task.DueDate.Hour(), task.DueDate.Minute(),
+			task.DueDate.Second(), 0, task.DueDate.Location())
+		due = rule.Next(base)
+	default:
+		due = rule.Next(*task.DueDate)
+		for steps := 0; !due.After(completedAt); steps++ {
+			if steps == maxRecurrenceSteps {
+				return nil, errors.New("could not find the next occurrence after the completion date")
+			}
+			due = rule.Next(due)
+		}
+	}
+
+	next, err := model.NewTask(task.Title, task.Description, task.Priority, append([]string(nil), task.Tags...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+	next.Recurrence = rule.Anchor(due)
+	next.ParentID = task.ParentID
+	next.ProjectID = task.ProjectID
+	next.Estimate = task.Estimate
+	next.DueDate = &due
+	next.SeriesID = task.SeriesID
+	if next.SeriesID == "" {
+		next.SeriesID = task.ID
+	}
+	return next, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRecurringTask は指定したルールと期限日を持つ繰り返しタスクを作成する
+func createRecurringTask(t *testing.T, service *TaskService, rule string, due *time.Time) *model.Task {
+	t.Helper()
+
+	recurrence, err := model.ParseRecurrence(rule)
+	require.NoError(t, err)
+	task, err := service.CreateTask(context.Background(), CreateTaskRequest{
+		Title: "Release checklist", Priority: model.PriorityHigh, Tags: []string{"release"},
+		DueDate: due, Recurrence: recurrence,
+	})
+	require.NoError(t, err)
+	return task
+}
+
+// openOccurrence は未完了の繰り返しタスクを返す
+func openOccurrence(t *testing.T, service *TaskService) *model.Task {
+	t.Helper()
+
+	tasks, err := service.GetTasksByStatus(context.Background(), model.StatusTodo)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	return tasks[0]
+}
+
+func TestTaskService_ToggleTaskStatus_WithRecurringTask_ShouldScheduleNextOccurrence(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	due := time.Now().AddDate(0, 0, 1).Truncate(time.Second)
+	task := createRecurringTask(t, service, "weekly", &due)
+
+	// When
+	completed, err := service.ToggleTaskStatus(ctx, task.ID)
+
+	// Then
+	require.NoError(t, err)
+	next := openOccurrence(t, service)
+	assert.NotEqual(t, task.ID, next.ID)
+	assert.Equal(t, "Release checklist", next.Title)
+	assert.Equal(t, model.PriorityHigh, next.Priority)
+	assert.Equal(t, []string{"release"}, next.Tags)
+	require.NotNil(t, next.DueDate)
+	assert.True(t, due.AddDate(0, 0, 7).Equal(*next.DueDate))
+	assert.Equal(t, "every week", next.Recurrence.String())
+	assert.Equal(t, task.ID, next.SeriesID)
+
+	// 完了したタスクは履歴として残り、ルールは次の回に引き継がれる
+	assert.Equal(t, model.StatusCompleted, completed.Status)
+	assert.Nil(t, completed.Recurrence)
+	assert.Equal(t, task.ID, completed.SeriesID)
+	all, _ := service.GetAllTasks(ctx)
+	assert.Len(t, all, 2)
+}
+
+func TestTaskService_ToggleTaskStatus_WithMonthlyRuleOnThe31st_ShouldKeepTheDay(t *testing.T) {
+	// Given - 来年1月31日から毎月繰り返す
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	due := time.Date(time.Now().Year()+1, time.January, 31, 9, 0, 0, 0, time.Local)
+	createRecurringTask(t, service, "monthly", &due)
+
+	// When - 2月末に丸めた回を完了する
+	_, err := service.ToggleTaskStatus(ctx, openOccurrence(t, service).ID)
+	require.NoError(t, err)
+	february := openOccurrence(t, service)
+	_, err = service.ToggleTaskStatus(ctx, february.ID)
+	require.NoError(t, err)
+
+	// Then - 3月は28日ではなく31日になる
+	require.NotNil(t, february.DueDate)
+	assert.Equal(t, time.February, february.DueDate.Month())
+	march := openOccurrence(t, service)
+	require.NotNil(t, march.DueDate)
+	assert.True(t, time.Date(due.Year(), time.March, 31, 9, 0, 0, 0, time.Local).Equal(*march.DueDate))
+}
+
+func TestTaskService_UpdateTask_CompletingOverdueRecurringTask_ShouldScheduleAfterToday(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	due := time.Now().AddDate(0, 0, -10).Truncate(time.Second)
+	task := createRecurringTask(t, service, "every 3 days", &due)
+
+	// When
+	_, err := service.UpdateTask(context.Background(), UpdateTaskRequest{
+		ID: task.ID, Title: task.Title, Priority: task.Priority, Tags: task.Tags, DueDate: task.DueDate,
+		Status: model.StatusCompleted,
+	})
+
+	// Then - 期限日から3日ごとに進め、今日より後の最初の回にする
+	require.NoError(t, err)
+	next := openOccurrence(t, service)
+	assert.True(t, due.AddDate(0, 0, 12).Equal(*next.DueDate), next.DueDate)
+}
+
+func TestTaskService_CompleteTask_WithFromCompletionRule_ShouldCountFromCompletion(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	due := time.Date(2020, 1, 1, 9, 0, 0, 0, time.Local)
+	task := createRecurringTask(t, service, "every 10 days after completion", &due)
+
+	// When
+	completed, err := service.CompleteTask(context.Background(), task.ID, CompleteOptions{})
+
+	// Then - 完了日の10日後で、期限日の時刻を保つ
+	require.NoError(t, err)
+	next := openOccurrence(t, service)
+	completedOn := completed.CompletedAt.AddDate(0, 0, 10)
+	assert.Equal(t, completedOn.Format("2006-01-02"), next.DueDate.Format("2006-01-02"))
+	assert.Equal(t, 9, next.DueDate.Hour())
+}
+
+func TestTaskService_ToggleTaskStatus_ReopeningCompletedOccurrence_ShouldNotScheduleAgain(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task := createRecurringTask(t, service, "daily", nil)
+	_, err := service.ToggleTaskStatus(ctx, task.ID)
+	require.NoError(t, err)
+
+	// When
+	_, err = service.ToggleTaskStatus(ctx, task.ID)
+	require.NoError(t, err)
+	_, err = service.ToggleTaskStatus(ctx, task.ID)
+	require.NoError(t, err)
+
+	// Then
+	all, err := service.GetAllTasks(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestTaskService_UpdateTask_WithClearRecurrence_ShouldStopRepeating(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task := createRecurringTask(t, service, "monthly", nil)
+
+	// When
+	updated, err := service.UpdateTask(ctx, UpdateTaskRequest{
+		ID: task.ID, Title: task.Title, Priority: task.Priority, Status: task.Status, ClearRecurrence: true,
+	})
+	require.NoError(t, err)
+	_, err = service.ToggleTaskStatus(ctx, task.ID)
+	require.NoError(t, err)
+
+	// Then
+	assert.Nil(t, updated.Recurrence)
+	all, _ := service.GetAllTasks(ctx)
+	assert.Len(t, all, 1)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"task-cli/internal/model"
+)
+
+// ignoredDiffFields は差分の比較対象から除外する管理用のフィールド
+var ignoredDiffFields = map[string]bool{
+	"updated_at": true,
+	"revision":   true,
+	"changes":    true,
+}
+
+// SnapshotDiff は2つのデータの間のタスクの差分
+type SnapshotDiff struct {
+	Added   []*model.Task
+	Removed []*model.Task
+	Changed []TaskChange
+}
+
+// TaskChange は変更されたタスクの変更前後と、変更されたフィールド（jsonタグ名）
+type TaskChange struct {
+	Before *model.Task
+	After  *model.Task
+	Fields []string
+}
+
+// IsEmpty は差分がないかを返す
+func (d SnapshotDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffSnapshots は before から after への差分を求める
+// 追加と変更は after の順序、削除は before の順序で返す
+func DiffSnapshots(before, after *model.AppData) SnapshotDiff {
+	beforeByID := make(map[string]*model.Task)
+	if before != nil {
+		for _, task := range before.Tasks {
+			beforeByID[task.ID] = task
+		}
+	}
+
+	var diff SnapshotDiff
+	afterIDs := make(map[string]bool)
+	if after != nil {
+		for _, task := range after.Tasks {
+			afterIDs[task.ID] = true
+			old, ok := beforeByID[task.ID]
+			if !ok {
+				diff.Added = append(diff.Added, task)
+				continue
+			}
+			if fields := ChangedTaskFields(old, task); len(fields) > 0 {
+				diff.Changed = append(diff.Changed, TaskChange{Before: old, After: task, Fields: fields})
+			}
+		}
+	}
+
+	if before != nil {
+		for _, task := range before.Tasks {
+			if !afterIDs[task.ID] {
+				diff.Removed = append(diff.Removed, task)
+			}
+		}
+	}
+	return diff
+}
+
+// ChangedTaskFields は2つのタスクで値が異なるフィールドのjsonタグ名を宣言順に返す
+// 更新日時やリビジョンなどの管理用フィールドは比較しない
+func ChangedTaskFields(before, after *model.Task) []string {
+	var fields []string
+	for _, change := range DiffTaskFields(before, after) {
+		fields = append(fields, change.Field)
+	}
+	return fields
+}
+
+// DiffTaskFields は2つのタスクで値が異なるフィールドを、変更前後の値を文字列化して宣言順に返す
+// 更新日時やリビジョンなどの管理用フィールドは比較しない
+func DiffTaskFields(before, after *model.Task) []model.FieldChange {
+	var changes []model.FieldChange
+	beforeValue := reflect.ValueOf(before).Elem()
+	afterValue := reflect.ValueOf(after).Elem()
+	taskType := beforeValue.Type()
+
+	for i := 0; i < taskType.NumField(); i++ {
+		name := strings.Split(taskType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || ignoredDiffFields[name] {
+			continue
+		}
+		oldValue, newValue := beforeValue.Field(i).Interface(), afterValue.Field(i).Interface()
+		if !fieldValuesEqual(oldValue, newValue) {
+			changes = append(changes, model.FieldChange{
+				Field: name,
+				Old:   formatFieldValue(oldValue),
+				New:   formatFieldValue(newValue),
+			})
+		}
+	}
+	return changes
+}
+
+// fieldValuesEqual はフィールドの値を比較する
+// 日時はタイムゾーンや単調時計の違いを無視し、nilと空のスライスは等しいとみなす
+func fieldValuesEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case time.Time:
+		return av.Equal(b.(time.Time))
+	case *time.Time:
+		bv := b.(*time.Time)
+		if av == nil || bv == nil {
+			return av == nil && bv == nil
+		}
+		return av.Equal(*bv)
+	}
+
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ra.Kind() == reflect.Slice && ra.Len() == 0 && rb.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// formatFieldValue は変更履歴に記録するためにフィールドの値を文字列化する
+// 時刻を含まない日時は日付のみ、スライスはカンマ区切りで表し、nilは空文字列にする
+func formatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return formatChangeTime(v)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatChangeTime(*v)
+	case []string:
+		return strings.Join(v, ", ")
+	case *model.Recurrence:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// formatChangeTime は日時を変更履歴用に文字列化する
+func formatChangeTime(t time.Time) string {
+	switch {
+	case t.IsZero():
+		return ""
+	case t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0:
+		return t.Format("2006-01-02")
+	default:
+		return t.Format("2006-01-02 15:04")
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSnapshots_ShouldReportAddedRemovedAndChangedTasks(t *testing.T) {
+	// Given
+	before := model.NewAppData()
+	kept, _ := model.NewTask("Kept", "", model.PriorityLow, nil)
+	changed, _ := model.NewTask("Changed", "", model.PriorityLow, []string{"a"})
+	removed, _ := model.NewTask("Removed", "", model.PriorityLow, nil)
+	before.AddTask(kept)
+	before.AddTask(changed)
+	before.AddTask(removed)
+
+	after := model.NewAppData()
+	keptCopy := *kept
+	keptCopy.UpdatedAt = time.Now().Add(time.Hour) // 管理用フィールドのみの変更は無視する
+	keptCopy.Revision = 5
+	changedCopy := *changed
+	changedCopy.Title = "Changed title"
+	changedCopy.Status = model.StatusCompleted
+	added, _ := model.NewTask("Added", "", model.PriorityHigh, nil)
+	after.AddTask(&keptCopy)
+	after.AddTask(&changedCopy)
+	after.AddTask(added)
+
+	// When
+	diff := DiffSnapshots(before, after)
+
+	// Then
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "Added", diff.Added[0].Title)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "Removed", diff.Removed[0].Title)
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, changed.ID, diff.Changed[0].After.ID)
+	assert.Equal(t, []string{"title", "status"}, diff.Changed[0].Fields)
+	assert.False(t, diff.IsEmpty())
+}
+
+func TestDiffSnapshots_WithIdenticalData_ShouldBeEmpty(t *testing.T) {
+	// Given
+	data := model.NewAppData()
+	task, _ := model.NewTask("Same", "", model.PriorityLow, nil)
+	data.AddTask(task)
+
+	// When
+	diff := DiffSnapshots(data, data)
+
+	// Then
+	assert.True(t, diff.IsEmpty())
+}
+
+func TestChangedTaskFields_ShouldCompareTimesAndEmptyTagsByValue(t *testing.T) {
+	// Given
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	dueLocal := due.In(time.FixedZone("JST", 9*60*60))
+	before := &model.Task{ID: "1", Title: "Task", DueDate: &due, Tags: nil}
+	after := &model.Task{ID: "1", Title: "Task", DueDate: &dueLocal, Tags: []string{}}
+
+	// When
+	fields := ChangedTaskFields(before, after)
+
+	// Then
+	assert.Empty(t, fields)
+}
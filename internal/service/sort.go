@@ -0,0 +1,159 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"task-cli/internal/model"
+)
+
+// SortField はタスクを並べ替える属性
+type SortField string
+
+const (
+	SortByPriority SortField = "priority"
+	SortByDue      SortField = "due"
+	SortByCreated  SortField = "created"
+	SortByUpdated  SortField = "updated"
+	SortByTitle    SortField = "title"
+	SortByTag      SortField = "tag"
+	SortByStatus   SortField = "status"
+)
+
+// SortKey は並べ替えの属性と向き
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// String は属性と向きを矢印で表す（例: "priority ↓"）
+func (k SortKey) String() string {
+	if k.Descending {
+		return string(k.Field) + " ↓"
+	}
+	return string(k.Field) + " ↑"
+}
+
+// SortOrder は優先する順に並べた並べ替えのキー
+// すべてのキーで等しいタスクは元の順序を保つ（空の場合は並べ替えない）
+type SortOrder []SortKey
+
+// DefaultSortOrder は既定の並び順（優先度の高い順、期限の近い順、作成の古い順）を返す
+func DefaultSortOrder() SortOrder {
+	return SortOrder{
+		{Field: SortByPriority, Descending: true},
+		{Field: SortByDue},
+		{Field: SortByCreated},
+	}
+}
+
+// String はキーをカンマ区切りで返す（例: "priority ↓, due ↑"）
+func (o SortOrder) String() string {
+	keys := make([]string, len(o))
+	for i, key := range o {
+		keys[i] = key.String()
+	}
+	return strings.Join(keys, ", ")
+}
+
+// Equal は同じキーと向きの並び順かを返す
+func (o SortOrder) Equal(other SortOrder) bool {
+	if len(o) != len(other) {
+		return false
+	}
+	for i := range o {
+		if o[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SortTasks は並び順に従って並べ替えたタスクリストを返す（tasks は変更しない）
+func SortTasks(tasks []*model.Task, order SortOrder) []*model.Task {
+	sorted := make([]*model.Task, len(tasks))
+	copy(sorted, tasks)
+	if len(order) == 0 {
+		return sorted
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for _, key := range order {
+			if c := compareTasks(sorted[i], sorted[j], key); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return sorted
+}
+
+// compareTasks はキーに従って a と b を比較する（負: a が先、0: 等しい、正: b が先）
+// 期限やタグがないタスクは向きに関係なく後ろに並べる
+func compareTasks(a, b *model.Task, key SortKey) int {
+	var c int
+	switch key.Field {
+	case SortByPriority:
+		c = a.Priority.Rank() - b.Priority.Rank()
+	case SortByDue:
+		if a.DueDate == nil || b.DueDate == nil {
+			return compareMissing(a.DueDate == nil, b.DueDate == nil)
+		}
+		c = a.DueDate.Compare(*b.DueDate)
+	case SortByCreated:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case SortByUpdated:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortByTitle:
+		c = strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case SortByTag:
+		tagA, tagB := firstTag(a), firstTag(b)
+		if tagA == "" || tagB == "" {
+			return compareMissing(tagA == "", tagB == "")
+		}
+		c = strings.Compare(tagA, tagB)
+	case SortByStatus:
+		c = statusOrder(a.Status) - statusOrder(b.Status)
+	}
+	if key.Descending {
+		return -c
+	}
+	return c
+}
+
+// compareMissing は値がないタスクを後ろにする比較結果を返す
+func compareMissing(aMissing, bMissing bool) int {
+	switch {
+	case aMissing && bMissing:
+		return 0
+	case aMissing:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// firstTag はアルファベット順で最初のタグを小文字で返す（タグがない場合は空文字列）
+func firstTag(task *model.Task) string {
+	first := ""
+	for _, tag := range task.Tags {
+		tag = strings.ToLower(tag)
+		if first == "" || tag < first {
+			first = tag
+		}
+	}
+	return first
+}
+
+// statusOrder は作業の流れ（未着手、作業中、完了）の順位を返す
+func statusOrder(status model.Status) int {
+	switch status {
+	case model.StatusTodo:
+		return 0
+	case model.StatusInProgress:
+		return 1
+	case model.StatusCompleted:
+		return 2
+	}
+	return 3
+}
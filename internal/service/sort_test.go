@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// titlesOf はタスクのタイトルを順に返す
+func titlesOf(tasks []*model.Task) []string {
+	titles := make([]string, len(tasks))
+	for i, task := range tasks {
+		titles[i] = task.Title
+	}
+	return titles
+}
+
+func TestSortTasks_WithDefaultOrder_ShouldSortByPriorityThenDueThenCreated(t *testing.T) {
+	// Given
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	soon, later := base.AddDate(0, 0, 1), base.AddDate(0, 0, 5)
+	tasks := []*model.Task{
+		{Title: "low", Priority: model.PriorityLow, CreatedAt: base},
+		{Title: "high no due, old", Priority: model.PriorityHigh, CreatedAt: base},
+		{Title: "high later", Priority: model.PriorityHigh, DueDate: &later, CreatedAt: base},
+		{Title: "high no due, new", Priority: model.PriorityHigh, CreatedAt: base.Add(time.Hour)},
+		{Title: "high soon", Priority: model.PriorityHigh, DueDate: &soon, CreatedAt: base},
+		{Title: "medium", Priority: model.PriorityMedium, CreatedAt: base},
+	}
+
+	// When
+	sorted := SortTasks(tasks, DefaultSortOrder())
+
+	// Then
+	assert.Equal(t, []string{"high soon", "high later", "high no due, old", "high no due, new", "medium", "low"}, titlesOf(sorted))
+	assert.Equal(t, "low", tasks[0].Title, "the input should not be reordered")
+}
+
+func TestSortTasks_WithMissingValues_ShouldKeepThemLastInBothDirections(t *testing.T) {
+	// Given
+	due := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []*model.Task{
+		{Title: "none"},
+		{Title: "due", DueDate: &due, Tags: []string{"Zeta", "alpha"}},
+	}
+
+	// When & Then
+	assert.Equal(t, []string{"due", "none"}, titlesOf(SortTasks(tasks, SortOrder{{Field: SortByDue, Descending: true}})))
+	assert.Equal(t, []string{"due", "none"}, titlesOf(SortTasks(tasks, SortOrder{{Field: SortByTag}})))
+}
+
+func TestSortTasks_ByTitleAndTag_ShouldIgnoreCase(t *testing.T) {
+	// Given
+	tasks := []*model.Task{
+		{Title: "beta", Tags: []string{"Web"}},
+		{Title: "Alpha", Tags: []string{"api", "web"}},
+		{Title: "gamma", Tags: []string{"CLI"}},
+	}
+
+	// When & Then
+	assert.Equal(t, []string{"Alpha", "beta", "gamma"}, titlesOf(SortTasks(tasks, SortOrder{{Field: SortByTitle}})))
+	assert.Equal(t, []string{"Alpha", "gamma", "beta"}, titlesOf(SortTasks(tasks, SortOrder{{Field: SortByTag}})))
+}
+
+func TestSortTasks_ByUpdatedAndStatus_ShouldFollowDirection(t *testing.T) {
+	// Given
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []*model.Task{
+		{Title: "done", Status: model.StatusCompleted, UpdatedAt: base.Add(2 * time.Hour)},
+		{Title: "todo", Status: model.StatusTodo, UpdatedAt: base},
+		{Title: "doing", Status: model.StatusInProgress, UpdatedAt: base.Add(time.Hour)},
+	}
+
+	// When & Then
+	assert.Equal(t, []string{"done", "doing", "todo"}, titlesOf(SortTasks(tasks, SortOrder{{Field: SortByUpdated, Descending: true}})))
+	assert.Equal(t, []string{"todo", "doing", "done"}, titlesOf(SortTasks(tasks, SortOrder{{Field: SortByStatus}})))
+}
+
+func TestSortTasks_WithEmptyOrder_ShouldKeepOrder(t *testing.T) {
+	// Given
+	tasks := []*model.Task{{Title: "b"}, {Title: "a"}}
+
+	// When & Then
+	assert.Equal(t, []string{"b", "a"}, titlesOf(SortTasks(tasks, nil)))
+}
+
+func TestSortOrder_String_ShouldShowDirections(t *testing.T) {
+	assert.Equal(t, "priority ↓, due ↑, created ↑", DefaultSortOrder().String())
+}
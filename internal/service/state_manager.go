@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"task-cli/internal/model"
+	"task-cli/internal/query"
 )
 
 // TaskFilter はタスクのフィルタリング条件を定義
@@ -12,6 +13,10 @@ type TaskFilter struct {
 	Status   *model.Status
 	Priority *model.Priority
 	Query    string
+	Where    *query.Query // クエリ言語による条件（nilの場合は絞り込まない）
+
+	// ProjectID は表示するプロジェクトのID（空の場合は全てのプロジェクト）
+	ProjectID string
 }
 
 // SubscriberFunc は状態変更の通知を受け取る関数の型
@@ -22,6 +27,7 @@ type StateManager struct {
 	mu          sync.RWMutex
 	tasks       []*model.Task
 	filter      TaskFilter
+	sort        SortOrder
 	subscribers map[int]SubscriberFunc
 	nextID      int
 }
@@ -31,6 +37,7 @@ func NewStateManager() *StateManager {
 	return &StateManager{
 		tasks:       make([]*model.Task, 0),
 		filter:      TaskFilter{},
+		sort:        DefaultSortOrder(),
 		subscribers: make(map[int]SubscriberFunc),
 		nextID:      1,
 	}
@@ -42,14 +49,15 @@ func (sm *StateManager) SetTasks(tasks []*model.Task) {
 	sm.tasks = make([]*model.Task, len(tasks))
 	copy(sm.tasks, tasks)
 	currentFilter := sm.filter
+	order := sm.sort
 	subscribers := make(map[int]SubscriberFunc, len(sm.subscribers))
 	for id, sub := range sm.subscribers {
 		subscribers[id] = sub
 	}
 	sm.mu.Unlock()
 
-	// フィルターを適用
-	filteredTasks := sm.ApplyFilter(tasks, currentFilter)
+	// フィルターと並び順を適用
+	filteredTasks := SortTasks(sm.ApplyFilter(tasks, currentFilter), order)
 
 	// サブスクライバーに通知（ロックの外で実行）
 	for _, subscriber := range subscribers {
@@ -61,16 +69,43 @@ func (sm *StateManager) SetTasks(tasks []*model.Task) {
 func (sm *StateManager) SetFilter(filter TaskFilter) {
 	sm.mu.Lock()
 	sm.filter = filter
+	sm.mu.Unlock()
+
+	sm.notify()
+}
+
+// SetSort は並び順を設定し、サブスクライバーに通知する
+func (sm *StateManager) SetSort(order SortOrder) {
+	sm.mu.Lock()
+	sm.sort = append(SortOrder(nil), order...)
+	sm.mu.Unlock()
+
+	sm.notify()
+}
+
+// GetSort は現在の並び順を取得する
+func (sm *StateManager) GetSort() SortOrder {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return append(SortOrder(nil), sm.sort...)
+}
+
+// notify は現在のタスクにフィルターと並び順を適用してサブスクライバーに通知する
+func (sm *StateManager) notify() {
+	sm.mu.RLock()
 	currentTasks := make([]*model.Task, len(sm.tasks))
 	copy(currentTasks, sm.tasks)
+	filter := sm.filter
+	order := sm.sort
 	subscribers := make(map[int]SubscriberFunc, len(sm.subscribers))
 	for id, sub := range sm.subscribers {
 		subscribers[id] = sub
 	}
-	sm.mu.Unlock()
+	sm.mu.RUnlock()
 
-	// フィルターを適用
-	filteredTasks := sm.ApplyFilter(currentTasks, filter)
+	// フィルターと並び順を適用
+	filteredTasks := SortTasks(sm.ApplyFilter(currentTasks, filter), order)
 
 	// サブスクライバーに通知（ロックの外で実行）
 	for _, subscriber := range subscribers {
@@ -116,9 +151,21 @@ func (sm *StateManager) GetCurrentFilter() TaskFilter {
 
 // ApplyFilter はタスクリストにフィルターを適用する
 func (sm *StateManager) ApplyFilter(tasks []*model.Task, filter TaskFilter) []*model.Task {
+	return FilterTasks(tasks, filter)
+}
+
+// FilterTasks はタスクリストにフィルターを適用する
+// クエリのブロック状態などは tasks 全体から判定する
+func FilterTasks(tasks []*model.Task, filter TaskFilter) []*model.Task {
 	var filtered []*model.Task
+	data := &model.AppData{Tasks: tasks}
 
 	for _, task := range tasks {
+		// プロジェクトフィルター
+		if filter.ProjectID != "" && task.ProjectID != filter.ProjectID {
+			continue
+		}
+
 		// ステータスフィルター
 		if filter.Status != nil && task.Status != *filter.Status {
 			continue
@@ -140,21 +187,27 @@ func (sm *StateManager) ApplyFilter(tasks []*model.Task, filter TaskFilter) []*m
 			}
 		}
 
+		// クエリ言語による条件
+		if filter.Where != nil && !filter.Where.Match(task, data) {
+			continue
+		}
+
 		filtered = append(filtered, task)
 	}
 
 	return filtered
 }
 
-// GetFilteredTasks は現在のフィルターを適用したタスクリストを取得する
+// GetFilteredTasks は現在のフィルターと並び順を適用したタスクリストを取得する
 func (sm *StateManager) GetFilteredTasks() []*model.Task {
 	sm.mu.RLock()
 	tasks := make([]*model.Task, len(sm.tasks))
 	copy(tasks, sm.tasks)
 	filter := sm.filter
+	order := sm.sort
 	sm.mu.RUnlock()
 
-	return sm.ApplyFilter(tasks, filter)
+	return SortTasks(sm.ApplyFilter(tasks, filter), order)
 }
 
 // ClearFilter はフィルターをクリアする
@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"task-cli/internal/model"
+	"task-cli/internal/query"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // RED: StateManagerのテスト
@@ -237,4 +239,52 @@ func TestStateManager_ApplyFilter_ShouldReturnFilteredTasks(t *testing.T) {
 			assert.Len(t, filtered, tt.expected)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestStateManager_ApplyFilter_WithWhereQuery_ShouldEvaluateAgainstAllTasks(t *testing.T) {
+	// Given
+	stateManager := NewStateManager()
+	blocker, _ := model.NewTask("Design API", "", model.PriorityHigh, []string{"backend"})
+	blocked, _ := model.NewTask("Implement API", "", model.PriorityMedium, []string{"backend"})
+	blocked.BlockedBy = []string{blocker.ID}
+	docs, _ := model.NewTask("Write docs", "", model.PriorityLow, []string{"docs"})
+	where, err := query.Parse("tag:backend is:blocked OR priority<medium", time.Now())
+	require.NoError(t, err)
+
+	// When - ブロック元が条件に一致しなくてもブロック状態を判定できる
+	filtered := stateManager.ApplyFilter([]*model.Task{blocker, blocked, docs}, TaskFilter{Where: where})
+
+	// Then
+	assert.Equal(t, []*model.Task{blocked, docs}, filtered)
+}
+
+func TestStateManager_SetSort_ShouldNotifySortedTasks(t *testing.T) {
+	// Given
+	stateManager := NewStateManager()
+	stateManager.SetTasks([]*model.Task{
+		{ID: "1", Title: "beta", Priority: model.PriorityLow},
+		{ID: "2", Title: "Alpha", Priority: model.PriorityHigh},
+	})
+	received := make(chan []*model.Task, 1)
+	unsubscribe := stateManager.Subscribe(func(tasks []*model.Task, filter TaskFilter) {
+		received <- tasks
+	})
+	defer unsubscribe()
+
+	// When
+	stateManager.SetSort(SortOrder{{Field: SortByTitle, Descending: true}})
+
+	// Then
+	tasks := <-received
+	assert.Equal(t, "beta", tasks[0].Title)
+	assert.Equal(t, SortOrder{{Field: SortByTitle, Descending: true}}, stateManager.GetSort())
+	assert.Equal(t, "beta", stateManager.GetFilteredTasks()[0].Title)
+}
+
+func TestStateManager_New_ShouldUseDefaultSort(t *testing.T) {
+	// When
+	stateManager := NewStateManager()
+
+	// Then
+	assert.True(t, stateManager.GetSort().Equal(DefaultSortOrder()))
+}
@@ -15,6 +15,10 @@ import (
 type TaskService struct {
 	repo      repository.Repository
 	validator *validator.Validator
+	actor     string // 変更履歴に記録するユーザー名
+
+	// warningHandler は保存した後の付随する処理の失敗を知らせる関数（nil の場合は知らせない）
+	warningHandler func(error)
 }
 
 // CreateTaskRequest はタスク作成のリクエスト
@@ -24,6 +28,14 @@ type CreateTaskRequest struct {
 	Priority    model.Priority
 	Tags        []string
 	DueDate     *time.Time
+	ParentID    string            // 親タスクのID（空の場合は親を持たない）
+	BlockedBy   []string          // このタスクをブロックするタスクのID
+	Recurrence  *model.Recurrence // 繰り返しルール（nilの場合は繰り返さない）
+	Estimate    int               // 見積もり時間（分、0の場合は未設定）
+
+	// ProjectID は作成するプロジェクトのID（空の場合はサブタスクなら親のプロジェクト、それ以外は既定のプロジェクト）
+	// プロジェクトの既定のタグはタスクのタグに追加する
+	ProjectID string
 }
 
 // UpdateTaskRequest はタスク更新のリクエスト
@@ -35,13 +47,66 @@ type UpdateTaskRequest struct {
 	Status      model.Status
 	Tags        []string
 	DueDate     *time.Time
+
+	// ParentID は新しい親タスクのID（nilの場合は変更しない、空文字列の場合は親を外す）
+	ParentID *string
+
+	// BlockedBy はこのタスクをブロックするタスクのID（nilの場合は変更しない、空の場合はブロックを外す）
+	BlockedBy *[]string
+
+	// IgnoreBlockers が true の場合、未完了のタスクにブロックされていても完了にできる
+	IgnoreBlockers bool
+
+	// Recurrence は新しい繰り返しルール（nilの場合は変更しない）
+	Recurrence *model.Recurrence
+	// ClearRecurrence が true の場合は繰り返しルールを外す
+	ClearRecurrence bool
+
+	// Estimate は新しい見積もり時間（分、nilの場合は変更しない、0の場合は見積もりを外す）
+	Estimate *int
+
+	// ExpectedRevision は更新元として読み込んだタスクのリビジョン
+	// 0以外の場合、保存されているリビジョンと一致しなければConflictErrorを返す
+	ExpectedRevision int64
 }
 
+// CompleteOptions はタスクを完了にする際のオプション
+type CompleteOptions struct {
+	// IncludeSubtasks が true の場合は未完了のサブタスクもまとめて完了にする
+	IncludeSubtasks bool
+	// IgnoreBlockers が true の場合、未完了のタスクにブロックされていても完了にする
+	IgnoreBlockers bool
+}
+
+// ChildrenPolicy は親タスクを削除する際のサブタスクの扱いを定義
+type ChildrenPolicy int
+
+const (
+	// ChildrenPromote はサブタスクを削除するタスクの親に付け替えて残す
+	ChildrenPromote ChildrenPolicy = iota
+	// ChildrenDelete はサブタスクもまとめて削除する
+	ChildrenDelete
+)
+
 // NewTaskService は新しいTaskServiceを作成する
 func NewTaskService(repo repository.Repository, validator *validator.Validator) *TaskService {
 	return &TaskService{
 		repo:      repo,
 		validator: validator,
+		actor:     defaultActor(),
+	}
+}
+
+// SetWarningHandler は、タスクの保存には成功したが元に戻す履歴の記録など付随する処理に失敗した場合に呼ぶ関数を設定する
+// 操作自体は成功しているため、これらの失敗は操作のエラーとしては返さない
+func (s *TaskService) SetWarningHandler(handler func(error)) {
+	s.warningHandler = handler
+}
+
+// warn は警告を知らせる
+func (s *TaskService) warn(err error) {
+	if s.warningHandler != nil {
+		s.warningHandler(err)
 	}
 }
 
@@ -52,12 +117,6 @@ func (s *TaskService) CreateTask(ctx context.Context, request CreateTaskRequest)
 		return nil, errors.New("title is required")
 	}
 
-	// データを読み込み
-	appData, err := s.loadAppData(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load data: %w", err)
-	}
-
 	// 新しいタスクを作成
 	task, err := model.NewTask(request.Title, request.Description, request.Priority, request.Tags)
 	if err != nil {
@@ -68,20 +127,44 @@ func (s *TaskService) CreateTask(ctx context.Context, request CreateTaskRequest)
 	if request.DueDate != nil {
 		task.DueDate = request.DueDate
 	}
+	task.Recurrence = request.Recurrence
+	task.Estimate = request.Estimate
 
 	// バリデーション
 	if err := s.validator.ValidateTask(task); err != nil {
 		return nil, fmt.Errorf("task validation failed: %w", err)
 	}
 
-	// データに追加
-	if err := appData.AddTask(task); err != nil {
-		return nil, fmt.Errorf("failed to add task: %w", err)
-	}
-
-	// データを保存
-	if err := s.repo.Save(ctx, appData); err != nil {
-		return nil, fmt.Errorf("failed to save data: %w", err)
+	err = s.mutate(ctx, actionCreate, func(appData *model.AppData) error {
+		// 親タスクを設定
+		if err := appData.ValidateParent(task.ID, request.ParentID); err != nil {
+			return err
+		}
+		task.ParentID = request.ParentID
+
+		// プロジェクトを設定（既定のタグも追加する）
+		project, err := taskProject(appData, request.ProjectID, request.ParentID)
+		if err != nil {
+			return err
+		}
+		task.ProjectID = project.ID
+		task.Tags = mergeTags(project.DefaultTags, task.Tags)
+
+		// ブロックするタスクを設定
+		blockedBy := uniqueIDs(request.BlockedBy)
+		if err := appData.ValidateBlockers(task.ID, blockedBy); err != nil {
+			return err
+		}
+		task.BlockedBy = blockedBy
+
+		// データに追加
+		if err := appData.AddTask(task); err != nil {
+			return fmt.Errorf("failed to add task: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -89,18 +172,87 @@ func (s *TaskService) CreateTask(ctx context.Context, request CreateTaskRequest)
 
 // UpdateTask は既存のタスクを更新する
 func (s *TaskService) UpdateTask(ctx context.Context, request UpdateTaskRequest) (*model.Task, error) {
-	// データを読み込み
-	appData, err := s.loadAppData(ctx)
+	var tasks []*model.Task
+	err := s.mutate(ctx, actionUpdate, func(appData *model.AppData) error {
+		var err error
+		tasks, err = s.applyUpdates(appData, []UpdateTaskRequest{request})
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to load data: %w", err)
+		return nil, err
 	}
 
+	return tasks[0], nil
+}
+
+// UpdateTasks は複数のタスクをまとめて更新する
+// 変更前のデータをバックアップし、いずれかの更新が失敗した場合は何も保存しない
+func (s *TaskService) UpdateTasks(ctx context.Context, requests []UpdateTaskRequest) ([]*model.Task, error) {
+	var tasks []*model.Task
+	err := s.mutateWithBackup(ctx, actionUpdate, func(appData *model.AppData) error {
+		var err error
+		tasks, err = s.applyUpdates(appData, requests)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// applyUpdates は複数のリクエストを順に適用する
+// ブロックの確認は全ての更新の後に行うため、まとめて完了にするタスク同士のブロックは問題にならない
+// 完了にした繰り返しタスクは次の回を作成する
+func (s *TaskService) applyUpdates(appData *model.AppData, requests []UpdateTaskRequest) ([]*model.Task, error) {
+	tasks := make([]*model.Task, 0, len(requests))
+	var completed, checked []*model.Task
+	for _, request := range requests {
+		wasCompleted := false
+		if existingTask, err := appData.GetTaskByID(request.ID); err == nil {
+			wasCompleted = existingTask.IsCompleted()
+		}
+
+		task, err := s.applyUpdate(appData, request)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+
+		if !wasCompleted && task.IsCompleted() {
+			completed = append(completed, task)
+			if !request.IgnoreBlockers {
+				checked = append(checked, task)
+			}
+		}
+	}
+
+	if err := checkBlockers(appData, checked); err != nil {
+		return nil, err
+	}
+	if _, err := s.scheduleNextOccurrences(appData, completed); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// applyUpdate はリクエストの内容でAppData内のタスクを更新する
+func (s *TaskService) applyUpdate(appData *model.AppData, request UpdateTaskRequest) (*model.Task, error) {
 	// 既存のタスクを取得
 	existingTask, err := appData.GetTaskByID(request.ID)
 	if err != nil {
 		return nil, fmt.Errorf("task not found: %w", err)
 	}
 
+	// 読み込み後に別の操作で変更されていないか確認
+	if request.ExpectedRevision != 0 && request.ExpectedRevision != existingTask.Revision {
+		return nil, &ConflictError{
+			TaskID:           existingTask.ID,
+			ExpectedRevision: request.ExpectedRevision,
+			ActualRevision:   existingTask.Revision,
+		}
+	}
+
 	// タスクを更新
 	existingTask.Title = request.Title
 	existingTask.Description = request.Description
@@ -110,6 +262,38 @@ func (s *TaskService) UpdateTask(ctx context.Context, request UpdateTaskRequest)
 	existingTask.DueDate = request.DueDate
 	existingTask.UpdatedAt = time.Now()
 
+	// 親タスクの変更（循環しないことを確認する）
+	if request.ParentID != nil {
+		if err := appData.ValidateParent(existingTask.ID, *request.ParentID); err != nil {
+			return nil, err
+		}
+		if parent, err := appData.GetTaskByID(*request.ParentID); err == nil && parent.ProjectID != existingTask.ProjectID {
+			return nil, ErrProjectMismatch
+		}
+		existingTask.ParentID = *request.ParentID
+	}
+
+	// ブロックするタスクの変更（依存関係が循環しないことを確認する）
+	if request.BlockedBy != nil {
+		blockedBy := uniqueIDs(*request.BlockedBy)
+		if err := appData.ValidateBlockers(existingTask.ID, blockedBy); err != nil {
+			return nil, err
+		}
+		existingTask.BlockedBy = blockedBy
+	}
+
+	// 繰り返しルールの変更
+	if request.ClearRecurrence {
+		existingTask.Recurrence = nil
+	} else if request.Recurrence != nil {
+		existingTask.Recurrence = request.Recurrence
+	}
+
+	// 見積もり時間の変更
+	if request.Estimate != nil {
+		existingTask.Estimate = *request.Estimate
+	}
+
 	// ステータスが完了に変更された場合、完了日時を設定
 	if request.Status == model.StatusCompleted && existingTask.CompletedAt == nil {
 		now := time.Now()
@@ -125,81 +309,180 @@ func (s *TaskService) UpdateTask(ctx context.Context, request UpdateTaskRequest)
 	if err := appData.UpdateTask(existingTask); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
-
-	// データを保存
-	if err := s.repo.Save(ctx, appData); err != nil {
-		return nil, fmt.Errorf("failed to save data: %w", err)
-	}
-
 	return existingTask, nil
 }
 
 // DeleteTask はタスクを削除する
+// サブタスクは削除するタスクの親に付け替えて残し、削除前のデータをバックアップする
+// 削除したタスクによるブロックは他のタスクから取り除かれる
 func (s *TaskService) DeleteTask(ctx context.Context, taskID string) error {
-	// データを読み込み
-	appData, err := s.loadAppData(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to load data: %w", err)
-	}
+	return s.DeleteTasks(ctx, taskID)
+}
 
-	// タスクが存在するか確認
-	_, err = appData.GetTaskByID(taskID)
-	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
-	}
+// DeleteTasks は複数のタスクをまとめて削除する
+// サブタスクは削除するタスクの親に付け替えて残す
+// 削除前のデータをバックアップし、存在しないタスクが含まれる場合は何も削除しない
+func (s *TaskService) DeleteTasks(ctx context.Context, taskIDs ...string) error {
+	return s.DeleteTasksWithPolicy(ctx, ChildrenPromote, taskIDs...)
+}
 
-	// タスクを削除
-	if err := appData.DeleteTask(taskID); err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
-	}
+// DeleteTasksWithPolicy は指定したサブタスクの扱いで複数のタスクをまとめて削除する
+// 削除前のデータをバックアップし、存在しないタスクが含まれる場合は何も削除しない
+func (s *TaskService) DeleteTasksWithPolicy(ctx context.Context, policy ChildrenPolicy, taskIDs ...string) error {
+	return s.mutateWithBackup(ctx, actionDelete, func(appData *model.AppData) error {
+		// タスクが存在するか確認
+		for _, taskID := range taskIDs {
+			if _, err := appData.GetTaskByID(taskID); err != nil {
+				return fmt.Errorf("task not found: %w", err)
+			}
+		}
+
+		for _, taskID := range taskIDs {
+			// サブタスクと一緒に既に削除されている
+			task, err := appData.GetTaskByID(taskID)
+			if err != nil {
+				continue
+			}
+
+			if err := s.detachChildren(appData, task, policy); err != nil {
+				return err
+			}
+
+			// タスクを削除
+			if err := appData.DeleteTask(taskID); err != nil {
+				return fmt.Errorf("failed to delete task: %w", err)
+			}
+		}
+
+		// 削除したタスクによるブロックを取り除く
+		for _, task := range appData.RemoveMissingBlockers() {
+			task.UpdatedAt = time.Now()
+			if err := appData.UpdateTask(task); err != nil {
+				return fmt.Errorf("failed to update blocked task: %w", err)
+			}
+		}
+		return nil
+	})
+}
 
-	// データを保存
-	if err := s.repo.Save(ctx, appData); err != nil {
-		return fmt.Errorf("failed to save data: %w", err)
+// detachChildren は削除するタスクのサブタスクを方針に従って付け替えまたは削除する
+func (s *TaskService) detachChildren(appData *model.AppData, task *model.Task, policy ChildrenPolicy) error {
+	switch policy {
+	case ChildrenDelete:
+		for _, descendant := range appData.GetDescendants(task.ID) {
+			if err := appData.DeleteTask(descendant.ID); err != nil {
+				return fmt.Errorf("failed to delete subtask: %w", err)
+			}
+		}
+	default:
+		for _, child := range appData.GetChildren(task.ID) {
+			child.ParentID = task.ParentID
+			child.UpdatedAt = time.Now()
+			if err := appData.UpdateTask(child); err != nil {
+				return fmt.Errorf("failed to update subtask: %w", err)
+			}
+		}
 	}
-
 	return nil
 }
 
-// ToggleTaskStatus はタスクのステータスを切り替える
-func (s *TaskService) ToggleTaskStatus(ctx context.Context, taskID string) (*model.Task, error) {
-	// データを読み込み
-	appData, err := s.loadAppData(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load data: %w", err)
-	}
-
-	// 既存のタスクを取得
-	task, err := appData.GetTaskByID(taskID)
-	if err != nil {
-		return nil, fmt.Errorf("task not found: %w", err)
-	}
+// CompleteTask はタスクを完了にする
+// 未完了のタスクにブロックされている場合は、IgnoreBlockers を指定しない限りBlockedErrorを返す
+func (s *TaskService) CompleteTask(ctx context.Context, taskID string, options CompleteOptions) (*model.Task, error) {
+	var task *model.Task
+	err := s.mutate(ctx, actionComplete, func(appData *model.AppData) error {
+		var err error
+		task, err = appData.GetTaskByID(taskID)
+		if err != nil {
+			return fmt.Errorf("task not found: %w", err)
+		}
+
+		targets := []*model.Task{task}
+		if options.IncludeSubtasks {
+			targets = append(targets, appData.GetDescendants(taskID)...)
+		}
 
-	// ステータスを切り替え
-	switch task.Status {
-	case model.StatusTodo:
-		task.Status = model.StatusCompleted
-		now := time.Now()
-		task.CompletedAt = &now
-	case model.StatusInProgress:
-		task.Status = model.StatusCompleted
 		now := time.Now()
-		task.CompletedAt = &now
-	case model.StatusCompleted:
-		task.Status = model.StatusTodo
-		task.CompletedAt = nil
+		var completed []*model.Task
+		for _, target := range targets {
+			if target.IsCompleted() {
+				continue
+			}
+			completed = append(completed, target)
+			target.Status = model.StatusCompleted
+			completedAt := now
+			target.CompletedAt = &completedAt
+			target.UpdatedAt = now
+			if err := appData.UpdateTask(target); err != nil {
+				return fmt.Errorf("failed to update task: %w", err)
+			}
+		}
+
+		if !options.IgnoreBlockers {
+			if err := checkBlockers(appData, completed); err != nil {
+				return err
+			}
+		}
+		_, err = s.scheduleNextOccurrences(appData, completed)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	task.UpdatedAt = time.Now()
-
-	// データを更新
-	if err := appData.UpdateTask(task); err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
-	}
+	return task, nil
+}
 
-	// データを保存
-	if err := s.repo.Save(ctx, appData); err != nil {
-		return nil, fmt.Errorf("failed to save data: %w", err)
+// ToggleTaskStatus はタスクのステータスを切り替える
+// 未完了のタスクにブロックされているタスクは完了にできずBlockedErrorを返す
+func (s *TaskService) ToggleTaskStatus(ctx context.Context, taskID string) (*model.Task, error) {
+	var task *model.Task
+	err := s.mutate(ctx, actionToggle, func(appData *model.AppData) error {
+		// 既存のタスクを取得
+		var err error
+		task, err = appData.GetTaskByID(taskID)
+		if err != nil {
+			return fmt.Errorf("task not found: %w", err)
+		}
+
+		// ステータスを切り替え
+		switch task.Status {
+		case model.StatusTodo:
+			task.Status = model.StatusCompleted
+			now := time.Now()
+			task.CompletedAt = &now
+		case model.StatusInProgress:
+			task.Status = model.StatusCompleted
+			now := time.Now()
+			task.CompletedAt = &now
+		case model.StatusCompleted:
+			task.Status = model.StatusTodo
+			task.CompletedAt = nil
+		}
+
+		task.UpdatedAt = time.Now()
+
+		if task.IsCompleted() {
+			if err := checkBlockers(appData, []*model.Task{task}); err != nil {
+				return err
+			}
+		}
+
+		// データを更新
+		if err := appData.UpdateTask(task); err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+
+		// 繰り返しタスクを完了にした場合は次の回を作成する
+		if task.IsCompleted() {
+			if _, err := s.scheduleNextOccurrences(appData, []*model.Task{task}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -260,12 +543,128 @@ func (s *TaskService) GetTasksByPriority(ctx context.Context, priority model.Pri
 	return appData.GetTasksByPriority(priority), nil
 }
 
-// loadAppData はAppDataを読み込み、存在しない場合は新しいインスタンスを作成する
+// ListBackups は利用可能なバックアップを新しい順に返す
+func (s *TaskService) ListBackups(ctx context.Context) ([]repository.BackupInfo, error) {
+	return s.repo.ListBackups(ctx)
+}
+
+// RestoreLatestBackup は最新のバックアップからデータを復元して保存し、使用したバックアップを返す
+// データファイルが破損している場合の復旧に使用する
+func (s *TaskService) RestoreLatestBackup(ctx context.Context) (*repository.BackupInfo, error) {
+	backup, err := s.FindBackup(ctx, LatestBackup)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.restoreBackup(ctx, *backup)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Restored, nil
+}
+
+// mutate はデータディレクトリのロックを保持したまま、読み込み・変更・保存を行う
+// fn がエラーを返した場合は保存しない
+// 変更したフィールドはタスクの変更履歴に追加し、保存した変更は action の操作として元に戻せるように記録する
+func (s *TaskService) mutate(ctx context.Context, action string, fn func(appData *model.AppData) error) error {
+	return s.mutateData(ctx, false, action, fn)
+}
+
+// mutateWithBackup は削除や一括変更などの破壊的な操作を mutate と同様に処理し、保存の前に変更前のデータをバックアップする
+func (s *TaskService) mutateWithBackup(ctx context.Context, action string, fn func(appData *model.AppData) error) error {
+	return s.mutateData(ctx, true, action, fn)
+}
+
+// mutateData は mutate と mutateWithBackup の共通処理
+func (s *TaskService) mutateData(ctx context.Context, backup bool, action string, fn func(appData *model.AppData) error) error {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// データを読み込み
+	appData, err := s.loadAppData(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load data: %w", err)
+	}
+
+	// fn はタスクをその場で書き換えるため、変更前のデータを残して複製に適用する
+	changed := appData.Clone()
+	if err := fn(changed); err != nil {
+		return err
+	}
+	now := time.Now()
+	s.recordChanges(appData.Tasks, changed.Tasks, model.ChangeCreated, model.ChangeUpdated, now)
+
+	// 変更できた場合だけ、保存の直前に変更前のデータをバックアップする（失われて困るタスクがない場合はバックアップしない）
+	if backup && len(appData.Tasks) > 0 {
+		if _, err := s.repo.CreateBackup(ctx, appData); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+
+	// データを保存
+	if err := s.repo.Save(ctx, changed); err != nil {
+		return fmt.Errorf("failed to save data: %w", err)
+	}
+	// 保存した後は、履歴や削除を記録できなくても操作は成功として扱う（失敗として返すと再試行でタスクが重複する）
+	if err := s.recordOperation(ctx, newOperation(action, appData.Tasks, changed.Tasks, now)); err != nil {
+		s.warn(err)
+	}
+	if err := s.recordDeletions(ctx, appData.Tasks, changed.Tasks, now); err != nil {
+		s.warn(err)
+	}
+	return nil
+}
+
+// lock はRepositoryがLockerを実装している場合にロックを取得する
+func (s *TaskService) lock(ctx context.Context) (func() error, error) {
+	locker, ok := s.repo.(repository.Locker)
+	if !ok {
+		return func() error { return nil }, nil
+	}
+
+	unlock, err := locker.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return unlock, nil
+}
+
+// loadAppData はAppDataを読み込み、データファイルが存在しない場合のみ新しいインスタンスを作成する
+// 破損などその他のエラーはそのまま返し、既存のファイルを空のデータで上書きしないようにする
 func (s *TaskService) loadAppData(ctx context.Context) (*model.AppData, error) {
 	appData, err := s.repo.Load(ctx)
-	if err != nil {
-		// データが存在しない場合は新しいインスタンスを作成
+	if errors.Is(err, repository.ErrNotFound) {
 		return model.NewAppData(), nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	return appData, nil
-}
\ No newline at end of file
+}
+
+// checkBlockers は完了にしたタスクが未完了のタスクにブロックされていないか確認する
+func checkBlockers(appData *model.AppData, completed []*model.Task) error {
+	for _, task := range completed {
+		if blockers := appData.GetOpenBlockers(task.ID); len(blockers) > 0 {
+			return &BlockedError{TaskID: task.ID, Blockers: blockers}
+		}
+	}
+	return nil
+}
+
+// uniqueIDs は重複と空文字列を除いたIDのリストを返す
+func uniqueIDs(ids []string) []string {
+	var unique []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
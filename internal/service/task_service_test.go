@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"task-cli/internal/model"
+	"task-cli/internal/repository"
 	"task-cli/internal/validator"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockRepository はRepositoryのモック実装
@@ -36,6 +41,11 @@ func (m *MockRepository) RestoreFromBackup(ctx context.Context, backupPath strin
 	return args.Get(0).(*model.AppData), args.Error(1)
 }
 
+func (m *MockRepository) ListBackups(ctx context.Context) ([]repository.BackupInfo, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]repository.BackupInfo), args.Error(1)
+}
+
 // RED: TaskServiceのテスト
 func TestTaskService_New_ShouldCreateService(t *testing.T) {
 	// Given
@@ -141,6 +151,71 @@ func TestTaskService_UpdateTask_WithValidData_ShouldUpdateTask(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTaskService_UpdateTask_WithMatchingRevision_ShouldIncrementRevision(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	appData := model.NewAppData()
+	existingTask, _ := model.NewTask("Title", "", model.PriorityLow, nil)
+	appData.AddTask(existingTask)
+
+	mockRepo.On("Load", ctx).Return(appData, nil)
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*model.AppData")).Return(nil)
+
+	request := UpdateTaskRequest{
+		ID:               existingTask.ID,
+		Title:            "Updated Title",
+		Priority:         model.PriorityLow,
+		Status:           model.StatusTodo,
+		ExpectedRevision: 1,
+	}
+
+	// When
+	task, err := service.UpdateTask(ctx, request)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), task.Revision)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaskService_UpdateTask_WithStaleRevision_ShouldReturnConflictWithoutSaving(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	appData := model.NewAppData()
+	existingTask, _ := model.NewTask("Changed elsewhere", "", model.PriorityLow, nil)
+	existingTask.Revision = 3
+	appData.AddTask(existingTask)
+
+	mockRepo.On("Load", ctx).Return(appData, nil)
+
+	request := UpdateTaskRequest{
+		ID:               existingTask.ID,
+		Title:            "My stale edit",
+		Priority:         model.PriorityLow,
+		Status:           model.StatusTodo,
+		ExpectedRevision: 2,
+	}
+
+	// When
+	task, err := service.UpdateTask(ctx, request)
+
+	// Then
+	assert.Nil(t, task)
+	assert.ErrorIs(t, err, ErrConflict)
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, int64(2), conflict.ExpectedRevision)
+	assert.Equal(t, int64(3), conflict.ActualRevision)
+	assert.Equal(t, "Changed elsewhere", existingTask.Title)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
 func TestTaskService_UpdateTask_WithNonexistentID_ShouldReturnError(t *testing.T) {
 	// Given
 	mockRepo := &MockRepository{}
@@ -183,6 +258,7 @@ func TestTaskService_DeleteTask_WithValidID_ShouldRemoveTask(t *testing.T) {
 	appData.AddTask(existingTask)
 
 	mockRepo.On("Load", ctx).Return(appData, nil)
+	mockRepo.On("CreateBackup", ctx, appData).Return("backup.json", nil)
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*model.AppData")).Return(nil)
 
 	// When
@@ -193,6 +269,86 @@ func TestTaskService_DeleteTask_WithValidID_ShouldRemoveTask(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTaskService_DeleteTask_WhenBackupFails_ShouldNotDelete(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	appData := model.NewAppData()
+	existingTask, _ := model.NewTask("Keep me", "", model.PriorityMedium, nil)
+	appData.AddTask(existingTask)
+
+	mockRepo.On("Load", ctx).Return(appData, nil)
+	mockRepo.On("CreateBackup", ctx, appData).Return("", errors.New("disk full"))
+
+	// When
+	err := service.DeleteTask(ctx, existingTask.ID)
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create backup")
+	assert.Len(t, appData.Tasks, 1)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_DeleteTasks_WithUnknownID_ShouldDeleteNothing(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	appData := model.NewAppData()
+	existingTask, _ := model.NewTask("Keep me", "", model.PriorityMedium, nil)
+	appData.AddTask(existingTask)
+
+	mockRepo.On("Load", ctx).Return(appData, nil)
+
+	// When
+	err := service.DeleteTasks(ctx, existingTask.ID, "missing-id")
+
+	// Then - 変更できなかった場合はバックアップも作成しない
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "task not found")
+	assert.Len(t, appData.Tasks, 1)
+	mockRepo.AssertNotCalled(t, "CreateBackup", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_UpdateTasks_ShouldBackupOnceAndSaveOnce(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	appData := model.NewAppData()
+	first, _ := model.NewTask("First", "", model.PriorityLow, nil)
+	second, _ := model.NewTask("Second", "", model.PriorityLow, nil)
+	appData.AddTask(first)
+	appData.AddTask(second)
+
+	mockRepo.On("Load", ctx).Return(appData, nil)
+	mockRepo.On("CreateBackup", ctx, appData).Return("backup.json", nil).Once()
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*model.AppData")).Return(nil).Once()
+
+	requests := []UpdateTaskRequest{
+		{ID: first.ID, Title: "First", Priority: model.PriorityLow, Status: model.StatusCompleted},
+		{ID: second.ID, Title: "Second", Priority: model.PriorityLow, Status: model.StatusCompleted},
+	}
+
+	// When
+	tasks, err := service.UpdateTasks(ctx, requests)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, model.StatusCompleted, tasks[0].Status)
+	assert.Equal(t, model.StatusCompleted, tasks[1].Status)
+	// バックアップには変更前のデータを渡す
+	assert.Equal(t, model.StatusTodo, appData.Tasks[0].Status)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestTaskService_ToggleTask_ShouldChangeStatus(t *testing.T) {
 	// Given
 	mockRepo := &MockRepository{}
@@ -266,4 +422,395 @@ func TestTaskService_SearchTasks_WithQuery_ShouldReturnMatchingTasks(t *testing.
 	assert.Len(t, results, 1)
 	assert.Equal(t, "Buy groceries", results[0].Title)
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestTaskService_CreateTask_WithMissingDataFile_ShouldStartFresh(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	mockRepo.On("Load", ctx).Return((*model.AppData)(nil), repository.ErrNotFound)
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*model.AppData")).Return(nil)
+
+	// When
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "First", Priority: model.PriorityLow})
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotNil(t, task)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaskService_CreateTask_WithCorruptDataFile_ShouldNotSave(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	corruptErr := &repository.CorruptError{Path: "tasks.json", Err: assert.AnError}
+	mockRepo.On("Load", ctx).Return((*model.AppData)(nil), corruptErr)
+
+	// When
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "First", Priority: model.PriorityLow})
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, task)
+	assert.ErrorIs(t, err, repository.ErrCorrupt)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestTaskService_RestoreLatestBackup_ShouldSaveNewestBackup(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	backups := []repository.BackupInfo{
+		{Name: "tasks_backup_new.json", Path: "/backups/tasks_backup_new.json"},
+		{Name: "tasks_backup_old.json", Path: "/backups/tasks_backup_old.json"},
+	}
+	restored := model.NewAppData()
+	mockRepo.On("ListBackups", ctx).Return(backups, nil)
+	mockRepo.On("RestoreFromBackup", ctx, "/backups/tasks_backup_new.json").Return(restored, nil)
+	mockRepo.On("Load", ctx).Return((*model.AppData)(nil), &repository.CorruptError{Path: "tasks.json"})
+	mockRepo.On("Save", ctx, restored).Return(nil)
+
+	// When
+	info, err := service.RestoreLatestBackup(ctx)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "tasks_backup_new.json", info.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaskService_RestoreLatestBackup_WithoutBackups_ShouldReturnErrNoBackups(t *testing.T) {
+	// Given
+	mockRepo := &MockRepository{}
+	service := NewTaskService(mockRepo, validator.New())
+	ctx := context.Background()
+
+	mockRepo.On("ListBackups", ctx).Return([]repository.BackupInfo{}, nil)
+
+	// When
+	_, err := service.RestoreLatestBackup(ctx)
+
+	// Then
+	assert.ErrorIs(t, err, repository.ErrNoBackups)
+}
+
+func TestTaskService_CreateTask_FromConcurrentServices_ShouldNotLoseTasks(t *testing.T) {
+	// Given
+	dataDir := t.TempDir()
+	ctx := context.Background()
+	const writers = 8
+
+	// When - 別プロセスを想定し、それぞれ独立したRepositoryとServiceで同時に書き込む
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			service := NewTaskService(repository.NewFileRepository(dataDir), validator.New())
+			_, err := service.CreateTask(ctx, CreateTaskRequest{
+				Title:    fmt.Sprintf("Task %d", i),
+				Priority: model.PriorityMedium,
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	// Then
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	tasks, err := NewTaskService(repository.NewFileRepository(dataDir), validator.New()).GetAllTasks(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, writers)
+}
+
+// createSubtaskTree は parent > child > grandchild の階層を作成する
+func createSubtaskTree(t *testing.T, service *TaskService) (parent, child, grandchild *model.Task) {
+	t.Helper()
+	ctx := context.Background()
+
+	parent, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Parent", Priority: model.PriorityMedium})
+	require.NoError(t, err)
+	child, err = service.CreateTask(ctx, CreateTaskRequest{Title: "Child", Priority: model.PriorityMedium, ParentID: parent.ID})
+	require.NoError(t, err)
+	grandchild, err = service.CreateTask(ctx, CreateTaskRequest{Title: "Grandchild", Priority: model.PriorityMedium, ParentID: child.ID})
+	require.NoError(t, err)
+	return parent, child, grandchild
+}
+
+func TestTaskService_CreateTask_WithUnknownParent_ShouldReturnError(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+
+	// When
+	_, err := service.CreateTask(context.Background(), CreateTaskRequest{
+		Title: "Orphan", Priority: model.PriorityLow, ParentID: "missing",
+	})
+
+	// Then
+	assert.ErrorIs(t, err, model.ErrInvalidParent)
+}
+
+func TestTaskService_UpdateTask_MovingUnderOwnSubtask_ShouldReturnError(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	parent, _, grandchild := createSubtaskTree(t, service)
+
+	request := UpdateTaskRequest{
+		ID: parent.ID, Title: parent.Title, Priority: parent.Priority, Status: parent.Status,
+		ParentID: &grandchild.ID,
+	}
+
+	// When
+	_, err := service.UpdateTask(ctx, request)
+
+	// Then
+	assert.ErrorIs(t, err, model.ErrInvalidParent)
+}
+
+func TestTaskService_UpdateTask_WithoutParentID_ShouldKeepParent(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	parent, child, _ := createSubtaskTree(t, service)
+
+	// When
+	updated, err := service.UpdateTask(ctx, UpdateTaskRequest{
+		ID: child.ID, Title: "Renamed", Priority: child.Priority, Status: child.Status,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, updated.ParentID)
+}
+
+func TestTaskService_CompleteTask_WithSubtasks_ShouldCompleteDescendants(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	parent, child, grandchild := createSubtaskTree(t, service)
+
+	// When
+	_, err := service.CompleteTask(ctx, parent.ID, CompleteOptions{IncludeSubtasks: true})
+
+	// Then
+	require.NoError(t, err)
+	for _, id := range []string{parent.ID, child.ID, grandchild.ID} {
+		task, err := service.GetTaskByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusCompleted, task.Status, task.Title)
+		assert.NotNil(t, task.CompletedAt)
+	}
+}
+
+func TestTaskService_CompleteTask_WithoutSubtasks_ShouldLeaveChildrenOpen(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	parent, child, _ := createSubtaskTree(t, service)
+
+	// When
+	_, err := service.CompleteTask(ctx, parent.ID, CompleteOptions{})
+
+	// Then
+	require.NoError(t, err)
+	task, err := service.GetTaskByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusTodo, task.Status)
+}
+
+func TestTaskService_DeleteTask_ShouldPromoteChildrenToGrandparent(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	parent, child, grandchild := createSubtaskTree(t, service)
+
+	// When
+	err := service.DeleteTask(ctx, child.ID)
+
+	// Then
+	require.NoError(t, err)
+	promoted, err := service.GetTaskByID(ctx, grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, promoted.ParentID)
+}
+
+func TestTaskService_DeleteTasksWithPolicy_Delete_ShouldRemoveWholeSubtree(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	parent, child, _ := createSubtaskTree(t, service)
+	other, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Other", Priority: model.PriorityLow})
+	require.NoError(t, err)
+
+	// When - 明示的に指定したサブタスクも重複して削除しようとしない
+	err = service.DeleteTasksWithPolicy(ctx, ChildrenDelete, parent.ID, child.ID)
+
+	// Then
+	require.NoError(t, err)
+	tasks, err := service.GetAllTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, other.ID, tasks[0].ID)
+}
+
+// createBlockedTasks は blocker にブロックされた blocked タスクを作成する
+func createBlockedTasks(t *testing.T, service *TaskService) (blocker, blocked *model.Task) {
+	t.Helper()
+	ctx := context.Background()
+
+	blocker, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Blocker", Priority: model.PriorityMedium})
+	require.NoError(t, err)
+	blocked, err = service.CreateTask(ctx, CreateTaskRequest{
+		Title: "Blocked", Priority: model.PriorityMedium, BlockedBy: []string{blocker.ID, blocker.ID},
+	})
+	require.NoError(t, err)
+	return blocker, blocked
+}
+
+func TestTaskService_CreateTask_WithBlockedBy_ShouldStoreUniqueBlockers(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+
+	// When
+	blocker, blocked := createBlockedTasks(t, service)
+
+	// Then
+	stored, err := service.GetTaskByID(context.Background(), blocked.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{blocker.ID}, stored.BlockedBy)
+}
+
+func TestTaskService_UpdateTask_WithDependencyCycle_ShouldReturnError(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	blocker, blocked := createBlockedTasks(t, service)
+	blockedBy := []string{blocked.ID}
+
+	// When
+	_, err := service.UpdateTask(context.Background(), UpdateTaskRequest{
+		ID: blocker.ID, Title: blocker.Title, Priority: blocker.Priority, Status: blocker.Status,
+		BlockedBy: &blockedBy,
+	})
+
+	// Then
+	assert.ErrorIs(t, err, model.ErrDependencyCycle)
+	stored, _ := service.GetTaskByID(context.Background(), blocker.ID)
+	assert.Empty(t, stored.BlockedBy)
+}
+
+func TestTaskService_UpdateTask_CompletingBlockedTask_ShouldReturnBlockedError(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	blocker, blocked := createBlockedTasks(t, service)
+	request := UpdateTaskRequest{
+		ID: blocked.ID, Title: blocked.Title, Priority: blocked.Priority, Status: model.StatusCompleted,
+	}
+
+	// When
+	_, err := service.UpdateTask(ctx, request)
+
+	// Then
+	var blockedErr *BlockedError
+	require.ErrorAs(t, err, &blockedErr)
+	assert.ErrorIs(t, err, ErrBlocked)
+	assert.Equal(t, blocked.ID, blockedErr.TaskID)
+	require.Len(t, blockedErr.Blockers, 1)
+	assert.Equal(t, blocker.ID, blockedErr.Blockers[0].ID)
+
+	stored, _ := service.GetTaskByID(ctx, blocked.ID)
+	assert.Equal(t, model.StatusTodo, stored.Status)
+}
+
+func TestTaskService_UpdateTask_CompletingBlockedTaskWithIgnoreBlockers_ShouldComplete(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	_, blocked := createBlockedTasks(t, service)
+
+	// When
+	updated, err := service.UpdateTask(context.Background(), UpdateTaskRequest{
+		ID: blocked.ID, Title: blocked.Title, Priority: blocked.Priority, Status: model.StatusCompleted,
+		IgnoreBlockers: true,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusCompleted, updated.Status)
+}
+
+func TestTaskService_UpdateTasks_CompletingBlockerTogether_ShouldSucceed(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	blocker, blocked := createBlockedTasks(t, service)
+
+	// When - ブロックされているタスクを先に指定しても、まとめて完了にできる
+	_, err := service.UpdateTasks(context.Background(), []UpdateTaskRequest{
+		{ID: blocked.ID, Title: blocked.Title, Priority: blocked.Priority, Status: model.StatusCompleted},
+		{ID: blocker.ID, Title: blocker.Title, Priority: blocker.Priority, Status: model.StatusCompleted},
+	})
+
+	// Then
+	assert.NoError(t, err)
+}
+
+func TestTaskService_ToggleTaskStatus_WithOpenBlocker_ShouldReturnBlockedError(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	blocker, blocked := createBlockedTasks(t, service)
+
+	// When
+	_, blockedErr := service.ToggleTaskStatus(ctx, blocked.ID)
+	_, err := service.ToggleTaskStatus(ctx, blocker.ID)
+	require.NoError(t, err)
+	_, unblockedErr := service.ToggleTaskStatus(ctx, blocked.ID)
+
+	// Then
+	assert.ErrorIs(t, blockedErr, ErrBlocked)
+	assert.NoError(t, unblockedErr)
+}
+
+func TestTaskService_CompleteTask_WithOpenBlocker_ShouldRespectIgnoreBlockers(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	_, blocked := createBlockedTasks(t, service)
+
+	// When
+	_, refused := service.CompleteTask(ctx, blocked.ID, CompleteOptions{})
+	forced, err := service.CompleteTask(ctx, blocked.ID, CompleteOptions{IgnoreBlockers: true})
+
+	// Then
+	assert.ErrorIs(t, refused, ErrBlocked)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusCompleted, forced.Status)
+}
+
+func TestTaskService_DeleteTask_ShouldRemoveDeletedBlocker(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	blocker, blocked := createBlockedTasks(t, service)
+
+	// When
+	err := service.DeleteTask(ctx, blocker.ID)
+
+	// Then
+	require.NoError(t, err)
+	stored, err := service.GetTaskByID(ctx, blocked.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.BlockedBy)
+	assert.Greater(t, stored.Revision, blocked.Revision)
+}
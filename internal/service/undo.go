@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"task-cli/internal/model"
+	"task-cli/internal/repository"
+)
+
+// maxUndoOperations は元に戻せるように記録しておく操作の最大数
+const maxUndoOperations = 100
+
+// 記録する操作の種類（操作の説明の先頭に使用する）
+const (
+	actionCreate   = "create"
+	actionUpdate   = "update"
+	actionDelete   = "delete"
+	actionComplete = "complete"
+	actionToggle   = "toggle"
+)
+
+// Undo は最後に記録した操作を元に戻し、その操作を返す
+// 操作の後に対象のタスクが別の操作で変更されている場合は何も変更せずにConflictErrorを返す
+func (s *TaskService) Undo(ctx context.Context) (*model.Operation, error) {
+	return s.stepHistory(ctx, true)
+}
+
+// Redo は最後に元に戻した操作をやり直し、その操作を返す
+// 元に戻した後に対象のタスクが別の操作で変更されている場合は何も変更せずにConflictErrorを返す
+func (s *TaskService) Redo(ctx context.Context) (*model.Operation, error) {
+	return s.stepHistory(ctx, false)
+}
+
+// GetHistory は元に戻せる操作とやり直せる操作の履歴を取得する
+// Repositoryが履歴の保存に対応していない場合は空の履歴を返す
+func (s *TaskService) GetHistory(ctx context.Context) (*model.History, error) {
+	historyRepo, ok := s.repo.(repository.HistoryRepository)
+	if !ok {
+		return model.NewHistory(), nil
+	}
+	return historyRepo.LoadHistory(ctx)
+}
+
+// stepHistory は undo が true の場合は元に戻し、false の場合はやり直す
+func (s *TaskService) stepHistory(ctx context.Context, undo bool) (*model.Operation, error) {
+	verb, empty, action := "undo", ErrNothingToUndo, model.ChangeUndone
+	if !undo {
+		verb, empty, action = "redo", ErrNothingToRedo, model.ChangeRedone
+	}
+
+	historyRepo, ok := s.repo.(repository.HistoryRepository)
+	if !ok {
+		return nil, empty
+	}
+
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	history, err := historyRepo.LoadHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stack := &history.Undo
+	if !undo {
+		stack = &history.Redo
+	}
+	if len(*stack) == 0 {
+		return nil, empty
+	}
+	operation := (*stack)[len(*stack)-1]
+
+	appData, err := s.loadAppData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %w", err)
+	}
+
+	// 元に戻す場合は変更後から変更前に、やり直す場合は変更前から変更後に置き換える
+	from, to := operation.After, operation.Before
+	if !undo {
+		from, to = operation.Before, operation.After
+	}
+	now := time.Now()
+	before := cloneTasks(appData.Tasks)
+	written, err := applyOperation(appData, from, to, now)
+	if err != nil {
+		return nil, fmt.Errorf("cannot %s %s: %w", verb, operation.Description, err)
+	}
+	s.recordChanges(before, appData.Tasks, action, action, now)
+	// 再び追加し直す場合に備えて、記録する状態にも追加した変更履歴を含める
+	for _, task := range written {
+		if current, err := appData.GetTaskByID(task.ID); err == nil {
+			task.Changes = current.Changes
+		}
+	}
+
+	if err := s.repo.Save(ctx, appData); err != nil {
+		return nil, fmt.Errorf("failed to save data: %w", err)
+	}
+	// 作成を元に戻した場合など、タスクを削除した場合は削除を記録する
+	if err := s.recordDeletions(ctx, before, appData.Tasks, now); err != nil {
+		s.warn(err)
+	}
+
+	// 反対側のスタックには書き込んだ後の状態を記録し、次の操作で競合を確認できるようにする
+	// 同じスタックに残っている操作も、書き込んだ状態を新しいリビジョンで参照するように更新する
+	*stack = (*stack)[:len(*stack)-1]
+	reversed := operation
+	if undo {
+		reversed.Before = written
+		history.Redo = append(history.Redo, reversed)
+		for i := range history.Undo {
+			rebaseRevisions(history.Undo[i].After, to, written)
+		}
+	} else {
+		reversed.After = written
+		history.Undo = append(history.Undo, reversed)
+		for i := range history.Redo {
+			rebaseRevisions(history.Redo[i].Before, to, written)
+		}
+	}
+	// タスクは保存済みのため、履歴を保存できなくても元に戻す・やり直す操作は成功として扱う
+	if err := historyRepo.SaveHistory(ctx, history); err != nil {
+		s.warn(fmt.Errorf("failed to save undo history: %w", err))
+	}
+	return &operation, nil
+}
+
+// recordOperation は保存した操作を元に戻せるように履歴に記録する
+// Repositoryが履歴の保存に対応していない場合やタスクが変更されていない場合は記録しない
+// 履歴のファイルが破損している場合は新しい履歴で置き換える
+func (s *TaskService) recordOperation(ctx context.Context, operation model.Operation) error {
+	historyRepo, ok := s.repo.(repository.HistoryRepository)
+	if !ok || (len(operation.Before) == 0 && len(operation.After) == 0) {
+		return nil
+	}
+
+	history, err := historyRepo.LoadHistory(ctx)
+	if errors.Is(err, repository.ErrCorrupt) {
+		history, err = model.NewHistory(), nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load undo history: %w", err)
+	}
+
+	history.Record(operation, maxUndoOperations)
+	if err := historyRepo.SaveHistory(ctx, history); err != nil {
+		return fmt.Errorf("failed to save undo history: %w", err)
+	}
+	return nil
+}
+
+// newOperation は変更前後のタスクから、変更・作成・削除されたタスクだけを含む操作を作成する
+func newOperation(action string, before, after []*model.Task, now time.Time) model.Operation {
+	beforeByID := make(map[string]*model.Task, len(before))
+	for _, task := range before {
+		beforeByID[task.ID] = task
+	}
+
+	operation := model.Operation{At: now, Before: []*model.Task{}, After: []*model.Task{}}
+	var added, changed, removed []*model.Task
+	afterIDs := make(map[string]bool, len(after))
+	for _, task := range after {
+		afterIDs[task.ID] = true
+		old, ok := beforeByID[task.ID]
+		switch {
+		case !ok:
+			added = append(added, task)
+		case old.Revision != task.Revision || len(ChangedTaskFields(old, task)) > 0:
+			changed = append(changed, task)
+			operation.Before = append(operation.Before, old)
+		default:
+			continue
+		}
+		operation.After = append(operation.After, task.Clone())
+	}
+	for _, task := range before {
+		if !afterIDs[task.ID] {
+			removed = append(removed, task)
+			operation.Before = append(operation.Before, task)
+		}
+	}
+
+	// 削除・作成・変更の順に、操作の主な対象を説明に使う
+	for _, subjects := range [][]*model.Task{removed, added, changed} {
+		if len(subjects) == 0 {
+			continue
+		}
+		operation.Description = fmt.Sprintf("%s %q", action, subjects[0].Title)
+		if len(subjects) > 1 {
+			operation.Description += fmt.Sprintf(" and %d more", len(subjects)-1)
+		}
+		break
+	}
+	return operation
+}
+
+// applyOperation は from の状態にあるタスクを to の状態に置き換え、書き込んだタスクを返す
+// from にだけ含まれるタスクは削除し、to にだけ含まれるタスクは追加する
+// 置き換えたタスクは現在の変更履歴を引き継ぐ
+// 現在のタスクが from の状態と異なる場合は何も変更せずにConflictErrorを返す
+func applyOperation(appData *model.AppData, from, to []*model.Task, now time.Time) ([]*model.Task, error) {
+	fromIDs := make(map[string]bool, len(from))
+	for _, task := range from {
+		fromIDs[task.ID] = true
+		current, err := appData.GetTaskByID(task.ID)
+		if err != nil {
+			return nil, &ConflictError{TaskID: task.ID, ExpectedRevision: task.Revision}
+		}
+		if current.Revision != task.Revision {
+			return nil, &ConflictError{TaskID: task.ID, ExpectedRevision: task.Revision, ActualRevision: current.Revision}
+		}
+	}
+	toIDs := make(map[string]bool, len(to))
+	for _, task := range to {
+		toIDs[task.ID] = true
+		if fromIDs[task.ID] {
+			continue
+		}
+		if current, err := appData.GetTaskByID(task.ID); err == nil {
+			return nil, &ConflictError{TaskID: task.ID, ActualRevision: current.Revision}
+		}
+	}
+
+	for _, task := range from {
+		if toIDs[task.ID] {
+			continue
+		}
+		if err := appData.DeleteTask(task.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete task: %w", err)
+		}
+	}
+
+	written := make([]*model.Task, 0, len(to))
+	for _, task := range to {
+		restored := task.Clone()
+		restored.UpdatedAt = now
+		if fromIDs[task.ID] {
+			current, _ := appData.GetTaskByID(task.ID)
+			restored.Changes = current.Changes
+			if err := appData.UpdateTask(restored); err != nil {
+				return nil, fmt.Errorf("failed to update task: %w", err)
+			}
+		} else if err := appData.AddTask(restored); err != nil {
+			return nil, fmt.Errorf("failed to add task: %w", err)
+		}
+		written = append(written, restored.Clone())
+	}
+	return written, nil
+}
+
+// rebaseRevisions は snapshots のうち original と同じリビジョンのタスクを、対応する written のリビジョンに置き換える
+// original と written は同じ順序で対応している必要がある
+func rebaseRevisions(snapshots, original, written []*model.Task) {
+	for _, snapshot := range snapshots {
+		for i, task := range original {
+			if snapshot.ID == task.ID && snapshot.Revision == task.Revision {
+				snapshot.Revision = written[i].Revision
+			}
+		}
+	}
+}
+
+// cloneTasks はタスクの一覧を複製する
+func cloneTasks(tasks []*model.Task) []*model.Task {
+	clones := make([]*model.Task, len(tasks))
+	for i, task := range tasks {
+		clones[i] = task.Clone()
+	}
+	return clones
+}
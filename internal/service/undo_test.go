@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"task-cli/internal/model"
+	"task-cli/internal/repository"
+	"task-cli/internal/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskService_Undo_Delete_ShouldRestoreTask(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Write docs", Priority: model.PriorityLow, Tags: []string{"docs"}})
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteTask(ctx, task.ID))
+
+	// When
+	operation, err := service.Undo(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, `delete "Write docs"`, operation.Description)
+	restored, err := service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Write docs", restored.Title)
+	assert.Equal(t, []string{"docs"}, restored.Tags)
+}
+
+func TestTaskService_CreateTask_WhenHistoryCannotBeSaved_ShouldSucceedWithWarning(t *testing.T) {
+	// Given - 履歴のファイルの場所にディレクトリがあり、読み書きできない
+	dataDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dataDir, "undo.json"), 0755))
+	service := NewTaskService(repository.NewFileRepository(dataDir), validator.New())
+	var warnings []error
+	service.SetWarningHandler(func(err error) { warnings = append(warnings, err) })
+	ctx := context.Background()
+
+	// When
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Write docs", Priority: model.PriorityLow})
+
+	// Then - タスクは保存され、履歴の失敗は警告として知らせる
+	require.NoError(t, err)
+	saved, err := service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Write docs", saved.Title)
+	require.Len(t, warnings, 1)
+	assert.ErrorContains(t, warnings[0], "undo history")
+}
+
+func TestTaskService_UndoRedo_ShouldWalkThroughHistory(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Fix login bug", Priority: model.PriorityHigh})
+	require.NoError(t, err)
+	_, err = service.ToggleTaskStatus(ctx, task.ID)
+	require.NoError(t, err)
+
+	// When - 完了を元に戻す
+	operation, err := service.Undo(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, `toggle "Fix login bug"`, operation.Description)
+	current, err := service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusTodo, current.Status)
+	assert.Nil(t, current.CompletedAt)
+
+	// When - 作成も元に戻す
+	_, err = service.Undo(ctx)
+	require.NoError(t, err)
+
+	// Then
+	tasks, err := service.GetAllTasks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+	_, err = service.Undo(ctx)
+	assert.True(t, errors.Is(err, ErrNothingToUndo))
+
+	// When - 2回やり直す
+	_, err = service.Redo(ctx)
+	require.NoError(t, err)
+	_, err = service.Redo(ctx)
+	require.NoError(t, err)
+
+	// Then
+	current, err = service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusCompleted, current.Status)
+	_, err = service.Redo(ctx)
+	assert.True(t, errors.Is(err, ErrNothingToRedo))
+}
+
+func TestTaskService_NewOperation_AfterUndo_ShouldDiscardRedo(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	_, err := service.CreateTask(ctx, CreateTaskRequest{Title: "First", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	_, err = service.Undo(ctx)
+	require.NoError(t, err)
+
+	// When
+	_, err = service.CreateTask(ctx, CreateTaskRequest{Title: "Second", Priority: model.PriorityLow})
+	require.NoError(t, err)
+
+	// Then
+	history, err := service.GetHistory(ctx)
+	require.NoError(t, err)
+	assert.Len(t, history.Undo, 1)
+	assert.Empty(t, history.Redo)
+	_, err = service.Redo(ctx)
+	assert.True(t, errors.Is(err, ErrNothingToRedo))
+}
+
+func TestTaskService_Undo_WhenTaskChangedSince_ShouldReturnConflict(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	task, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Original", Priority: model.PriorityLow})
+	require.NoError(t, err)
+	_, err = service.UpdateTask(ctx, UpdateTaskRequest{ID: task.ID, Title: "Renamed", Priority: model.PriorityLow, Status: model.StatusTodo})
+	require.NoError(t, err)
+
+	// 別のプロセスが履歴に残らない方法でタスクを変更した
+	appData, err := service.LoadCurrent(ctx)
+	require.NoError(t, err)
+	changed, err := appData.GetTaskByID(task.ID)
+	require.NoError(t, err)
+	changed.Title = "Changed elsewhere"
+	require.NoError(t, appData.UpdateTask(changed))
+	require.NoError(t, service.repo.Save(ctx, appData))
+
+	// When
+	_, err = service.Undo(ctx)
+
+	// Then - 何も変更せずに競合を返す
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.Contains(t, err.Error(), `cannot undo update "Renamed"`)
+	current, err := service.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Changed elsewhere", current.Title)
+	history, err := service.GetHistory(ctx)
+	require.NoError(t, err)
+	assert.Len(t, history.Undo, 2)
+}
+
+func TestTaskService_Undo_BulkDelete_ShouldRestoreAllTasksAndBlockers(t *testing.T) {
+	// Given
+	service := newFileTaskService(t)
+	ctx := context.Background()
+	parent, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Parent", Priority: model.PriorityMedium})
+	require.NoError(t, err)
+	child, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Child", Priority: model.PriorityMedium, ParentID: parent.ID})
+	require.NoError(t, err)
+	blocked, err := service.CreateTask(ctx, CreateTaskRequest{Title: "Blocked", Priority: model.PriorityMedium, BlockedBy: []string{parent.ID}})
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteTasksWithPolicy(ctx, ChildrenDelete, parent.ID))
+
+	// When
+	operation, err := service.Undo(ctx)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, `delete "Parent" and 1 more`, operation.Description)
+	tasks, err := service.GetAllTasks(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3)
+	restoredChild, err := service.GetTaskByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, restoredChild.ParentID)
+	restoredBlocked, err := service.GetTaskByID(ctx, blocked.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{parent.ID}, restoredBlocked.BlockedBy)
+}
+
+func TestTaskService_Undo_WithoutHistoryRepository_ShouldReportNothingToUndo(t *testing.T) {
+	// Given - モックのRepositoryは履歴の保存に対応していない
+	service := NewTaskService(&MockRepository{}, nil)
+
+	// When
+	_, undoErr := service.Undo(context.Background())
+	_, redoErr := service.Redo(context.Background())
+
+	// Then
+	assert.True(t, errors.Is(undoErr, ErrNothingToUndo))
+	assert.True(t, errors.Is(redoErr, ErrNothingToRedo))
+}
+
+func TestNewOperation_ShouldKeepOnlyChangedTasks(t *testing.T) {
+	// Given
+	unchanged := &model.Task{ID: "1", Title: "Unchanged", Revision: 1}
+	renamed := &model.Task{ID: "2", Title: "Before", Revision: 1}
+	removed := &model.Task{ID: "3", Title: "Removed", Revision: 2}
+	before := cloneTasks([]*model.Task{unchanged, renamed, removed})
+	renamed.Title = "After"
+	renamed.Revision = 2
+	added := &model.Task{ID: "4", Title: "Added", Revision: 1}
+
+	// When
+	operation := newOperation(actionUpdate, before, []*model.Task{unchanged, renamed, added}, renamed.UpdatedAt)
+
+	// Then
+	assert.Equal(t, `update "Removed"`, operation.Description)
+	assert.Equal(t, []string{"Before", "Removed"}, taskTitles(operation.Before))
+	assert.Equal(t, []string{"After", "Added"}, taskTitles(operation.After))
+}
+
+// taskTitles はタスクのタイトルの一覧を返す
+func taskTitles(tasks []*model.Task) []string {
+	titles := make([]string, len(tasks))
+	for i, task := range tasks {
+		titles[i] = task.Title
+	}
+	return titles
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"task-cli/internal/model"
+	"task-cli/internal/query"
+	"task-cli/internal/repository"
+)
+
+// ViewService は名前を付けて保存したフィルター（ビュー）を管理する
+type ViewService struct {
+	repo repository.ViewRepository
+}
+
+// NewViewService は新しいViewServiceを作成する
+func NewViewService(repo repository.ViewRepository) *ViewService {
+	return &ViewService{repo: repo}
+}
+
+// ListViews は保存されたビューを保存した順に返す
+func (s *ViewService) ListViews(ctx context.Context) ([]model.View, error) {
+	views, err := s.repo.LoadViews(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load views: %w", err)
+	}
+	return views, nil
+}
+
+// GetView は名前でビューを取得する（大文字小文字は区別しない）
+func (s *ViewService) GetView(ctx context.Context, name string) (model.View, error) {
+	views, err := s.ListViews(ctx)
+	if err != nil {
+		return model.View{}, err
+	}
+
+	i := model.FindView(views, name)
+	if i < 0 {
+		return model.View{}, fmt.Errorf("%w: %q", ErrViewNotFound, name)
+	}
+	return views[i], nil
+}
+
+// SaveView はビューを保存する
+// 同じ名前のビューがある場合は位置を保ったまま置き換え、置き換えたかを返す
+func (s *ViewService) SaveView(ctx context.Context, view model.View) (replaced bool, err error) {
+	if err := model.ValidateViewName(view.Name); err != nil {
+		return false, err
+	}
+	// 保存する前に構文を検証する（相対的な日付は使用するたびに解釈する）
+	if _, err := query.Parse(view.Query, time.Now()); err != nil {
+		return false, err
+	}
+
+	err = s.mutate(ctx, func(views []model.View) ([]model.View, error) {
+		if i := model.FindView(views, view.Name); i >= 0 {
+			views[i] = view
+			replaced = true
+			return views, nil
+		}
+		return append(views, view), nil
+	})
+	return replaced, err
+}
+
+// DeleteView は名前でビューを削除する
+func (s *ViewService) DeleteView(ctx context.Context, name string) error {
+	return s.mutate(ctx, func(views []model.View) ([]model.View, error) {
+		i := model.FindView(views, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrViewNotFound, name)
+		}
+		return append(views[:i], views[i+1:]...), nil
+	})
+}
+
+// ViewFilter はビューの条件を now を基準に解釈したフィルターを返す
+func ViewFilter(view model.View, now time.Time) (TaskFilter, error) {
+	where, err := query.Parse(view.Query, now)
+	if err != nil {
+		return TaskFilter{}, fmt.Errorf("view %q: %w", view.Name, err)
+	}
+	return TaskFilter{Where: where}, nil
+}
+
+// mutate はデータディレクトリのロックを保持したまま、ビューの読み込み・変更・保存を行う
+// fn がエラーを返した場合は保存しない
+func (s *ViewService) mutate(ctx context.Context, fn func(views []model.View) ([]model.View, error)) error {
+	if locker, ok := s.repo.(repository.Locker); ok {
+		unlock, err := locker.Lock(ctx)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	views, err := s.ListViews(ctx)
+	if err != nil {
+		return err
+	}
+
+	views, err = fn(views)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SaveViews(ctx, views); err != nil {
+		return fmt.Errorf("failed to save views: %w", err)
+	}
+	return nil
+}
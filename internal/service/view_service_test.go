@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+	"task-cli/internal/query"
+	"task-cli/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestViewService は一時ディレクトリを使用するViewServiceを作成する
+func newTestViewService(t *testing.T) *ViewService {
+	t.Helper()
+	return NewViewService(repository.NewFileViewRepository(t.TempDir()))
+}
+
+func TestViewService_SaveView_ShouldAppendAndReplaceByName(t *testing.T) {
+	// Given
+	service := newTestViewService(t)
+	ctx := context.Background()
+
+	// When
+	replaced1, err1 := service.SaveView(ctx, model.View{Name: "Backend", Query: "tag:backend"})
+	replaced2, err2 := service.SaveView(ctx, model.View{Name: "Due this week", Query: "due<=+7d"})
+	replaced3, err3 := service.SaveView(ctx, model.View{Name: "backend", Query: "tag:backend is:open"})
+
+	// Then
+	require.NoError(t, errors.Join(err1, err2, err3))
+	assert.False(t, replaced1)
+	assert.False(t, replaced2)
+	assert.True(t, replaced3)
+
+	views, err := service.ListViews(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.View{
+		{Name: "backend", Query: "tag:backend is:open"},
+		{Name: "Due this week", Query: "due<=+7d"},
+	}, views)
+}
+
+func TestViewService_SaveView_WithInvalidQuery_ShouldNotSave(t *testing.T) {
+	// Given
+	service := newTestViewService(t)
+	ctx := context.Background()
+
+	// When
+	_, err := service.SaveView(ctx, model.View{Name: "Broken", Query: "priority>urgent"})
+
+	// Then
+	assert.True(t, errors.Is(err, query.ErrInvalidQuery))
+	views, listErr := service.ListViews(ctx)
+	require.NoError(t, listErr)
+	assert.Empty(t, views)
+}
+
+func TestViewService_SaveView_WithInvalidName_ShouldFail(t *testing.T) {
+	// Given
+	service := newTestViewService(t)
+
+	// When
+	_, err := service.SaveView(context.Background(), model.View{Name: "  ", Query: "tag:x"})
+
+	// Then
+	assert.Error(t, err)
+}
+
+func TestViewService_GetView_ShouldIgnoreCase(t *testing.T) {
+	// Given
+	service := newTestViewService(t)
+	ctx := context.Background()
+	_, err := service.SaveView(ctx, model.View{Name: "My backend work", Query: "tag:backend"})
+	require.NoError(t, err)
+
+	// When
+	view, err := service.GetView(ctx, "my backend WORK")
+	_, missingErr := service.GetView(ctx, "frontend")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "My backend work", view.Name)
+	assert.True(t, errors.Is(missingErr, ErrViewNotFound))
+}
+
+func TestViewService_DeleteView_ShouldRemoveView(t *testing.T) {
+	// Given
+	service := newTestViewService(t)
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := service.SaveView(ctx, model.View{Name: name})
+		require.NoError(t, err)
+	}
+
+	// When
+	err := service.DeleteView(ctx, "B")
+	missingErr := service.DeleteView(ctx, "b")
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, errors.Is(missingErr, ErrViewNotFound))
+	views, err := service.ListViews(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.View{{Name: "a"}, {Name: "c"}}, views)
+}
+
+func TestViewFilter_ShouldResolveRelativeDatesAtUseTime(t *testing.T) {
+	// Given
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.Local)
+	inFiveDays := now.AddDate(0, 0, 5)
+	inTenDays := now.AddDate(0, 0, 10)
+	tasks := []*model.Task{
+		{ID: "1", Title: "soon", Status: model.StatusTodo, Priority: model.PriorityLow, DueDate: &inFiveDays},
+		{ID: "2", Title: "later", Status: model.StatusTodo, Priority: model.PriorityLow, DueDate: &inTenDays},
+	}
+	view := model.View{Name: "Due this week", Query: "due<=+7d"}
+
+	// When
+	filter, err := ViewFilter(view, now)
+	later, laterErr := ViewFilter(view, now.AddDate(0, 0, 3))
+
+	// Then
+	require.NoError(t, errors.Join(err, laterErr))
+	assert.Len(t, FilterTasks(tasks, filter), 1)
+	assert.Len(t, FilterTasks(tasks, later), 2)
+}
+
+func TestViewFilter_WithInvalidQuery_ShouldNameTheView(t *testing.T) {
+	// When
+	_, err := ViewFilter(model.View{Name: "Broken", Query: "("}, time.Now())
+
+	// Then
+	assert.True(t, errors.Is(err, query.ErrInvalidQuery))
+	assert.Contains(t, err.Error(), `view "Broken"`)
+}
@@ -2,9 +2,14 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"task-cli/internal/model"
+	"task-cli/internal/quickadd"
 	"task-cli/internal/service"
 
 	"github.com/rivo/tview"
@@ -17,18 +22,54 @@ type ViewMode int
 const (
 	ViewModeList ViewMode = iota
 	ViewModeForm
+	ViewModeDialog
+	ViewModeQuickAdd
+	ViewModeSearch
 )
 
+// listHelpText はリストビューのキー操作のヘルプ
+const listHelpText = "Keys: n=New, a=Quick add, e=Edit, d=Delete, t=Toggle, c=Collapse, q=Quit, /=Search, 0-9=Views, s/S=Sort/Reverse, i=Details, u/Ctrl+R=Undo/Redo, p=Project, m=Move to project"
+
+// searchHelpText は検索中のリストビューのキー操作のヘルプ
+// 検索中の n は新規作成ではなく次の一致への移動になるため、ヘルプを切り替えて示す
+const searchHelpText = "Searching: n/N=Next/Prev match, Esc=Clear search (then n=New), /=Edit search, a=Quick add, e=Edit, d=Delete, t=Toggle, i=Details, q=Quit"
+
+// サブタスクを持つタスクの削除ダイアログの選択肢
+var deleteSubtaskChoices = []string{"Delete all", "Keep subtasks", "Cancel"}
+
+// サブタスクを持つタスクの完了ダイアログの選択肢
+var completeSubtaskChoices = []string{"Complete all", "Only this task", "Cancel"}
+
+// ブロックされているタスクの完了ダイアログの選択肢
+var completeBlockedChoices = []string{"Complete anyway", "Cancel"}
+
+// sortPresets は s キーで順に切り替える並び順
+var sortPresets = []service.SortOrder{
+	service.DefaultSortOrder(),
+	{{Field: service.SortByDue}, {Field: service.SortByPriority, Descending: true}},
+	{{Field: service.SortByUpdated, Descending: true}},
+	{{Field: service.SortByCreated, Descending: true}},
+	{{Field: service.SortByTitle}},
+	{{Field: service.SortByTag}, {Field: service.SortByTitle}},
+	{{Field: service.SortByStatus}, {Field: service.SortByPriority, Descending: true}},
+}
+
 // TaskServiceInterface はTaskServiceのインターフェース
 type TaskServiceInterface interface {
 	CreateTask(ctx context.Context, request service.CreateTaskRequest) (*model.Task, error)
 	UpdateTask(ctx context.Context, request service.UpdateTaskRequest) (*model.Task, error)
 	DeleteTask(ctx context.Context, taskID string) error
+	DeleteTasksWithPolicy(ctx context.Context, policy service.ChildrenPolicy, taskIDs ...string) error
 	ToggleTaskStatus(ctx context.Context, taskID string) (*model.Task, error)
+	CompleteTask(ctx context.Context, taskID string, options service.CompleteOptions) (*model.Task, error)
 	GetAllTasks(ctx context.Context) ([]*model.Task, error)
 	SearchTasks(ctx context.Context, query string) ([]*model.Task, error)
 	GetTasksByStatus(ctx context.Context, status model.Status) ([]*model.Task, error)
 	GetTasksByPriority(ctx context.Context, priority model.Priority) ([]*model.Task, error)
+	Undo(ctx context.Context) (*model.Operation, error)
+	Redo(ctx context.Context) (*model.Operation, error)
+	GetProjectStats(ctx context.Context) ([]service.ProjectStats, error)
+	MoveTasks(ctx context.Context, projectID string, taskIDs ...string) ([]*model.Task, error)
 }
 
 // App はメインアプリケーション
@@ -42,11 +83,24 @@ type App struct {
 	// UI Components
 	taskListWidget *TaskListWidget
 	inputFormWidget *InputFormWidget
+	quickAddWidget *QuickAddWidget
+	searchBarWidget *SearchBarWidget
+	viewBarWidget  *ViewBarWidget
+	detailPaneWidget *DetailPaneWidget
+	statusBarWidget *StatusBarWidget
+	dialogs        *DialogManager
 	pages          *tview.Pages
+	listLayout     *tview.Flex
+	contentLayout  *tview.Flex
 	
 	// State
 	currentView    ViewMode
+	previousView   ViewMode
 	editingTaskID  string
+	editingRevision int64
+	showDetail     bool
+	detailBeside   bool // 詳細ペインをリストの横に並べているか（false の場合は下）
+	screenWidth    int
 	ctx           context.Context
 }
 
@@ -58,6 +112,7 @@ func NewApp(taskService TaskServiceInterface, stateManager *service.StateManager
 		stateManager: stateManager,
 		theme:        theme,
 		currentView:  ViewModeList,
+		showDetail:   true,
 		ctx:         context.Background(),
 	}
 	
@@ -77,9 +132,16 @@ func (a *App) setupUI() {
 	// ウィジェットを作成
 	a.taskListWidget = NewTaskListWidget(a.theme)
 	a.inputFormWidget = NewInputFormWidget(a.theme)
+	a.quickAddWidget = NewQuickAddWidget(a.theme)
+	a.searchBarWidget = NewSearchBarWidget(a.theme)
+	a.viewBarWidget = NewViewBarWidget(a.theme)
+	a.detailPaneWidget = NewDetailPaneWidget(a.theme)
+	a.statusBarWidget = NewStatusBarWidget(a.theme, listHelpText)
+	a.taskListWidget.SetSort(a.stateManager.GetSort())
 	
-	// ページコンテナを作成
+	// ページコンテナとダイアログを作成
 	a.pages = tview.NewPages()
+	a.dialogs = NewDialogManager(a.tviewApp, a.pages, a.statusBarWidget, a.theme)
 	
 	// リストビューを作成
 	listLayout := a.createListLayout()
@@ -95,18 +157,22 @@ func (a *App) setupUI() {
 
 // createListLayout はリストビューのレイアウトを作成する
 func (a *App) createListLayout() tview.Primitive {
-	// ヘルプテキストを作成
-	helpText := tview.NewTextView().
-		SetText("Keys: n=New, e=Edit, d=Delete, t=Toggle, q=Quit, /=Search").
-		SetTextColor(a.theme.GetHighlightColor()).
-		SetBackgroundColor(a.theme.GetBackgroundColor())
+	// タスクリストと詳細ペイン（画面幅に応じて横か下に並べる）
+	a.contentLayout = tview.NewFlex().
+		AddItem(a.taskListWidget.GetPrimitive(), 0, 2, true).
+		AddItem(a.detailPaneWidget.GetPrimitive(), 0, 1, false)
+	a.contentLayout.SetBackgroundColor(a.theme.GetBackgroundColor())
 	
-	// ボーダーを作成
+	// ボーダーを作成（ビューバーは保存したビューがある場合のみ、クイック追加バーと検索バーは使用中のみ高さを持つ）
 	border := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(a.taskListWidget.GetPrimitive(), 0, 1, true).
-		AddItem(helpText, 1, 0, false)
+		AddItem(a.viewBarWidget.GetPrimitive(), 0, 0, false).
+		AddItem(a.contentLayout, 0, 1, true).
+		AddItem(a.quickAddWidget.GetPrimitive(), 0, 0, false).
+		AddItem(a.searchBarWidget.GetPrimitive(), 0, 0, false).
+		AddItem(a.statusBarWidget.GetPrimitive(), 1, 0, false)
 	
 	border.SetBackgroundColor(a.theme.GetBackgroundColor())
+	a.listLayout = border
 	
 	return border
 }
@@ -131,9 +197,29 @@ func (a *App) createFormLayout() tview.Primitive {
 
 // setupEventHandlers はイベントハンドラーを設定する
 func (a *App) setupEventHandlers() {
-	// タスクリストの選択変更イベント
+	// タスクリストの選択変更イベント（詳細ペインに選択中のタスクを表示する）
 	a.taskListWidget.SetSelectionChangedCallback(func(task *model.Task) {
-		// 必要に応じて詳細表示などを実装
+		a.detailPaneWidget.SetTask(task, &model.AppData{Tasks: a.taskListWidget.GetAllTasks()})
+	})
+	
+	// ダイアログを閉じたら開く前のビューに戻る
+	a.dialogs.SetOpenCallback(func() {
+		if a.currentView != ViewModeDialog {
+			a.previousView = a.currentView
+		}
+		a.currentView = ViewModeDialog
+	})
+	
+	a.dialogs.SetCloseCallback(func() {
+		a.currentView = a.previousView
+		a.focusCurrentView()
+	})
+	
+	// 描画の前に画面幅に合わせて詳細ペインの配置を決める
+	a.tviewApp.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		width, _ := screen.Size()
+		a.layoutDetailPane(width)
+		return false
 	})
 	
 	// フォームのイベント
@@ -145,10 +231,34 @@ func (a *App) setupEventHandlers() {
 		a.handleFormCancel()
 	})
 	
-	// StateManagerのイベント
-	a.stateManager.Subscribe(func(tasks []*model.Task, filter service.TaskFilter) {
-		a.taskListWidget.SetTasks(tasks)
-		a.taskListWidget.ApplyFilter(filter)
+	// クイック追加バーのイベント
+	a.quickAddWidget.SetSubmitCallback(func(line string) {
+		a.handleQuickAddSubmit(line)
+	})
+	
+	a.quickAddWidget.SetCancelCallback(func() {
+		a.closeQuickAdd()
+	})
+	
+	// 検索バーのイベント（入力のたびに絞り込む）
+	a.searchBarWidget.SetChangeCallback(func(query string) {
+		a.UpdateSearch(query)
+	})
+	
+	a.searchBarWidget.SetSubmitCallback(func(string) {
+		a.closeSearchBar()
+	})
+	
+	a.searchBarWidget.SetCancelCallback(func() {
+		a.ClearSearch()
+	})
+	
+	// StateManagerのイベント（通知は別のゴルーチンから届くため、UIの更新はイベントループで行う）
+	// 進捗やブロック状態を正しく表示できるよう、ウィジェットには絞り込む前のタスクを渡す
+	a.stateManager.Subscribe(func([]*model.Task, service.TaskFilter) {
+		a.tviewApp.QueueUpdateDraw(func() {
+			a.syncTaskList()
+		})
 	})
 	
 	// キーボードイベント
@@ -157,6 +267,15 @@ func (a *App) setupEventHandlers() {
 	})
 }
 
+// syncTaskList はStateManagerの最新の状態をタスクリストと検索の一致件数に反映する
+// 通知が遅れて届いても古い条件に戻さないよう、通知時ではなく反映時の並び順とフィルターを使う
+func (a *App) syncTaskList() {
+	a.taskListWidget.SetSort(a.stateManager.GetSort())
+	a.taskListWidget.SetTasks(a.stateManager.GetCurrentTasks())
+	a.taskListWidget.ApplyFilter(a.stateManager.GetCurrentFilter())
+	a.searchBarWidget.SetMatchCount(a.taskListWidget.GetMatchCount())
+}
+
 // handleKeyPress はキーボード入力を処理する
 func (a *App) handleKeyPress(event *tcell.EventKey) *tcell.EventKey {
 	switch a.currentView {
@@ -164,6 +283,9 @@ func (a *App) handleKeyPress(event *tcell.EventKey) *tcell.EventKey {
 		return a.handleListViewKeyPress(event)
 	case ViewModeForm:
 		return a.handleFormViewKeyPress(event)
+	case ViewModeDialog, ViewModeQuickAdd, ViewModeSearch:
+		// ダイアログや入力バー自身にキー入力を処理させる
+		return event
 	}
 	return event
 }
@@ -175,8 +297,21 @@ func (a *App) handleListViewKeyPress(event *tcell.EventKey) *tcell.EventKey {
 		a.tviewApp.Stop()
 		return nil
 	case 'n':
+		// 検索中は次の一致に移動する
+		if a.IsSearchActive() {
+			a.taskListWidget.SelectNextMatch()
+			return nil
+		}
 		a.StartCreateTask()
 		return nil
+	case 'N':
+		if a.IsSearchActive() {
+			a.taskListWidget.SelectPreviousMatch()
+		}
+		return nil
+	case 'a':
+		a.StartQuickAdd()
+		return nil
 	case 'e':
 		a.StartEditTask()
 		return nil
@@ -186,13 +321,45 @@ func (a *App) handleListViewKeyPress(event *tcell.EventKey) *tcell.EventKey {
 	case 't':
 		a.ToggleSelectedTask()
 		return nil
+	case 'c':
+		a.taskListWidget.ToggleCollapsed()
+		return nil
 	case '/':
-		// 検索機能は将来的に実装
+		a.StartSearch()
+		return nil
+	case 'i':
+		a.ToggleDetailPane()
+		return nil
+	case 's':
+		a.CycleSort()
+		return nil
+	case 'S':
+		a.ReverseSort()
+		return nil
+	case 'u':
+		a.UndoLastChange()
+		return nil
+	case 'p':
+		a.StartSwitchProject()
+		return nil
+	case 'm':
+		a.MoveSelectedTask()
+		return nil
+	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		a.selectViewByKey(int(event.Rune() - '0'))
 		return nil
 	}
 	
 	switch event.Key() {
+	case tcell.KeyCtrlR:
+		a.RedoLastChange()
+		return nil
 	case tcell.KeyEscape:
+		// 検索中は検索を解除し、それ以外は終了する
+		if a.IsSearchActive() {
+			a.ClearSearch()
+			return nil
+		}
 		a.tviewApp.Stop()
 		return nil
 	}
@@ -207,8 +374,8 @@ func (a *App) handleFormViewKeyPress(event *tcell.EventKey) *tcell.EventKey {
 		a.handleFormCancel()
 		return nil
 	case tcell.KeyCtrlS:
-		data := a.inputFormWidget.GetFormData()
-		a.handleFormSubmit(data)
+		// 送信ボタンと同じく検証してから送信する（不正な期限日で既存の期限日を消さないようにする）
+		a.inputFormWidget.Submit()
 		return nil
 	}
 	
@@ -246,6 +413,7 @@ func (a *App) RefreshTasks() error {
 // StartCreateTask は新しいタスク作成を開始する
 func (a *App) StartCreateTask() {
 	a.editingTaskID = ""
+	a.editingRevision = 0
 	a.inputFormWidget.SetMode(FormModeCreate)
 	a.inputFormWidget.Clear()
 	a.SwitchToFormView()
@@ -259,35 +427,474 @@ func (a *App) StartEditTask() {
 	}
 	
 	a.editingTaskID = selectedTask.ID
+	a.editingRevision = selectedTask.Revision
 	a.inputFormWidget.SetMode(FormModeEdit)
 	a.inputFormWidget.LoadTask(selectedTask)
 	a.SwitchToFormView()
 }
 
 // DeleteSelectedTask は選択されたタスクを削除する
+// サブタスクがある場合はサブタスクも削除するかを確認する
 func (a *App) DeleteSelectedTask() {
 	selectedTask := a.taskListWidget.GetSelectedTask()
 	if selectedTask == nil {
 		return
 	}
 	
-	if err := a.HandleDeleteTask(selectedTask.ID); err != nil {
-		// エラー処理（将来的にダイアログで表示）
-		return
-	}
+	a.deleteTask(selectedTask)
 }
 
 // ToggleSelectedTask は選択されたタスクのステータスを切り替える
+// 未完了のサブタスクがあるタスクを完了にする場合はサブタスクも完了にするかを確認する
 func (a *App) ToggleSelectedTask() {
 	selectedTask := a.taskListWidget.GetSelectedTask()
 	if selectedTask == nil {
 		return
 	}
 	
-	if err := a.HandleToggleTask(selectedTask.ID); err != nil {
-		// エラー処理（将来的にダイアログで表示）
+	a.toggleTask(selectedTask)
+}
+
+// deleteTask は確認してからタスクを削除する（サブタスクがある場合はその扱いも選択させる）
+func (a *App) deleteTask(task *model.Task) {
+	hierarchy := &model.AppData{Tasks: a.stateManager.GetCurrentTasks()}
+	subtasks := hierarchy.GetDescendants(task.ID)
+	if len(subtasks) == 0 {
+		a.dialogs.Confirm(tview.Escape(fmt.Sprintf("Delete %q? y/N", task.Title)), func(confirmed bool) {
+			if confirmed {
+				a.report(a.HandleDeleteTask(task.ID), fmt.Sprintf("Deleted %q", task.Title))
+			}
+		})
+		return
+	}
+	
+	text := tview.Escape(fmt.Sprintf("Delete %q?\nIt has %d subtasks.", task.Title, len(subtasks)))
+	a.dialogs.Choose(text, deleteSubtaskChoices, func(index int) {
+		if index == 0 || index == 1 {
+			a.report(a.handleDeleteChoice(task.ID, index), fmt.Sprintf("Deleted %q", task.Title))
+		}
+	})
+}
+
+// handleDeleteChoice は削除ダイアログで選ばれた選択肢に従ってタスクを削除する
+func (a *App) handleDeleteChoice(taskID string, index int) error {
+	switch index {
+	case 0:
+		return a.HandleDeleteTaskWithPolicy(taskID, service.ChildrenDelete)
+	case 1:
+		return a.HandleDeleteTaskWithPolicy(taskID, service.ChildrenPromote)
+	default:
+		return nil
+	}
+}
+
+// toggleTask はタスクのステータスを切り替える
+// 未完了のタスクにブロックされている場合や未完了のサブタスクがある場合は確認してから完了にする
+func (a *App) toggleTask(task *model.Task) {
+	if task.IsCompleted() {
+		a.report(a.HandleToggleTask(task.ID), fmt.Sprintf("Reopened %q", task.Title))
+		return
+	}
+	
+	hierarchy := &model.AppData{Tasks: a.stateManager.GetCurrentTasks()}
+	blockers := hierarchy.GetOpenBlockers(task.ID)
+	if len(blockers) == 0 {
+		a.completeTask(task, hierarchy, false)
+		return
+	}
+	
+	titles := make([]string, len(blockers))
+	for i, blocker := range blockers {
+		titles[i] = blocker.Title
+	}
+	text := tview.Escape(fmt.Sprintf("%q is blocked by %d open tasks:\n%s\n\nComplete it anyway?",
+		task.Title, len(blockers), strings.Join(titles, "\n")))
+	a.dialogs.Choose(text, completeBlockedChoices, func(index int) {
+		if index == 0 {
+			a.completeTask(task, hierarchy, true)
+		}
+	})
+}
+
+// completeTask はタスクを完了にし、未完了のサブタスクがある場合は扱いを選択させる
+func (a *App) completeTask(task *model.Task, hierarchy *model.AppData, ignoreBlockers bool) {
+	done, total := hierarchy.SubtaskProgress(task.ID)
+	if openSubtasks := total - done; openSubtasks > 0 {
+		text := tview.Escape(fmt.Sprintf("Complete %q?\nIt has %d open subtasks.", task.Title, openSubtasks))
+		a.dialogs.Choose(text, completeSubtaskChoices, func(index int) {
+			if index == 0 || index == 1 {
+				a.report(a.handleCompleteChoice(task.ID, index, ignoreBlockers), fmt.Sprintf("Completed %q", task.Title))
+			}
+		})
+		return
+	}
+	
+	success := fmt.Sprintf("Completed %q", task.Title)
+	if ignoreBlockers {
+		a.report(a.HandleCompleteTask(task.ID, service.CompleteOptions{IgnoreBlockers: true}), success)
+		return
+	}
+	a.report(a.HandleToggleTask(task.ID), success)
+}
+
+// handleCompleteChoice は完了ダイアログで選ばれた選択肢に従ってタスクを完了にする
+func (a *App) handleCompleteChoice(taskID string, index int, ignoreBlockers bool) error {
+	switch index {
+	case 0:
+		return a.HandleCompleteTask(taskID, service.CompleteOptions{IncludeSubtasks: true, IgnoreBlockers: ignoreBlockers})
+	case 1:
+		return a.HandleCompleteTask(taskID, service.CompleteOptions{IgnoreBlockers: ignoreBlockers})
+	default:
+		return nil
+	}
+}
+
+// report は操作の結果を知らせる（失敗した場合はエラーダイアログ、成功した場合は success をトーストで表示する）
+func (a *App) report(err error, success string) {
+	if err != nil {
+		a.dialogs.ShowError(err)
+		return
+	}
+	if success != "" {
+		a.dialogs.ShowToast(success)
+	}
+}
+
+// ShowWarning は操作は成功したが付随する処理に失敗したことを知らせる
+func (a *App) ShowWarning(err error) {
+	a.dialogs.ShowWarning(err)
+}
+
+// SetErrorLog はTUIで表示したエラーを書き込むログの出力先を設定する
+func (a *App) SetErrorLog(w io.Writer) {
+	a.dialogs.SetErrorLog(w)
+}
+
+// focusCurrentView は現在のビューの入力を受け付けるウィジェットにフォーカスを移す
+func (a *App) focusCurrentView() {
+	switch a.currentView {
+	case ViewModeForm:
+		a.tviewApp.SetFocus(a.inputFormWidget.GetPrimitive())
+	case ViewModeQuickAdd:
+		a.tviewApp.SetFocus(a.quickAddWidget.GetPrimitive())
+	case ViewModeSearch:
+		a.tviewApp.SetFocus(a.searchBarWidget.GetPrimitive())
+	default:
+		a.tviewApp.SetFocus(a.taskListWidget.GetPrimitive())
+	}
+}
+
+// StartQuickAdd はリストの下にクイック追加バーを表示して入力を開始する
+func (a *App) StartQuickAdd() {
+	a.quickAddWidget.Clear()
+	a.currentView = ViewModeQuickAdd
+	a.listLayout.ResizeItem(a.quickAddWidget.GetPrimitive(), quickAddHeight, 0)
+	a.tviewApp.SetFocus(a.quickAddWidget.GetPrimitive())
+}
+
+// closeQuickAdd はクイック追加バーを閉じてリストにフォーカスを戻す
+func (a *App) closeQuickAdd() {
+	a.quickAddWidget.Clear()
+	a.currentView = ViewModeList
+	a.listLayout.ResizeItem(a.quickAddWidget.GetPrimitive(), 0, 0)
+	a.tviewApp.SetFocus(a.taskListWidget.GetPrimitive())
+}
+
+// handleQuickAddSubmit はクイック追加バーの送信を処理する
+// 解釈できない入力はバーを開いたままエラーを表示し、修正できるようにする
+func (a *App) handleQuickAddSubmit(line string) {
+	if err := a.HandleQuickAdd(line); err != nil {
+		a.quickAddWidget.SetErrorMessage(err.Error())
+		return
+	}
+	
+	a.closeQuickAdd()
+	a.dialogs.ShowToast("Added a task")
+}
+
+// HandleQuickAdd はクイック追加の構文で書かれた1行からタスクを作成する
+func (a *App) HandleQuickAdd(line string) error {
+	request, err := quickadd.Parse(line, time.Now())
+	if err != nil {
+		return err
+	}
+	request.ProjectID = a.currentProjectID()
+	
+	if _, err := a.taskService.CreateTask(a.ctx, request); err != nil {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+	
+	return a.RefreshTasks()
+}
+
+// StartSearch はリストの下に検索バーを表示して検索語の入力を開始する
+// 確定済みの検索語がある場合はその続きから編集できる
+func (a *App) StartSearch() {
+	a.currentView = ViewModeSearch
+	a.listLayout.ResizeItem(a.searchBarWidget.GetPrimitive(), searchBarHeight, 0)
+	a.tviewApp.SetFocus(a.searchBarWidget.GetPrimitive())
+}
+
+// UpdateSearch は検索語でタスクを絞り込み、一致部分を強調して最初の一致を選択する
+// ステータスと優先度のフィルターは保つ
+func (a *App) UpdateSearch(query string) {
+	filter := a.stateManager.GetCurrentFilter()
+	filter.Query = query
+	a.stateManager.SetFilter(filter)
+	
+	// 入力中の表示を遅らせないよう、ウィジェットにも直接適用する
+	a.taskListWidget.ApplyFilter(filter)
+	a.taskListWidget.SetHighlight(query)
+	a.taskListWidget.SelectFirstMatch()
+	a.searchBarWidget.SetMatchCount(a.taskListWidget.GetMatchCount())
+	if a.IsSearchActive() {
+		a.statusBarWidget.SetHelp(searchHelpText)
+	} else {
+		a.statusBarWidget.SetHelp(listHelpText)
+	}
+}
+
+// IsSearchActive は検索語で絞り込んでいるかを返す
+func (a *App) IsSearchActive() bool {
+	return a.taskListWidget.GetHighlight() != ""
+}
+
+// ClearSearch は検索語を消して絞り込みと強調表示を解除する
+func (a *App) ClearSearch() {
+	a.searchBarWidget.SetText("")
+	a.UpdateSearch("")
+	a.closeSearchBar()
+}
+
+// closeSearchBar は検索語を保ったまま検索バーを閉じ、リストにフォーカスを戻す
+func (a *App) closeSearchBar() {
+	if a.searchBarWidget.GetText() == "" {
+		a.listLayout.ResizeItem(a.searchBarWidget.GetPrimitive(), 0, 0)
+	}
+	a.currentView = ViewModeList
+	a.tviewApp.SetFocus(a.taskListWidget.GetPrimitive())
+}
+
+// ToggleDetailPane は詳細ペインの表示・非表示を切り替える
+func (a *App) ToggleDetailPane() {
+	a.showDetail = !a.showDetail
+	a.layoutDetailPane(a.screenWidth)
+}
+
+// IsDetailPaneVisible は詳細ペインを表示しているかを返す
+func (a *App) IsDetailPaneVisible() bool {
+	return a.showDetail
+}
+
+// layoutDetailPane は画面幅に応じて詳細ペインをリストの横か下に配置する（非表示の場合は高さと幅を0にする）
+func (a *App) layoutDetailPane(width int) {
+	a.screenWidth = width
+	pane := a.detailPaneWidget.GetPrimitive()
+	switch {
+	case !a.showDetail:
+		a.contentLayout.ResizeItem(pane, 0, 0)
+	case width >= detailPaneMinSideWidth:
+		a.detailBeside = true
+		a.contentLayout.SetDirection(tview.FlexColumn)
+		a.contentLayout.ResizeItem(pane, 0, 1)
+	default:
+		a.detailBeside = false
+		a.contentLayout.SetDirection(tview.FlexRow)
+		a.contentLayout.ResizeItem(pane, detailPaneBottomHeight, 0)
+	}
+}
+
+// SetSort は並び順を設定する
+func (a *App) SetSort(order service.SortOrder) {
+	a.stateManager.SetSort(order)
+
+	// 検索と同様に、表示を遅らせないようウィジェットにも直接適用する
+	a.taskListWidget.SetSort(order)
+}
+
+// GetSort は現在の並び順を取得する
+func (a *App) GetSort() service.SortOrder {
+	return a.stateManager.GetSort()
+}
+
+// CycleSort は最優先のキーを基準に、次の並び順に切り替える
+func (a *App) CycleSort() {
+	current := a.stateManager.GetSort()
+	next := 0
+	if len(current) > 0 {
+		for i, preset := range sortPresets {
+			if preset[0].Field == current[0].Field {
+				next = (i + 1) % len(sortPresets)
+				break
+			}
+		}
+	}
+	a.SetSort(sortPresets[next])
+}
+
+// ReverseSort は最優先のキーの向きを逆にする
+func (a *App) ReverseSort() {
+	order := a.stateManager.GetSort()
+	if len(order) == 0 {
+		return
+	}
+	order[0].Descending = !order[0].Descending
+	a.SetSort(order)
+}
+
+// SetViews は数字キーで切り替える保存したビューを設定する
+func (a *App) SetViews(views []model.View) {
+	a.viewBarWidget.SetViews(views)
+	a.resizeViewBar()
+}
+
+// resizeViewBar は保存したビューがあるかプロジェクトを選択している場合にだけビューバーを表示する
+func (a *App) resizeViewBar() {
+	height := 0
+	if len(a.viewBarWidget.GetViews()) > 0 || a.viewBarWidget.GetProject() != nil {
+		height = viewBarHeight
+	}
+	a.listLayout.ResizeItem(a.viewBarWidget.GetPrimitive(), height, 0)
+}
+
+// SelectView は番号のビューの条件でタスクを絞り込む（0の場合はビューによる絞り込みを解除する）
+// ビューの条件は選択した時点の日付で解釈し、検索語などその他のフィルターは保つ
+func (a *App) SelectView(number int) error {
+	filter := a.stateManager.GetCurrentFilter()
+	filter.Where = nil
+	if number != 0 {
+		view, ok := a.viewBarWidget.GetView(number)
+		if !ok {
+			return fmt.Errorf("no view is assigned to key %d", number)
+		}
+		viewFilter, err := service.ViewFilter(view, time.Now())
+		if err != nil {
+			return err
+		}
+		filter.Where = viewFilter.Where
+	}
+
+	a.stateManager.SetFilter(filter)
+	a.viewBarWidget.SetActive(number)
+
+	// 検索と同様に、表示を遅らせないようウィジェットにも直接適用する
+	a.taskListWidget.ApplyFilter(filter)
+	a.searchBarWidget.SetMatchCount(a.taskListWidget.GetMatchCount())
+	return nil
+}
+
+// GetActiveView は選択中のビューの番号を取得する（0はすべてのタスク）
+func (a *App) GetActiveView() int {
+	return a.viewBarWidget.GetActive()
+}
+
+// selectViewByKey は数字キーでビューを切り替える（ビューが割り当てられていないキーは無視する）
+func (a *App) selectViewByKey(number int) {
+	if _, ok := a.viewBarWidget.GetView(number); number != 0 && !ok {
+		return
+	}
+	a.report(a.SelectView(number), "")
+}
+
+// SelectProject はプロジェクトのタスクだけを表示し、新しいタスクをそのプロジェクトに作成する
+// project が nil の場合はすべてのプロジェクトのタスクを表示し、新しいタスクは既定のプロジェクトに作成する
+func (a *App) SelectProject(project *model.Project) {
+	filter := a.stateManager.GetCurrentFilter()
+	filter.ProjectID = ""
+	if project != nil {
+		filter.ProjectID = project.ID
+	}
+	
+	a.stateManager.SetFilter(filter)
+	a.viewBarWidget.SetProject(project)
+	a.resizeViewBar()
+	
+	// ビューと同様に、表示を遅らせないようウィジェットにも直接適用する
+	a.taskListWidget.ApplyFilter(filter)
+	a.searchBarWidget.SetMatchCount(a.taskListWidget.GetMatchCount())
+}
+
+// GetCurrentProject は選択中のプロジェクトを取得する（nil はすべてのプロジェクト）
+func (a *App) GetCurrentProject() *model.Project {
+	return a.viewBarWidget.GetProject()
+}
+
+// currentProjectID は選択中のプロジェクトのIDを返す（すべてのプロジェクトの場合は空文字列）
+func (a *App) currentProjectID() string {
+	if project := a.GetCurrentProject(); project != nil {
+		return project.ID
+	}
+	return ""
+}
+
+// StartSwitchProject はプロジェクトごとの集計を表示し、選んだプロジェクトに切り替える
+func (a *App) StartSwitchProject() {
+	stats, err := a.taskService.GetProjectStats(a.ctx)
+	if err != nil {
+		a.report(fmt.Errorf("failed to load projects: %w", err), "")
+		return
+	}
+	
+	lines := []string{"Switch project"}
+	choices := []string{"All"}
+	for _, stat := range stats {
+		lines = append(lines, tview.Escape(fmt.Sprintf("%s: %d/%d done, %d in progress, %d overdue",
+			stat.Project.Name, stat.Completed, stat.Total, stat.InProgress, stat.Overdue)))
+		choices = append(choices, tview.Escape(stat.Project.Name))
+	}
+	
+	a.dialogs.Choose(strings.Join(lines, "\n"), choices, func(index int) {
+		switch {
+		case index == 0:
+			a.SelectProject(nil)
+		case index > 0:
+			a.SelectProject(stats[index-1].Project)
+		}
+	})
+}
+
+// MoveSelectedTask は選択されたタスクをサブタスクごと、選んだプロジェクトに移動する
+func (a *App) MoveSelectedTask() {
+	selectedTask := a.taskListWidget.GetSelectedTask()
+	if selectedTask == nil {
+		return
+	}
+	
+	stats, err := a.taskService.GetProjectStats(a.ctx)
+	if err != nil {
+		a.report(fmt.Errorf("failed to load projects: %w", err), "")
+		return
+	}
+	
+	var targets []*model.Project
+	var choices []string
+	for _, stat := range stats {
+		if stat.Project.ID != selectedTask.ProjectID {
+			targets = append(targets, stat.Project)
+			choices = append(choices, tview.Escape(stat.Project.Name))
+		}
+	}
+	if len(targets) == 0 {
+		a.dialogs.ShowToast("No other project to move to; create one with task-cli project add")
 		return
 	}
+	
+	text := tview.Escape(fmt.Sprintf("Move %q to which project?", selectedTask.Title))
+	a.dialogs.Choose(text, append(choices, "Cancel"), func(index int) {
+		if index >= 0 && index < len(targets) {
+			target := targets[index]
+			a.report(a.HandleMoveTask(selectedTask.ID, target.ID), fmt.Sprintf("Moved %q to %s", selectedTask.Title, target.Name))
+		}
+	})
+}
+
+// HandleMoveTask はタスクをサブタスクごと別のプロジェクトに移動する
+func (a *App) HandleMoveTask(taskID, projectID string) error {
+	if _, err := a.taskService.MoveTasks(a.ctx, projectID, taskID); err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+	
+	return a.RefreshTasks()
 }
 
 // handleFormSubmit はフォーム送信を処理する
@@ -308,6 +915,11 @@ func (a *App) handleFormSubmit(data FormData) {
 	}
 	
 	a.SwitchToListView()
+	if a.editingTaskID == "" {
+		a.dialogs.ShowToast(fmt.Sprintf("Created %q", data.Title))
+	} else {
+		a.dialogs.ShowToast(fmt.Sprintf("Updated %q", data.Title))
+	}
 }
 
 // handleFormCancel はフォームキャンセルを処理する
@@ -323,6 +935,8 @@ func (a *App) HandleCreateTask(data FormData) error {
 		Description: data.Description,
 		Priority:    data.Priority,
 		Tags:        data.Tags,
+		DueDate:     data.DueDate,
+		ProjectID:   a.currentProjectID(),
 	}
 	
 	_, err := a.taskService.CreateTask(a.ctx, request)
@@ -334,6 +948,7 @@ func (a *App) HandleCreateTask(data FormData) error {
 }
 
 // HandleUpdateTask は既存のタスクを更新する
+// 編集開始後にタスクが変更されていた場合は上書きせずにエラーを返す
 func (a *App) HandleUpdateTask(taskID string, data FormData) error {
 	request := service.UpdateTaskRequest{
 		ID:          taskID,
@@ -342,9 +957,18 @@ func (a *App) HandleUpdateTask(taskID string, data FormData) error {
 		Priority:    data.Priority,
 		Status:      data.Status,
 		Tags:        data.Tags,
+		DueDate:     data.DueDate,
+	}
+	if taskID == a.editingTaskID {
+		request.ExpectedRevision = a.editingRevision
 	}
 	
 	_, err := a.taskService.UpdateTask(a.ctx, request)
+	if errors.Is(err, service.ErrConflict) {
+		// 一覧を最新の状態にしておき、再度編集できるようにする
+		a.RefreshTasks()
+		return errors.New("this task changed since you opened it; press Escape and edit it again to see the latest version")
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
@@ -362,6 +986,26 @@ func (a *App) HandleDeleteTask(taskID string) error {
 	return a.RefreshTasks()
 }
 
+// HandleDeleteTaskWithPolicy は指定したサブタスクの扱いでタスクを削除する
+func (a *App) HandleDeleteTaskWithPolicy(taskID string, policy service.ChildrenPolicy) error {
+	err := a.taskService.DeleteTasksWithPolicy(a.ctx, policy, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	
+	return a.RefreshTasks()
+}
+
+// HandleCompleteTask は指定したオプションでタスクを完了にする
+func (a *App) HandleCompleteTask(taskID string, options service.CompleteOptions) error {
+	_, err := a.taskService.CompleteTask(a.ctx, taskID, options)
+	if err != nil {
+		return fmt.Errorf("failed to complete task: %w", err)
+	}
+	
+	return a.RefreshTasks()
+}
+
 // HandleToggleTask はタスクのステータスを切り替える
 func (a *App) HandleToggleTask(taskID string) error {
 	_, err := a.taskService.ToggleTaskStatus(a.ctx, taskID)
@@ -372,6 +1016,52 @@ func (a *App) HandleToggleTask(taskID string) error {
 	return a.RefreshTasks()
 }
 
+// HandleUndo は最後の変更を元に戻してタスクを再読み込みし、元に戻した操作を返す
+func (a *App) HandleUndo() (*model.Operation, error) {
+	operation, err := a.taskService.Undo(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	return operation, a.RefreshTasks()
+}
+
+// HandleRedo は最後に元に戻した変更をやり直してタスクを再読み込みし、やり直した操作を返す
+func (a *App) HandleRedo() (*model.Operation, error) {
+	operation, err := a.taskService.Redo(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	return operation, a.RefreshTasks()
+}
+
+// UndoLastChange は最後の変更を元に戻して結果を知らせる
+func (a *App) UndoLastChange() {
+	operation, err := a.HandleUndo()
+	switch {
+	case errors.Is(err, service.ErrNothingToUndo):
+		a.dialogs.ShowToast("Nothing to undo")
+	case err != nil:
+		a.report(err, "")
+	default:
+		a.dialogs.ShowToast("Undid " + operation.Description)
+	}
+}
+
+// RedoLastChange は最後に元に戻した変更をやり直して結果を知らせる
+func (a *App) RedoLastChange() {
+	operation, err := a.HandleRedo()
+	switch {
+	case errors.Is(err, service.ErrNothingToRedo):
+		a.dialogs.ShowToast("Nothing to redo")
+	case err != nil:
+		a.report(err, "")
+	default:
+		a.dialogs.ShowToast("Redid " + operation.Description)
+	}
+}
+
 // ApplyFilter はフィルターを適用する
 func (a *App) ApplyFilter(filter service.TaskFilter) {
 	a.stateManager.SetFilter(filter)
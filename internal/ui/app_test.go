@@ -1,15 +1,20 @@
 package ui
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"task-cli/internal/model"
 	"task-cli/internal/service"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockTaskService はTaskServiceのモック実装
@@ -38,6 +43,19 @@ func (m *MockTaskService) DeleteTask(ctx context.Context, taskID string) error {
 	return args.Error(0)
 }
 
+func (m *MockTaskService) DeleteTasksWithPolicy(ctx context.Context, policy service.ChildrenPolicy, taskIDs ...string) error {
+	args := m.Called(ctx, policy, taskIDs)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) CompleteTask(ctx context.Context, taskID string, options service.CompleteOptions) (*model.Task, error) {
+	args := m.Called(ctx, taskID, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Task), args.Error(1)
+}
+
 func (m *MockTaskService) ToggleTaskStatus(ctx context.Context, taskID string) (*model.Task, error) {
 	args := m.Called(ctx, taskID)
 	if args.Get(0) == nil {
@@ -66,6 +84,38 @@ func (m *MockTaskService) GetTasksByPriority(ctx context.Context, priority model
 	return args.Get(0).([]*model.Task), args.Error(1)
 }
 
+func (m *MockTaskService) Undo(ctx context.Context) (*model.Operation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Operation), args.Error(1)
+}
+
+func (m *MockTaskService) Redo(ctx context.Context) (*model.Operation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Operation), args.Error(1)
+}
+
+func (m *MockTaskService) GetProjectStats(ctx context.Context) ([]service.ProjectStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.ProjectStats), args.Error(1)
+}
+
+func (m *MockTaskService) MoveTasks(ctx context.Context, projectID string, taskIDs ...string) ([]*model.Task, error) {
+	args := m.Called(ctx, projectID, taskIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Task), args.Error(1)
+}
+
 // RED: メインAppのテスト
 func TestApp_New_ShouldCreateApp(t *testing.T) {
 	// Given
@@ -243,6 +293,30 @@ func TestApp_HandleUpdateTask_ShouldUpdateExistingTask(t *testing.T) {
 	mockTaskService.AssertExpectations(t)
 }
 
+func TestApp_HandleUpdateTask_WhenTaskChanged_ShouldReportConflict(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+
+	// 編集開始時のリビジョンを保持している状態
+	app.editingTaskID = "existing-id"
+	app.editingRevision = 1
+
+	conflict := &service.ConflictError{TaskID: "existing-id", ExpectedRevision: 1, ActualRevision: 2}
+	mockTaskService.On("UpdateTask", mock.Anything, mock.MatchedBy(func(r service.UpdateTaskRequest) bool {
+		return r.ExpectedRevision == 1
+	})).Return(nil, conflict)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{}, nil)
+
+	// When
+	err := app.HandleUpdateTask("existing-id", FormData{Title: "Stale", Priority: model.PriorityLow})
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "this task changed since you opened it")
+	mockTaskService.AssertExpectations(t)
+}
+
 func TestApp_HandleDeleteTask_ShouldRemoveTask(t *testing.T) {
 	// Given
 	mockTaskService := &MockTaskService{}
@@ -346,4 +420,780 @@ func TestApp_GetCurrentView_ShouldReturnActiveView(t *testing.T) {
 	// フォームビューに切り替えてテスト
 	app.SwitchToFormView()
 	assert.Equal(t, ViewModeForm, app.GetCurrentView())
-}
\ No newline at end of file
+}
+
+// newSubtaskFixture は親と未完了のサブタスクを持つタスク一覧を作成する
+func newSubtaskFixture() (parent, child *model.Task, tasks []*model.Task) {
+	parent = &model.Task{ID: "parent", Title: "Parent", Status: model.StatusTodo, Priority: model.PriorityMedium}
+	child = &model.Task{ID: "child", Title: "Child", Status: model.StatusTodo, Priority: model.PriorityMedium, ParentID: "parent"}
+	return parent, child, []*model.Task{parent, child}
+}
+
+func TestApp_DeleteTask_WithSubtasks_ShouldAskBeforeDeleting(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	parent, _, tasks := newSubtaskFixture()
+	app.stateManager.SetTasks(tasks)
+
+	// When
+	app.deleteTask(parent)
+
+	// Then - 削除せずに確認ダイアログを表示する
+	front, _ := app.pages.GetFrontPage()
+	assert.Equal(t, choicePage, front)
+	assert.Equal(t, ViewModeDialog, app.GetCurrentView())
+	mockTaskService.AssertNotCalled(t, "DeleteTask", mock.Anything, mock.Anything)
+	mockTaskService.AssertNotCalled(t, "DeleteTasksWithPolicy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApp_DeleteTask_ShouldEscapeTitleInDialog(t *testing.T) {
+	// Given
+	app := NewApp(&MockTaskService{}, service.NewStateManager(), NewTheme())
+	task := &model.Task{ID: "1", Title: "Fix [red]colors[white]", Status: model.StatusTodo, Priority: model.PriorityLow}
+	app.stateManager.SetTasks([]*model.Task{task})
+
+	// When
+	app.deleteTask(task)
+
+	// Then - タイトルは色タグとして解釈されない
+	assert.Equal(t, `Delete "Fix [red[]colors[white[]"? y/N`, app.dialogs.GetText())
+}
+
+func TestApp_DeleteTask_WithoutSubtasks_ShouldConfirmBeforeDeleting(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	_, child, tasks := newSubtaskFixture()
+	app.stateManager.SetTasks(tasks)
+	mockTaskService.On("DeleteTask", mock.Anything, "child").Return(nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return(tasks[:1], nil)
+
+	// When
+	app.deleteTask(child)
+
+	// Then - 答えるまでは削除しない
+	front, _ := app.pages.GetFrontPage()
+	assert.Equal(t, choicePage, front)
+	assert.Equal(t, `Delete "Child"? y/N`, app.dialogs.GetText())
+	mockTaskService.AssertNotCalled(t, "DeleteTask", mock.Anything, mock.Anything)
+
+	// When - Yes を選ぶ
+	app.dialogs.Answer(0)
+
+	// Then
+	mockTaskService.AssertExpectations(t)
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+	assert.Equal(t, `Deleted "Child"`, app.dialogs.GetToast())
+}
+
+func TestApp_DeleteTask_WhenDeclined_ShouldKeepTask(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	_, child, tasks := newSubtaskFixture()
+	app.stateManager.SetTasks(tasks)
+
+	// When
+	app.deleteTask(child)
+	app.dialogs.Answer(1)
+
+	// Then
+	mockTaskService.AssertNotCalled(t, "DeleteTask", mock.Anything, mock.Anything)
+	assert.False(t, app.dialogs.IsOpen())
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+	assert.Empty(t, app.dialogs.GetToast())
+}
+
+func TestApp_DeleteTask_WhenServiceFails_ShouldShowAndLogError(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	var errorLog bytes.Buffer
+	app.SetErrorLog(&errorLog)
+	_, child, tasks := newSubtaskFixture()
+	app.stateManager.SetTasks(tasks)
+	mockTaskService.On("DeleteTask", mock.Anything, "child").Return(errors.New("disk full"))
+
+	// When
+	app.deleteTask(child)
+	app.dialogs.Answer(0)
+
+	// Then - 確認ダイアログの代わりにエラーダイアログを表示する
+	assert.True(t, app.dialogs.IsOpen())
+	assert.Contains(t, app.dialogs.GetText(), "disk full")
+	assert.Equal(t, ViewModeDialog, app.GetCurrentView())
+	assert.Contains(t, errorLog.String(), "disk full")
+
+	// When - エラーダイアログを閉じる
+	app.dialogs.Answer(0)
+
+	// Then - リストビューに戻る
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+}
+
+func TestApp_ToggleTask_WhenServiceFails_ShouldShowError(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	_, child, tasks := newSubtaskFixture()
+	app.stateManager.SetTasks(tasks)
+	mockTaskService.On("ToggleTaskStatus", mock.Anything, "child").Return(nil, errors.New("locked"))
+
+	// When
+	app.toggleTask(child)
+
+	// Then
+	assert.True(t, app.dialogs.IsOpen())
+	assert.Contains(t, app.dialogs.GetText(), "locked")
+}
+
+func TestApp_HandleDeleteChoice_ShouldUseChosenPolicy(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	mockTaskService.On("DeleteTasksWithPolicy", mock.Anything, service.ChildrenDelete, []string{"parent"}).Return(nil).Once()
+	mockTaskService.On("DeleteTasksWithPolicy", mock.Anything, service.ChildrenPromote, []string{"parent"}).Return(nil).Once()
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{}, nil)
+
+	// When
+	deleteAllErr := app.handleDeleteChoice("parent", 0)
+	keepErr := app.handleDeleteChoice("parent", 1)
+	cancelErr := app.handleDeleteChoice("parent", -1)
+
+	// Then
+	assert.NoError(t, deleteAllErr)
+	assert.NoError(t, keepErr)
+	assert.NoError(t, cancelErr)
+	mockTaskService.AssertExpectations(t)
+}
+
+func TestApp_ToggleTask_WithOpenSubtasks_ShouldAskBeforeCompleting(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	parent, _, tasks := newSubtaskFixture()
+	app.stateManager.SetTasks(tasks)
+
+	// When
+	app.toggleTask(parent)
+
+	// Then
+	front, _ := app.pages.GetFrontPage()
+	assert.Equal(t, choicePage, front)
+	mockTaskService.AssertNotCalled(t, "ToggleTaskStatus", mock.Anything, mock.Anything)
+}
+
+func TestApp_HandleCompleteChoice_CompleteAll_ShouldIncludeSubtasks(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	parent, _, _ := newSubtaskFixture()
+	mockTaskService.On("CompleteTask", mock.Anything, "parent", service.CompleteOptions{IncludeSubtasks: true}).Return(parent, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{parent}, nil)
+
+	// When
+	err := app.handleCompleteChoice("parent", 0, false)
+
+	// Then
+	assert.NoError(t, err)
+	mockTaskService.AssertExpectations(t)
+}
+
+func TestApp_ToggleTask_WithoutSubtasks_ShouldToggleImmediately(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	_, child, tasks := newSubtaskFixture()
+	app.stateManager.SetTasks(tasks)
+	mockTaskService.On("ToggleTaskStatus", mock.Anything, "child").Return(child, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return(tasks, nil)
+
+	// When
+	app.toggleTask(child)
+
+	// Then
+	mockTaskService.AssertExpectations(t)
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+}
+
+func TestApp_ToggleTask_WithOpenBlocker_ShouldAskBeforeCompleting(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	blocker := &model.Task{ID: "blocker", Title: "Blocker", Status: model.StatusTodo, Priority: model.PriorityMedium}
+	blocked := &model.Task{ID: "blocked", Title: "Blocked", Status: model.StatusTodo, Priority: model.PriorityMedium, BlockedBy: []string{"blocker"}}
+	app.stateManager.SetTasks([]*model.Task{blocker, blocked})
+
+	// When
+	app.toggleTask(blocked)
+
+	// Then
+	front, _ := app.pages.GetFrontPage()
+	assert.Equal(t, choicePage, front)
+	mockTaskService.AssertNotCalled(t, "ToggleTaskStatus", mock.Anything, mock.Anything)
+	mockTaskService.AssertNotCalled(t, "CompleteTask", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApp_CompleteTask_IgnoringBlockers_ShouldForceCompletion(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	blocked := &model.Task{ID: "blocked", Title: "Blocked", Status: model.StatusTodo, Priority: model.PriorityMedium, BlockedBy: []string{"blocker"}}
+	mockTaskService.On("CompleteTask", mock.Anything, "blocked", service.CompleteOptions{IgnoreBlockers: true}).Return(blocked, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{blocked}, nil)
+
+	// When - 「Complete anyway」を選んだ後の処理
+	app.completeTask(blocked, &model.AppData{Tasks: []*model.Task{blocked}}, true)
+
+	// Then
+	mockTaskService.AssertExpectations(t)
+	mockTaskService.AssertNotCalled(t, "ToggleTaskStatus", mock.Anything, mock.Anything)
+}
+
+func TestApp_HandleUpdateTask_ShouldKeepDueDateFromForm(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	updated := &model.Task{ID: "existing-id", Title: "Task", DueDate: &due}
+
+	mockTaskService.On("UpdateTask", mock.Anything, mock.MatchedBy(func(r service.UpdateTaskRequest) bool {
+		return r.DueDate != nil && r.DueDate.Equal(due)
+	})).Return(updated, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{updated}, nil)
+
+	// When
+	err := app.HandleUpdateTask("existing-id", FormData{Title: "Task", Priority: model.PriorityMedium, DueDate: &due})
+
+	// Then
+	assert.NoError(t, err)
+	mockTaskService.AssertExpectations(t)
+}
+
+func TestApp_CtrlS_WithInvalidDueDate_ShouldKeepFormOpenWithoutUpdating(t *testing.T) {
+	// Given - 期限日のあるタスクを編集し、解釈できない期限日を入力する
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	app.taskListWidget.SetTasks([]*model.Task{{ID: "1", Title: "Task", Status: model.StatusTodo, Priority: model.PriorityMedium, DueDate: &due}})
+	app.StartEditTask()
+	app.inputFormWidget.dueField.SetText("nxt fri")
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModNone))
+
+	// Then - 期限日を消さずにエラーを表示する
+	assert.Equal(t, ViewModeForm, app.GetCurrentView())
+	assert.NotEmpty(t, app.inputFormWidget.GetErrorMessage())
+	mockTaskService.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
+
+func TestApp_CtrlS_WithValidForm_ShouldUpdateTask(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	task := &model.Task{ID: "1", Title: "Task", Status: model.StatusTodo, Priority: model.PriorityMedium}
+	app.taskListWidget.SetTasks([]*model.Task{task})
+	mockTaskService.On("UpdateTask", mock.Anything, mock.MatchedBy(func(r service.UpdateTaskRequest) bool {
+		return r.ID == "1" && r.Title == "Renamed"
+	})).Return(task, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{task}, nil)
+	app.StartEditTask()
+	app.inputFormWidget.SetTitle("Renamed")
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModNone))
+
+	// Then
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+	mockTaskService.AssertExpectations(t)
+}
+
+func TestApp_QuickAddKey_ShouldOpenQuickAddBar(t *testing.T) {
+	// Given
+	app := NewApp(&MockTaskService{}, service.NewStateManager(), NewTheme())
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone))
+
+	// Then - バーが表示され、以降のキー入力はバーに渡る
+	assert.Equal(t, ViewModeQuickAdd, app.GetCurrentView())
+	event := tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone)
+	assert.Equal(t, event, app.handleKeyPress(event))
+}
+
+func TestApp_HandleQuickAdd_ShouldCreateParsedTask(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	created := &model.Task{ID: "new-id", Title: "Fix login bug", Priority: model.PriorityHigh}
+
+	mockTaskService.On("CreateTask", mock.Anything, mock.MatchedBy(func(r service.CreateTaskRequest) bool {
+		return r.Title == "Fix login bug" && r.Priority == model.PriorityHigh &&
+			assert.ObjectsAreEqual([]string{"auth", "@work"}, r.Tags) && r.Estimate == 120 && r.DueDate != nil
+	})).Return(created, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{created}, nil)
+	app.StartQuickAdd()
+
+	// When
+	app.quickAddWidget.SetText("Fix login bug #auth !high due:fri @work est:2h")
+	app.quickAddWidget.Submit()
+
+	// Then - 作成後はバーを閉じてリストに戻る
+	mockTaskService.AssertExpectations(t)
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+	assert.Empty(t, app.quickAddWidget.GetText())
+}
+
+func TestApp_HandleQuickAdd_WithInvalidToken_ShouldKeepBarOpenWithError(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	app.StartQuickAdd()
+
+	// When
+	app.quickAddWidget.SetText("Fix bug !urgent")
+	app.quickAddWidget.Submit()
+
+	// Then
+	assert.Equal(t, ViewModeQuickAdd, app.GetCurrentView())
+	assert.Contains(t, app.quickAddWidget.GetErrorMessage(), `column 9: "!urgent"`)
+	assert.Equal(t, "Fix bug !urgent", app.quickAddWidget.GetText())
+	mockTaskService.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+// newSearchApp は検索用のタスクを表示したアプリケーションを作成する
+func newSearchApp() *App {
+	app := NewApp(&MockTaskService{}, service.NewStateManager(), NewTheme())
+	app.taskListWidget.SetTasks([]*model.Task{
+		{ID: "1", Title: "Fix login bug", Status: model.StatusTodo, Priority: model.PriorityHigh},
+		{ID: "2", Title: "Write docs", Status: model.StatusTodo, Priority: model.PriorityLow},
+		{ID: "3", Title: "Bug bash", Status: model.StatusTodo, Priority: model.PriorityMedium},
+	})
+	return app
+}
+
+func TestApp_SearchKey_ShouldFilterAsYouType(t *testing.T) {
+	// Given
+	app := newSearchApp()
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone))
+	app.searchBarWidget.SetText("bu")
+
+	// Then
+	assert.Equal(t, ViewModeSearch, app.GetCurrentView())
+	assert.Equal(t, "bu", app.GetCurrentFilter().Query)
+	assert.Equal(t, 2, app.taskListWidget.GetTaskCount())
+	assert.Equal(t, "1", app.taskListWidget.GetSelectedTask().ID)
+	assert.Equal(t, "2 matches", app.searchBarWidget.GetMatchText())
+}
+
+func TestApp_SyncTaskList_ShouldUseLatestFilterAndUpdateMatchCount(t *testing.T) {
+	// Given - 検索中にタスクが読み込み直される
+	app := newSearchApp()
+	app.StartSearch()
+	app.searchBarWidget.SetText("bug")
+	app.stateManager.SetTasks([]*model.Task{
+		{ID: "1", Title: "Fix login bug", Status: model.StatusTodo, Priority: model.PriorityHigh},
+		{ID: "2", Title: "Write docs", Status: model.StatusTodo, Priority: model.PriorityLow},
+		{ID: "3", Title: "Bug bash", Status: model.StatusTodo, Priority: model.PriorityMedium},
+		{ID: "4", Title: "Triage bug reports", Status: model.StatusTodo, Priority: model.PriorityLow},
+	})
+
+	// When
+	app.syncTaskList()
+
+	// Then - 検索語を保ったまま一致件数も更新する
+	assert.Equal(t, 3, app.taskListWidget.GetTaskCount())
+	assert.Equal(t, "3 matches", app.searchBarWidget.GetMatchText())
+}
+
+func TestApp_SearchNavigation_ShouldJumpBetweenMatchesWithNAndShiftN(t *testing.T) {
+	// Given - 検索語を確定してリストに戻る
+	app := newSearchApp()
+	app.StartSearch()
+	app.searchBarWidget.SetText("bug")
+	app.searchBarWidget.Submit()
+	require.Equal(t, ViewModeList, app.GetCurrentView())
+
+	// When & Then - 検索中の n は新規作成ではなく次の一致に移動する
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone))
+	assert.Equal(t, "3", app.taskListWidget.GetSelectedTask().ID)
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'N', tcell.ModNone))
+	assert.Equal(t, "1", app.taskListWidget.GetSelectedTask().ID)
+}
+
+func TestApp_NKey_WithoutSearch_ShouldStartCreateTask(t *testing.T) {
+	// Given
+	app := newSearchApp()
+	require.False(t, app.IsSearchActive())
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone))
+
+	// Then - 検索していないときの n は新規作成で、ヘルプにもそう表示する
+	assert.Equal(t, ViewModeForm, app.GetCurrentView())
+	assert.Equal(t, listHelpText, app.statusBarWidget.GetText())
+}
+
+func TestApp_Search_ShouldShowSearchKeysInStatusBar(t *testing.T) {
+	// Given
+	app := newSearchApp()
+	app.StartSearch()
+	app.searchBarWidget.SetText("bug")
+	app.searchBarWidget.Submit()
+
+	// Then - 検索中は n が次の一致に移動することをヘルプに表示する
+	assert.Equal(t, searchHelpText, app.statusBarWidget.GetText())
+	assert.Contains(t, app.statusBarWidget.GetText(), "n/N=Next/Prev match")
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+
+	// Then - 検索を解除するとリストのヘルプに戻る
+	assert.Equal(t, listHelpText, app.statusBarWidget.GetText())
+}
+
+func TestApp_SearchEscape_ShouldClearFilterAndHighlight(t *testing.T) {
+	// Given
+	app := newSearchApp()
+	app.StartSearch()
+	app.searchBarWidget.SetText("docs")
+	app.searchBarWidget.Submit()
+
+	// When - 検索中の Esc は終了せずに検索を解除する
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+
+	// Then
+	assert.False(t, app.IsSearchActive())
+	assert.Empty(t, app.GetCurrentFilter().Query)
+	assert.Empty(t, app.searchBarWidget.GetText())
+	assert.Equal(t, 3, app.taskListWidget.GetTaskCount())
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+}
+
+func TestApp_SearchBarCancel_ShouldClearSearch(t *testing.T) {
+	// Given
+	app := newSearchApp()
+	app.StartSearch()
+	app.searchBarWidget.SetText("docs")
+
+	// When
+	app.searchBarWidget.Cancel()
+
+	// Then
+	assert.False(t, app.IsSearchActive())
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+}
+
+// newViewApp は保存したビューを設定したテスト用のAppを作成する
+func newViewApp() *App {
+	app := newSearchApp()
+	app.SetViews([]model.View{
+		{Name: "Urgent", Query: "priority:high"},
+		{Name: "Not urgent", Query: "-priority:high"},
+		{Name: "Broken", Query: "priority>urgent"},
+	})
+	return app
+}
+
+func TestApp_ViewKey_ShouldFilterByView(t *testing.T) {
+	// Given
+	app := newViewApp()
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, '2', tcell.ModNone))
+
+	// Then
+	assert.Equal(t, 2, app.GetActiveView())
+	require.NotNil(t, app.GetCurrentFilter().Where)
+	assert.Equal(t, "-priority:high", app.GetCurrentFilter().Where.Source())
+	assert.Equal(t, 2, app.taskListWidget.GetTaskCount())
+}
+
+func TestApp_ViewKey_Zero_ShouldShowAllTasks(t *testing.T) {
+	// Given
+	app := newViewApp()
+	require.NoError(t, app.SelectView(1))
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, '0', tcell.ModNone))
+
+	// Then
+	assert.Equal(t, 0, app.GetActiveView())
+	assert.Nil(t, app.GetCurrentFilter().Where)
+	assert.Equal(t, 3, app.taskListWidget.GetTaskCount())
+}
+
+func TestApp_SelectView_ShouldKeepSearch(t *testing.T) {
+	// Given
+	app := newViewApp()
+	app.UpdateSearch("bu")
+
+	// When
+	err := app.SelectView(1)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "bu", app.GetCurrentFilter().Query)
+	assert.Equal(t, 1, app.taskListWidget.GetTaskCount())
+}
+
+func TestApp_SelectView_WithInvalidQuery_ShouldKeepCurrentView(t *testing.T) {
+	// Given
+	app := newViewApp()
+	require.NoError(t, app.SelectView(1))
+
+	// When
+	err := app.SelectView(3)
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, '3', tcell.ModNone))
+
+	// Then
+	assert.ErrorContains(t, err, `view "Broken"`)
+	assert.Equal(t, 1, app.GetActiveView())
+	assert.Equal(t, ViewModeDialog, app.GetCurrentView())
+}
+
+func TestApp_ViewKey_WithoutView_ShouldBeIgnored(t *testing.T) {
+	// Given
+	app := newViewApp()
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, '7', tcell.ModNone))
+
+	// Then
+	assert.Equal(t, 0, app.GetActiveView())
+	assert.Equal(t, ViewModeList, app.GetCurrentView())
+}
+
+func TestApp_New_ShouldSortByDefaultOrder(t *testing.T) {
+	// When
+	app := newSearchApp()
+
+	// Then
+	assert.True(t, app.taskListWidget.GetSort().Equal(service.DefaultSortOrder()))
+	app.taskListWidget.SelectTask(1)
+	assert.Equal(t, "Bug bash", app.taskListWidget.GetSelectedTask().Title)
+}
+
+func TestApp_SortKey_ShouldCycleSortOrders(t *testing.T) {
+	// Given
+	app := newSearchApp()
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone))
+
+	// Then
+	assert.Equal(t, service.SortByDue, app.GetSort()[0].Field)
+	assert.True(t, app.taskListWidget.GetSort().Equal(app.GetSort()))
+
+	// When - 最後の並び順の次は既定に戻る
+	for range sortPresets[1:] {
+		app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone))
+	}
+
+	// Then
+	assert.True(t, app.GetSort().Equal(service.DefaultSortOrder()))
+}
+
+func TestApp_ReverseSortKey_ShouldFlipPrimaryKeyAndKeepCycling(t *testing.T) {
+	// Given
+	app := newSearchApp()
+	app.SetSort(service.SortOrder{{Field: service.SortByTitle}})
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'S', tcell.ModNone))
+
+	// Then
+	assert.Equal(t, service.SortOrder{{Field: service.SortByTitle, Descending: true}}, app.GetSort())
+	app.taskListWidget.SelectTask(0)
+	assert.Equal(t, "Write docs", app.taskListWidget.GetSelectedTask().Title)
+
+	// When - 向きを逆にしても次の並び順に進む
+	app.CycleSort()
+
+	// Then
+	assert.Equal(t, service.SortByTag, app.GetSort()[0].Field)
+}
+
+func TestApp_SelectTask_ShouldShowDetails(t *testing.T) {
+	// Given
+	app := newSearchApp()
+
+	// When
+	app.taskListWidget.SelectTask(2)
+
+	// Then
+	assert.Equal(t, "Write docs", app.detailPaneWidget.GetTask().Title)
+	assert.Contains(t, app.detailPaneWidget.GetText(), "Write docs")
+}
+
+func TestApp_FilterWithoutMatches_ShouldClearDetails(t *testing.T) {
+	// Given
+	app := newSearchApp()
+
+	// When
+	app.UpdateSearch("no such task")
+
+	// Then
+	assert.Nil(t, app.detailPaneWidget.GetTask())
+}
+
+func TestApp_DetailKey_ShouldToggleDetailPane(t *testing.T) {
+	// Given
+	app := newSearchApp()
+	require.True(t, app.IsDetailPaneVisible())
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'i', tcell.ModNone))
+
+	// Then
+	assert.False(t, app.IsDetailPaneVisible())
+}
+
+func TestApp_LayoutDetailPane_ShouldFollowTerminalWidth(t *testing.T) {
+	// Given
+	app := newSearchApp()
+
+	// When & Then - 広い画面ではリストの横に並べる
+	app.layoutDetailPane(160)
+	assert.True(t, app.detailBeside)
+
+	// When & Then - 狭い画面ではリストの下に並べる
+	app.layoutDetailPane(80)
+	assert.False(t, app.detailBeside)
+}
+
+func TestApp_UndoKey_ShouldUndoAndShowToast(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	restored := &model.Task{ID: "1", Title: "Write docs", Status: model.StatusTodo, Priority: model.PriorityLow}
+	mockTaskService.On("Undo", mock.Anything).Return(&model.Operation{Description: `delete "Write docs"`}, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{restored}, nil)
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone))
+
+	// Then
+	mockTaskService.AssertExpectations(t)
+	assert.Equal(t, `Undid delete "Write docs"`, app.dialogs.GetToast())
+	assert.Len(t, app.stateManager.GetCurrentTasks(), 1)
+}
+
+func TestApp_RedoKey_WithNothingToRedo_ShouldShowToast(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	mockTaskService.On("Redo", mock.Anything).Return(nil, service.ErrNothingToRedo)
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyCtrlR, 0, tcell.ModCtrl))
+
+	// Then - エラーダイアログではなくメッセージで知らせる
+	assert.Equal(t, "Nothing to redo", app.dialogs.GetToast())
+	assert.False(t, app.dialogs.IsOpen())
+}
+
+func TestApp_Undo_WithConflict_ShouldShowError(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	mockTaskService.On("Undo", mock.Anything).Return(nil, fmt.Errorf("cannot undo update \"Write docs\": %w", &service.ConflictError{TaskID: "1", ExpectedRevision: 2, ActualRevision: 3}))
+
+	// When
+	app.UndoLastChange()
+
+	// Then
+	assert.True(t, app.dialogs.IsOpen())
+	assert.Contains(t, app.dialogs.GetText(), "cannot undo")
+}
+
+// newProjectApp は2つのプロジェクトのタスクを表示したテスト用のAppを作成する
+func newProjectApp(mockTaskService *MockTaskService) (*App, []service.ProjectStats) {
+	app := NewApp(mockTaskService, service.NewStateManager(), NewTheme())
+	tasks := []*model.Task{
+		{ID: "1", Title: "Groceries", Status: model.StatusTodo, Priority: model.PriorityLow, ProjectID: model.DefaultProjectID},
+		{ID: "2", Title: "Write report", Status: model.StatusTodo, Priority: model.PriorityHigh, ProjectID: "work"},
+	}
+	app.stateManager.SetTasks(tasks)
+	app.taskListWidget.SetTasks(tasks)
+	stats := []service.ProjectStats{
+		{Project: &model.Project{ID: model.DefaultProjectID, Name: model.DefaultProjectName}, Total: 1, Todo: 1},
+		{Project: &model.Project{ID: "work", Name: "Work", Color: "blue"}, Total: 1, Todo: 1},
+	}
+	mockTaskService.On("GetProjectStats", mock.Anything).Return(stats, nil)
+	return app, stats
+}
+
+func TestApp_ProjectKey_ShouldSwitchToChosenProject(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app, _ := newProjectApp(mockTaskService)
+
+	// When
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone))
+
+	// Then - プロジェクトごとの集計を表示する
+	require.True(t, app.dialogs.IsOpen())
+	assert.Contains(t, app.dialogs.GetText(), "Work: 0/1 done, 0 in progress, 0 overdue")
+
+	// When
+	app.dialogs.Answer(2)
+
+	// Then
+	assert.Equal(t, "work", app.GetCurrentFilter().ProjectID)
+	assert.Equal(t, "Work", app.GetCurrentProject().Name)
+	assert.Equal(t, 1, app.taskListWidget.GetTaskCount())
+	assert.Contains(t, app.viewBarWidget.GetText(), "Work")
+
+	// When - すべてのプロジェクトに戻す
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone))
+	app.dialogs.Answer(0)
+
+	// Then
+	assert.Nil(t, app.GetCurrentProject())
+	assert.Equal(t, 2, app.taskListWidget.GetTaskCount())
+}
+
+func TestApp_HandleCreateTask_WithSelectedProject_ShouldCreateInProject(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app, stats := newProjectApp(mockTaskService)
+	app.SelectProject(stats[1].Project)
+	created := &model.Task{ID: "3", Title: "Review", ProjectID: "work"}
+	mockTaskService.On("CreateTask", mock.Anything, mock.MatchedBy(func(r service.CreateTaskRequest) bool {
+		return r.Title == "Review" && r.ProjectID == "work"
+	})).Return(created, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{created}, nil)
+
+	// When
+	err := app.HandleQuickAdd("Review")
+
+	// Then
+	require.NoError(t, err)
+	mockTaskService.AssertCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestApp_MoveKey_ShouldMoveSelectedTaskToChosenProject(t *testing.T) {
+	// Given
+	mockTaskService := &MockTaskService{}
+	app, _ := newProjectApp(mockTaskService)
+	require.Equal(t, "Write report", app.taskListWidget.GetSelectedTask().Title)
+	moved := &model.Task{ID: "2", Title: "Write report", ProjectID: model.DefaultProjectID}
+	mockTaskService.On("MoveTasks", mock.Anything, model.DefaultProjectID, []string{"2"}).Return([]*model.Task{moved}, nil)
+	mockTaskService.On("GetAllTasks", mock.Anything).Return([]*model.Task{moved}, nil)
+
+	// When - 移動先には今のプロジェクト以外だけを選択肢にする
+	app.handleKeyPress(tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModNone))
+	require.True(t, app.dialogs.IsOpen())
+	app.dialogs.Answer(0)
+
+	// Then
+	mockTaskService.AssertExpectations(t)
+	assert.Equal(t, `Moved "Write report" to default`, app.dialogs.GetToast())
+}
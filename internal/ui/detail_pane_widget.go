@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"task-cli/internal/dateparse"
+	"task-cli/internal/model"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	// detailPaneMinSideWidth はリストの横に詳細ペインを並べる最小の画面幅（これより狭い場合はリストの下に並べる）
+	detailPaneMinSideWidth = 100
+
+	// detailPaneBottomHeight はリストの下に並べる場合の詳細ペインの高さ
+	detailPaneBottomHeight = 12
+
+	// detailTimestampLayout は詳細ペインに表示する日時のフォーマット
+	detailTimestampLayout = "2006-01-02 15:04"
+)
+
+// DetailPaneWidget は選択中のタスクの詳細（説明の全文、タグ、日時、依存関係など）を表示するウィジェット
+type DetailPaneWidget struct {
+	textView *tview.TextView
+	theme    *Theme
+	task     *model.Task
+}
+
+// NewDetailPaneWidget は新しいDetailPaneWidgetを作成する
+func NewDetailPaneWidget(theme *Theme) *DetailPaneWidget {
+	widget := &DetailPaneWidget{theme: theme}
+
+	widget.textView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetWordWrap(true).
+		SetScrollable(true).
+		SetTextColor(theme.GetForegroundColor())
+	widget.textView.SetBorder(true).
+		SetTitle(" Details ").
+		SetTitleColor(theme.GetHighlightColor()).
+		SetBorderColor(theme.GetBorderColor()).
+		SetBackgroundColor(theme.GetBackgroundColor())
+
+	widget.SetTask(nil, nil)
+	return widget
+}
+
+// GetPrimitive はtview.Primitiveインターフェースを実装
+func (w *DetailPaneWidget) GetPrimitive() tview.Primitive {
+	return w.textView
+}
+
+// SetTask は表示するタスクを設定する（nilの場合はタスクが選択されていないことを表示する）
+// hierarchy は親子関係と依存関係の表示に使う（nilの場合は表示しない）
+func (w *DetailPaneWidget) SetTask(task *model.Task, hierarchy *model.AppData) {
+	w.task = task
+	if task == nil {
+		w.textView.SetText("No task selected")
+		return
+	}
+	if hierarchy == nil {
+		hierarchy = &model.AppData{Tasks: []*model.Task{task}}
+	}
+
+	w.textView.SetText(w.render(task, hierarchy, time.Now()))
+	w.textView.ScrollToBeginning()
+}
+
+// GetTask は表示中のタスクを返す
+func (w *DetailPaneWidget) GetTask() *model.Task {
+	return w.task
+}
+
+// GetText は表示しているテキストを色指定なしで取得する
+func (w *DetailPaneWidget) GetText() string {
+	return w.textView.GetText(true)
+}
+
+// render はタスクの詳細をスタイルタグ付きのテキストにする
+func (w *DetailPaneWidget) render(task *model.Task, hierarchy *model.AppData, now time.Time) string {
+	var b strings.Builder
+	field := func(label, value string) {
+		fmt.Fprintf(&b, "%s%-11s[-] %s\n", colorTag(w.theme.GetHighlightColor()), label+":", value)
+	}
+
+	fmt.Fprintf(&b, "[::b]%s[::-]\n\n", tview.Escape(task.Title))
+
+	status := colorTag(w.theme.GetStatusColor(task.Status)) + string(task.Status) + "[-]"
+	if !task.IsCompleted() && hierarchy.IsBlocked(task.ID) {
+		status += " " + blockedSymbol + " blocked"
+	}
+	field("Status", status)
+	field("Priority", colorTag(w.theme.GetPriorityColor(task.Priority))+string(task.Priority)+"[-]")
+	if task.DueDate != nil {
+		due := task.DueDate.Format(dateparse.Layout)
+		if relative := formatDueDate(task.DueDate, now); relative != due {
+			due += " (" + relative + ")"
+		}
+		field("Due", colorTag(w.theme.GetDueColor(task.DueStatus(now)))+due+"[-]")
+	}
+	if task.Estimate > 0 {
+		field("Estimate", model.FormatEstimate(task.Estimate))
+	}
+	if len(task.Tags) > 0 {
+		field("Tags", tview.Escape(strings.Join(task.Tags, ", ")))
+	}
+	if task.Recurrence != nil {
+		field("Repeats", tview.Escape(task.Recurrence.String()))
+	}
+	if task.ParentID != "" {
+		if parent, err := hierarchy.GetTaskByID(task.ParentID); err == nil {
+			field("Parent", tview.Escape(parent.Title))
+		}
+	}
+	if done, total := hierarchy.SubtaskProgress(task.ID); total > 0 {
+		field("Subtasks", fmt.Sprintf("%d/%d done", done, total))
+	}
+	if blockers := hierarchy.GetBlockers(task.ID); len(blockers) > 0 {
+		field("Blocked by", tview.Escape(taskTitles(blockers)))
+	}
+	if dependents := hierarchy.GetDependents(task.ID); len(dependents) > 0 {
+		field("Blocks", tview.Escape(taskTitles(dependents)))
+	}
+
+	b.WriteString("\n")
+	field("ID", task.ID)
+	field("Created", task.CreatedAt.Local().Format(detailTimestampLayout))
+	field("Updated", task.UpdatedAt.Local().Format(detailTimestampLayout))
+	if task.CompletedAt != nil {
+		field("Completed", task.CompletedAt.Local().Format(detailTimestampLayout))
+	}
+	field("Revision", fmt.Sprintf("%d", task.Revision))
+
+	if task.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", tview.Escape(task.Description))
+	}
+
+	// 変更履歴は新しい順に表示する
+	if len(task.Changes) > 0 {
+		fmt.Fprintf(&b, "\n%sHistory[-]\n", colorTag(w.theme.GetHighlightColor()))
+		for i := len(task.Changes) - 1; i >= 0; i-- {
+			change := task.Changes[i]
+			fmt.Fprintf(&b, "%s %s %s\n", change.At.Local().Format(detailTimestampLayout),
+				tview.Escape(change.Actor), change.Action)
+			for _, fieldChange := range change.Fields {
+				fmt.Fprintf(&b, "  %s: %s → %s\n", fieldChange.Field,
+					tview.Escape(historyValue(fieldChange.Old)), tview.Escape(historyValue(fieldChange.New)))
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// historyValue は変更履歴に表示する値を返す（空の場合は (none)）
+func historyValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// taskTitles はタスクのタイトルをカンマ区切りで返す
+func taskTitles(tasks []*model.Task) string {
+	titles := make([]string, len(tasks))
+	for i, task := range tasks {
+		titles[i] = task.Title
+	}
+	return strings.Join(titles, ", ")
+}
+
+// colorTag は前景色を指定するスタイルタグを返す（色が無効な場合は既定の色に戻すタグ）
+func colorTag(color tcell.Color) string {
+	if css := color.CSS(); css != "" {
+		return "[" + css + "]"
+	}
+	return "[-]"
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetailPaneWidget_New_ShouldShowPlaceholder(t *testing.T) {
+	// When
+	widget := NewDetailPaneWidget(NewTheme())
+
+	// Then
+	assert.Implements(t, (*tview.Primitive)(nil), widget.GetPrimitive())
+	assert.Equal(t, "No task selected", widget.GetText())
+	assert.Nil(t, widget.GetTask())
+}
+
+func TestDetailPaneWidget_SetTask_ShouldShowAllFields(t *testing.T) {
+	// Given
+	widget := NewDetailPaneWidget(NewTheme())
+	description := strings.Repeat("long description [not a tag] ", 5)
+	task, _ := model.NewTask("Ship [v2]", description, model.PriorityHigh, []string{"backend", "release"})
+	due := time.Now().AddDate(0, 0, 1)
+	completed := time.Now()
+	task.DueDate = &due
+	task.CompletedAt = &completed
+	task.Estimate = 90
+	task.Revision = 4
+
+	// When
+	widget.SetTask(task, nil)
+
+	// Then
+	text := widget.GetText()
+	assert.Equal(t, task, widget.GetTask())
+	assert.True(t, strings.HasPrefix(text, "Ship [v2]\n"))
+	assert.Contains(t, text, "Priority:   high")
+	assert.Contains(t, text, "Due:        "+due.Format("2006-01-02")+" (tomorrow)")
+	assert.Contains(t, text, "Estimate:   1h30m")
+	assert.Contains(t, text, "Tags:       backend, release")
+	assert.Contains(t, text, "ID:         "+task.ID)
+	assert.Contains(t, text, "Created:    "+task.CreatedAt.Format("2006-01-02 15:04"))
+	assert.Contains(t, text, "Updated:    ")
+	assert.Contains(t, text, "Completed:  ")
+	assert.Contains(t, text, "Revision:   4")
+	assert.Contains(t, text, strings.TrimSpace(description))
+}
+
+func TestDetailPaneWidget_SetTask_WithHierarchy_ShouldShowRelations(t *testing.T) {
+	// Given
+	widget := NewDetailPaneWidget(NewTheme())
+	parent, _ := model.NewTask("Release", "", model.PriorityMedium, nil)
+	design, _ := model.NewTask("Design", "", model.PriorityMedium, nil)
+	build, _ := model.NewTask("Build", "", model.PriorityMedium, nil)
+	child, _ := model.NewTask("Child", "", model.PriorityMedium, nil)
+	build.ParentID = parent.ID
+	child.ParentID = build.ID
+	build.BlockedBy = []string{design.ID}
+	parent.BlockedBy = []string{build.ID}
+	hierarchy := &model.AppData{Tasks: []*model.Task{parent, design, build, child}}
+
+	// When
+	widget.SetTask(build, hierarchy)
+
+	// Then
+	text := widget.GetText()
+	assert.Contains(t, text, "Status:     todo ⊘ blocked")
+	assert.Contains(t, text, "Parent:     Release")
+	assert.Contains(t, text, "Subtasks:   0/1 done")
+	assert.Contains(t, text, "Blocked by: Design")
+	assert.Contains(t, text, "Blocks:     Release")
+}
+
+func TestDetailPaneWidget_SetTask_WithChanges_ShouldShowHistoryNewestFirst(t *testing.T) {
+	// Given
+	widget := NewDetailPaneWidget(NewTheme())
+	task, _ := model.NewTask("Final", "", model.PriorityMedium, nil)
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	updated := created.Add(2 * time.Hour)
+	task.Changes = []model.ChangeEntry{
+		{At: created, Actor: "alice", Action: model.ChangeCreated},
+		{At: updated, Actor: "bob", Action: model.ChangeUpdated, Fields: []model.FieldChange{
+			{Field: "title", Old: "Draft", New: "Final"},
+			{Field: "due_date", Old: "", New: "2026-03-10"},
+		}},
+	}
+
+	// When
+	widget.SetTask(task, nil)
+
+	// Then
+	text := widget.GetText()
+	history := text[strings.Index(text, "History\n"):]
+	assert.Equal(t, "History\n"+
+		"2026-03-01 11:00 bob updated\n"+
+		"  title: Draft → Final\n"+
+		"  due_date: (none) → 2026-03-10\n"+
+		"2026-03-01 09:00 alice created", history)
+}
+
+func TestDetailPaneWidget_SetTask_WithNil_ShouldClear(t *testing.T) {
+	// Given
+	widget := NewDetailPaneWidget(NewTheme())
+	task, _ := model.NewTask("Task", "", model.PriorityLow, nil)
+	widget.SetTask(task, nil)
+
+	// When
+	widget.SetTask(nil, nil)
+
+	// Then
+	assert.Equal(t, "No task selected", widget.GetText())
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"io"
+	"log"
+	"time"
+
+	"task-cli/internal/model"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// choicePage はダイアログのページ名
+const choicePage = "choice"
+
+// toastDuration はトーストを表示しておく時間
+const toastDuration = 3 * time.Second
+
+// confirmChoices は確認ダイアログの選択肢（既定は No）
+var confirmChoices = []string{"Yes", "No"}
+
+// DialogManager はモーダルダイアログ（エラー、確認、選択肢）とトーストを表示する
+// ダイアログは同時に1つだけ表示し、新しいダイアログは表示中のものを置き換える
+type DialogManager struct {
+	tviewApp      *tview.Application
+	pages         *tview.Pages
+	statusBar     *StatusBarWidget
+	theme         *Theme
+	logger        *log.Logger
+	text          string
+	done          func(index int)
+	toastID       int
+	openCallback  func()
+	closeCallback func()
+}
+
+// NewDialogManager は新しいDialogManagerを作成する
+// ダイアログは pages に重ねて表示し、トーストは statusBar に表示する
+func NewDialogManager(tviewApp *tview.Application, pages *tview.Pages, statusBar *StatusBarWidget, theme *Theme) *DialogManager {
+	return &DialogManager{
+		tviewApp:  tviewApp,
+		pages:     pages,
+		statusBar: statusBar,
+		theme:     theme,
+	}
+}
+
+// SetErrorLog はエラーを書き込むログの出力先を設定する（nilの場合は書き込まない）
+func (d *DialogManager) SetErrorLog(w io.Writer) {
+	if w == nil {
+		d.logger = nil
+		return
+	}
+	d.logger = log.New(w, "", log.LstdFlags)
+}
+
+// SetOpenCallback はダイアログを表示したときのコールバックを設定する
+func (d *DialogManager) SetOpenCallback(callback func()) {
+	d.openCallback = callback
+}
+
+// SetCloseCallback はダイアログを閉じたときのコールバックを設定する（選択肢のコールバックより先に呼ばれる）
+func (d *DialogManager) SetCloseCallback(callback func()) {
+	d.closeCallback = callback
+}
+
+// Choose は選択肢を持つダイアログを表示し、選ばれたボタンのインデックス（Escapeの場合は-1）を callback に渡す
+// text とボタンのラベルは色タグとして解釈されるため、タスクのタイトルなどは tview.Escape してから渡す
+func (d *DialogManager) Choose(text string, buttons []string, callback func(index int)) {
+	d.show(text, d.newModal(text, buttons), callback)
+}
+
+// Confirm は Yes/No を確認するダイアログを表示する（y/n キーでも答えられ、Escape は No とする）
+func (d *DialogManager) Confirm(text string, callback func(confirmed bool)) {
+	modal := d.newModal(text, confirmChoices).SetFocus(1)
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'y', 'Y':
+			d.Answer(0)
+			return nil
+		case 'n', 'N':
+			d.Answer(1)
+			return nil
+		}
+		return event
+	})
+	d.show(text, modal, func(index int) {
+		callback(index == 0)
+	})
+}
+
+// ShowError はエラーをダイアログで表示し、ログに書き込む
+func (d *DialogManager) ShowError(err error) {
+	if err == nil {
+		return
+	}
+	if d.logger != nil {
+		d.logger.Printf("error: %v", err)
+	}
+
+	text := "Error\n\n" + tview.Escape(err.Error())
+	modal := d.newModal(text, []string{"OK"}).
+		SetTextColor(d.theme.GetPriorityColor(model.PriorityHigh))
+	d.show(text, modal, func(int) {})
+}
+
+// ShowWarning は操作は成功したが付随する処理に失敗したことをトーストで知らせ、ログに書き込む
+func (d *DialogManager) ShowWarning(err error) {
+	if err == nil {
+		return
+	}
+	if d.logger != nil {
+		d.logger.Printf("warning: %v", err)
+	}
+	d.ShowToast("Warning: " + err.Error())
+}
+
+// ShowToast はメッセージを一時的に表示する（新しいメッセージは前のものを置き換える）
+func (d *DialogManager) ShowToast(message string) {
+	d.toastID++
+	id := d.toastID
+	d.statusBar.ShowMessage(message)
+
+	// 表示中のトーストが後から表示したものに置き換わっていなければ消す
+	time.AfterFunc(toastDuration, func() {
+		d.tviewApp.QueueUpdateDraw(func() {
+			if d.toastID == id {
+				d.statusBar.ClearMessage()
+			}
+		})
+	})
+}
+
+// GetToast は表示中のトーストを取得する（ない場合は空文字列）
+func (d *DialogManager) GetToast() string {
+	return d.statusBar.GetMessage()
+}
+
+// IsOpen はダイアログを表示しているかを返す
+func (d *DialogManager) IsOpen() bool {
+	return d.done != nil
+}
+
+// GetText は表示中のダイアログのテキストを取得する（表示していない場合は空文字列）
+func (d *DialogManager) GetText() string {
+	return d.text
+}
+
+// Answer は表示中のダイアログでインデックスのボタンを選んだものとして閉じる（-1はキャンセル）
+func (d *DialogManager) Answer(index int) {
+	done := d.done
+	if done == nil {
+		return
+	}
+
+	d.pages.RemovePage(choicePage)
+	d.text = ""
+	d.done = nil
+	if d.closeCallback != nil {
+		d.closeCallback()
+	}
+	done(index)
+}
+
+// newModal はテーマに合わせたモーダルを作成する
+func (d *DialogManager) newModal(text string, buttons []string) *tview.Modal {
+	return tview.NewModal().
+		SetText(text).
+		AddButtons(buttons).
+		SetDoneFunc(func(index int, label string) {
+			d.Answer(index)
+		})
+}
+
+// show はモーダルを前面に表示してフォーカスを移す
+func (d *DialogManager) show(text string, modal *tview.Modal, done func(index int)) {
+	d.text = text
+	d.done = done
+	d.pages.AddPage(choicePage, modal, true, true)
+	if d.openCallback != nil {
+		d.openCallback()
+	}
+	d.tviewApp.SetFocus(modal)
+}
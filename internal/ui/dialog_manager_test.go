@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestDialogManager はテスト用のDialogManagerを作成する
+func newTestDialogManager() *DialogManager {
+	theme := NewTheme()
+	return NewDialogManager(tview.NewApplication(), tview.NewPages(), NewStatusBarWidget(theme, "help"), theme)
+}
+
+// pressKey は表示中のダイアログにキー入力を送る
+func pressKey(d *DialogManager, key tcell.Key, ch rune) {
+	_, primitive := d.pages.GetFrontPage()
+	handler := primitive.InputHandler()
+	if capture := primitive.(*tview.Modal).GetInputCapture(); capture != nil {
+		if event := capture(tcell.NewEventKey(key, ch, tcell.ModNone)); event == nil {
+			return
+		}
+	}
+	handler(tcell.NewEventKey(key, ch, tcell.ModNone), func(p tview.Primitive) {})
+}
+
+func TestDialogManager_Confirm_ShouldAnswerWithKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      tcell.Key
+		ch       rune
+		expected bool
+	}{
+		{name: "y confirms", key: tcell.KeyRune, ch: 'y', expected: true},
+		{name: "n declines", key: tcell.KeyRune, ch: 'n', expected: false},
+		{name: "Enter uses default No", key: tcell.KeyEnter, expected: false},
+		{name: "Escape declines", key: tcell.KeyEscape, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			dialogs := newTestDialogManager()
+			var answers []bool
+			dialogs.Confirm("Delete? y/N", func(confirmed bool) {
+				answers = append(answers, confirmed)
+			})
+
+			// When
+			pressKey(dialogs, tt.key, tt.ch)
+
+			// Then
+			assert.Equal(t, []bool{tt.expected}, answers)
+			assert.False(t, dialogs.IsOpen())
+			assert.False(t, dialogs.pages.HasPage(choicePage))
+		})
+	}
+}
+
+func TestDialogManager_Callbacks_ShouldRunAroundDialog(t *testing.T) {
+	// Given
+	dialogs := newTestDialogManager()
+	var events []string
+	dialogs.SetOpenCallback(func() { events = append(events, "open") })
+	dialogs.SetCloseCallback(func() { events = append(events, "close") })
+
+	// When
+	dialogs.Choose("Pick", []string{"A", "B"}, func(index int) {
+		events = append(events, "chose")
+	})
+	dialogs.Answer(1)
+
+	// Then - 閉じてから選択肢のコールバックを呼ぶ
+	assert.Equal(t, []string{"open", "close", "chose"}, events)
+}
+
+func TestDialogManager_ShowError_ShouldShowDialogAndWriteLog(t *testing.T) {
+	// Given
+	dialogs := newTestDialogManager()
+	var errorLog bytes.Buffer
+	dialogs.SetErrorLog(&errorLog)
+
+	// When
+	dialogs.ShowError(errors.New("failed to delete task: disk full"))
+
+	// Then
+	assert.True(t, dialogs.IsOpen())
+	assert.Equal(t, "Error\n\nfailed to delete task: disk full", dialogs.GetText())
+	assert.Contains(t, errorLog.String(), "error: failed to delete task: disk full")
+}
+
+func TestDialogManager_ShowError_ShouldEscapeColorTags(t *testing.T) {
+	// Given
+	dialogs := newTestDialogManager()
+
+	// When - タスクのタイトルなどに色タグと同じ形の文字列が含まれる
+	dialogs.ShowError(errors.New(`task "[red]urgent[white]" not found`))
+
+	// Then - 色タグとして解釈されずにそのまま表示される
+	assert.Equal(t, "Error\n\n"+`task "[red[]urgent[white[]" not found`, dialogs.GetText())
+}
+
+func TestDialogManager_ShowError_WithNil_ShouldDoNothing(t *testing.T) {
+	// Given
+	dialogs := newTestDialogManager()
+
+	// When
+	dialogs.ShowError(nil)
+
+	// Then
+	assert.False(t, dialogs.IsOpen())
+}
+
+func TestDialogManager_ShowWarning_ShouldShowToastAndWriteLog(t *testing.T) {
+	// Given
+	dialogs := newTestDialogManager()
+	var errorLog bytes.Buffer
+	dialogs.SetErrorLog(&errorLog)
+
+	// When
+	dialogs.ShowWarning(errors.New("failed to save undo history: disk full"))
+
+	// Then - 操作は成功しているため、ダイアログは開かない
+	assert.False(t, dialogs.IsOpen())
+	assert.Equal(t, "Warning: failed to save undo history: disk full", dialogs.GetToast())
+	assert.Contains(t, errorLog.String(), "warning: failed to save undo history: disk full")
+}
+
+func TestDialogManager_ShowToast_ShouldShowMessageInStatusBar(t *testing.T) {
+	// Given
+	dialogs := newTestDialogManager()
+
+	// When
+	dialogs.ShowToast("Saved")
+	dialogs.ShowToast(`Deleted "Write docs"`)
+
+	// Then - 新しいトーストが前のものを置き換える
+	assert.Equal(t, `Deleted "Write docs"`, dialogs.GetToast())
+	assert.Equal(t, `» Deleted "Write docs"`, dialogs.statusBar.GetText())
+	assert.False(t, dialogs.IsOpen())
+}
@@ -3,7 +3,9 @@ package ui
 import (
 	"errors"
 	"strings"
+	"time"
 
+	"task-cli/internal/dateparse"
 	"task-cli/internal/model"
 
 	"github.com/rivo/tview"
@@ -24,6 +26,7 @@ type FormData struct {
 	Priority    model.Priority
 	Status      model.Status
 	Tags        []string
+	DueDate     *time.Time // 期限日（nilの場合は期限なし）
 }
 
 // InputFormWidget はタスク入力フォームのウィジェット
@@ -42,6 +45,7 @@ type InputFormWidget struct {
 	priorityField    *tview.DropDown
 	statusField      *tview.DropDown
 	tagsField        *tview.InputField
+	dueField         *tview.InputField
 	errorLabel       *tview.TextView
 }
 
@@ -98,6 +102,12 @@ func (w *InputFormWidget) initializeFields() {
 		SetFieldWidth(50).
 		SetPlaceholder("Comma separated tags")
 
+	// 期限日フィールド（絶対日付と相対的な表現を受け付ける）
+	w.dueField = tview.NewInputField().
+		SetLabel("Due: ").
+		SetFieldWidth(20).
+		SetPlaceholder("YYYY-MM-DD, tomorrow, fri, +3d")
+
 	// エラーラベル
 	w.errorLabel = tview.NewTextView().
 		SetTextColor(w.theme.GetPriorityColor(model.PriorityHigh)). // 赤色でエラー表示
@@ -112,6 +122,7 @@ func (w *InputFormWidget) setupForm() {
 	w.form.AddFormItem(w.titleField)
 	w.form.AddFormItem(w.descriptionField)
 	w.form.AddFormItem(w.priorityField)
+	w.form.AddFormItem(w.dueField)
 	w.form.AddFormItem(w.tagsField)
 	w.form.AddFormItem(w.errorLabel)
 
@@ -145,6 +156,7 @@ func (w *InputFormWidget) updateFormForMode() {
 	w.form.AddFormItem(w.titleField)
 	w.form.AddFormItem(w.descriptionField)
 	w.form.AddFormItem(w.priorityField)
+	w.form.AddFormItem(w.dueField)
 	
 	// 編集モードの場合はステータスフィールドを追加
 	if w.mode == FormModeEdit {
@@ -176,6 +188,7 @@ func (w *InputFormWidget) LoadTask(task *model.Task) {
 	w.SetPriority(task.Priority)
 	w.SetStatus(task.Status)
 	w.SetTags(strings.Join(task.Tags, ","))
+	w.SetDueDate(task.DueDate)
 }
 
 // SetTitle はタイトルを設定する
@@ -232,6 +245,33 @@ func (w *InputFormWidget) GetTags() string {
 	return w.tagsField.GetText()
 }
 
+// SetDueDate は期限日を設定する（nilの場合は空にする）
+func (w *InputFormWidget) SetDueDate(due *time.Time) {
+	if due == nil {
+		w.dueField.SetText("")
+		return
+	}
+	w.dueField.SetText(due.Format(dateparse.Layout))
+}
+
+// GetDueText は期限日フィールドの入力内容を取得する
+func (w *InputFormWidget) GetDueText() string {
+	return w.dueField.GetText()
+}
+
+// parseDueDate は期限日フィールドの入力を解析する（空の場合はnilを返す）
+func (w *InputFormWidget) parseDueDate() (*time.Time, error) {
+	text := strings.TrimSpace(w.GetDueText())
+	if text == "" {
+		return nil, nil
+	}
+	due, err := dateparse.Parse(text, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &due, nil
+}
+
 // Validate はフォームの入力を検証する
 func (w *InputFormWidget) Validate() error {
 	if strings.TrimSpace(w.GetTitle()) == "" {
@@ -246,6 +286,10 @@ func (w *InputFormWidget) Validate() error {
 		return errors.New("description must be 500 characters or less")
 	}
 	
+	if _, err := w.parseDueDate(); err != nil {
+		return err
+	}
+	
 	return nil
 }
 
@@ -256,6 +300,7 @@ func (w *InputFormWidget) Clear() {
 	w.SetPriority(model.PriorityMedium) // デフォルト値
 	w.SetStatus(model.StatusTodo) // デフォルト値
 	w.SetTags("")
+	w.SetDueDate(nil)
 	w.ClearError()
 }
 
@@ -303,6 +348,7 @@ func (w *InputFormWidget) SetCancelCallback(callback func()) {
 }
 
 // GetFormData はフォームデータを取得する
+// 入力は検証しないため、送信には Validate を行う Submit を使う
 func (w *InputFormWidget) GetFormData() FormData {
 	tags := []string{}
 	if tagText := strings.TrimSpace(w.GetTags()); tagText != "" {
@@ -313,12 +359,16 @@ func (w *InputFormWidget) GetFormData() FormData {
 		}
 	}
 	
+	// 不正な期限日は Submit が Validate で弾くため、検証せずに呼ぶ場合のみ期限なしとして扱われる
+	dueDate, _ := w.parseDueDate()
+	
 	return FormData{
 		Title:       strings.TrimSpace(w.GetTitle()),
 		Description: strings.TrimSpace(w.GetDescription()),
 		Priority:    w.GetPriority(),
 		Status:      w.GetStatus(),
 		Tags:        tags,
+		DueDate:     dueDate,
 	}
 }
 
@@ -2,10 +2,13 @@ package ui
 
 import (
 	"testing"
+	"time"
 
+	"task-cli/internal/dateparse"
 	"task-cli/internal/model"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/rivo/tview"
 )
 
@@ -237,4 +240,61 @@ func TestInputFormWidget_ClearError_ShouldRemoveErrorMessage(t *testing.T) {
 
 	// Then
 	assert.Empty(t, widget.GetErrorMessage())
-}
\ No newline at end of file
+}
+
+func TestInputFormWidget_LoadTask_ShouldKeepDueDate(t *testing.T) {
+	// Given
+	widget := NewInputFormWidget(NewTheme())
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)
+	task, _ := model.NewTask("Task", "", model.PriorityMedium, nil)
+	task.DueDate = &due
+
+	// When
+	widget.LoadTask(task)
+	data := widget.GetFormData()
+
+	// Then
+	assert.Equal(t, "2026-11-01", widget.GetDueText())
+	require.NotNil(t, data.DueDate)
+	assert.True(t, due.Equal(*data.DueDate))
+}
+
+func TestInputFormWidget_GetFormData_WithRelativeDueDate_ShouldResolveDate(t *testing.T) {
+	// Given
+	widget := NewInputFormWidget(NewTheme())
+	widget.SetTitle("Task")
+	widget.dueField.SetText("+3d")
+
+	// When
+	data := widget.GetFormData()
+
+	// Then
+	require.NotNil(t, data.DueDate)
+	assert.Equal(t, time.Now().AddDate(0, 0, 3).Format("2006-01-02"), data.DueDate.Format("2006-01-02"))
+}
+
+func TestInputFormWidget_Validate_WithInvalidDueDate_ShouldReturnError(t *testing.T) {
+	// Given
+	widget := NewInputFormWidget(NewTheme())
+	widget.SetTitle("Task")
+	widget.dueField.SetText("someday")
+
+	// When
+	err := widget.Validate()
+
+	// Then
+	assert.ErrorIs(t, err, dateparse.ErrInvalidDate)
+}
+
+func TestInputFormWidget_Clear_ShouldClearDueDate(t *testing.T) {
+	// Given
+	widget := NewInputFormWidget(NewTheme())
+	widget.dueField.SetText("tomorrow")
+
+	// When
+	widget.Clear()
+
+	// Then
+	assert.Empty(t, widget.GetDueText())
+	assert.Nil(t, widget.GetFormData().DueDate)
+}
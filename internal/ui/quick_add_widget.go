@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"strings"
+
+	"task-cli/internal/model"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// quickAddHeight はクイック追加バーを表示している間の高さ（入力欄とエラー表示）
+const quickAddHeight = 2
+
+// QuickAddWidget は1行の入力からタスクを追加するクイック追加バーのウィジェット
+type QuickAddWidget struct {
+	layout         *tview.Flex
+	inputField     *tview.InputField
+	errorLabel     *tview.TextView
+	theme          *Theme
+	errorMessage   string
+	submitCallback func(string)
+	cancelCallback func()
+}
+
+// NewQuickAddWidget は新しいQuickAddWidgetを作成する
+func NewQuickAddWidget(theme *Theme) *QuickAddWidget {
+	widget := &QuickAddWidget{theme: theme}
+
+	widget.inputField = tview.NewInputField().
+		SetLabel("Add: ").
+		SetPlaceholder("Fix login bug #auth !high due:fri @work est:2h").
+		SetFieldBackgroundColor(theme.GetBackgroundColor()).
+		SetLabelColor(theme.GetHighlightColor()).
+		SetDoneFunc(func(key tcell.Key) {
+			switch key {
+			case tcell.KeyEnter:
+				widget.Submit()
+			case tcell.KeyEscape:
+				widget.Cancel()
+			}
+		})
+
+	// エラーラベル
+	widget.errorLabel = tview.NewTextView().
+		SetTextColor(theme.GetPriorityColor(model.PriorityHigh)).
+		SetDynamicColors(true)
+	widget.errorLabel.SetBackgroundColor(theme.GetBackgroundColor())
+
+	widget.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(widget.inputField, 1, 0, true).
+		AddItem(widget.errorLabel, 1, 0, false)
+	widget.layout.SetBackgroundColor(theme.GetBackgroundColor())
+
+	return widget
+}
+
+// GetPrimitive はtview.Primitiveインターフェースを実装
+func (w *QuickAddWidget) GetPrimitive() tview.Primitive {
+	return w.layout
+}
+
+// SetText は入力欄のテキストを設定する
+func (w *QuickAddWidget) SetText(text string) {
+	w.inputField.SetText(text)
+}
+
+// GetText は入力欄のテキストを取得する
+func (w *QuickAddWidget) GetText() string {
+	return w.inputField.GetText()
+}
+
+// Submit は入力された行を送信する（空の場合は何もしない）
+func (w *QuickAddWidget) Submit() {
+	line := strings.TrimSpace(w.GetText())
+	if line == "" {
+		return
+	}
+
+	w.ClearError()
+	if w.submitCallback != nil {
+		w.submitCallback(line)
+	}
+}
+
+// Cancel は入力をキャンセルする
+func (w *QuickAddWidget) Cancel() {
+	w.Clear()
+	if w.cancelCallback != nil {
+		w.cancelCallback()
+	}
+}
+
+// Clear は入力欄とエラーメッセージをクリアする
+func (w *QuickAddWidget) Clear() {
+	w.inputField.SetText("")
+	w.ClearError()
+}
+
+// SetSubmitCallback は送信時のコールバックを設定する
+func (w *QuickAddWidget) SetSubmitCallback(callback func(string)) {
+	w.submitCallback = callback
+}
+
+// SetCancelCallback はキャンセル時のコールバックを設定する
+func (w *QuickAddWidget) SetCancelCallback(callback func()) {
+	w.cancelCallback = callback
+}
+
+// SetErrorMessage はエラーメッセージを設定する（複数行のエラーは1行にまとめる）
+func (w *QuickAddWidget) SetErrorMessage(message string) {
+	w.errorMessage = message
+	w.errorLabel.SetText("[red]" + tview.Escape(strings.ReplaceAll(message, "\n", "; ")) + "[white]")
+}
+
+// GetErrorMessage はエラーメッセージを取得する
+func (w *QuickAddWidget) GetErrorMessage() string {
+	return w.errorMessage
+}
+
+// ClearError はエラーメッセージをクリアする
+func (w *QuickAddWidget) ClearError() {
+	w.errorMessage = ""
+	w.errorLabel.SetText("")
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuickAddWidget_New_ShouldCreateWidget(t *testing.T) {
+	// When
+	widget := NewQuickAddWidget(NewTheme())
+
+	// Then
+	assert.NotNil(t, widget)
+	assert.Implements(t, (*tview.Primitive)(nil), widget.GetPrimitive())
+	assert.Empty(t, widget.GetText())
+}
+
+func TestQuickAddWidget_Submit_ShouldPassTrimmedLine(t *testing.T) {
+	// Given
+	widget := NewQuickAddWidget(NewTheme())
+	var submitted []string
+	widget.SetSubmitCallback(func(line string) {
+		submitted = append(submitted, line)
+	})
+
+	// When
+	widget.SetText("   ")
+	widget.Submit()
+	widget.SetText("  Buy milk #home ")
+	widget.Submit()
+
+	// Then - 空の入力は送信しない
+	assert.Equal(t, []string{"Buy milk #home"}, submitted)
+}
+
+func TestQuickAddWidget_Cancel_ShouldClearInputAndError(t *testing.T) {
+	// Given
+	widget := NewQuickAddWidget(NewTheme())
+	cancelled := false
+	widget.SetCancelCallback(func() {
+		cancelled = true
+	})
+	widget.SetText("Half typed")
+	widget.SetErrorMessage("column 1: \"x\": first\ncolumn 3: \"y\": second")
+
+	// When
+	widget.Cancel()
+
+	// Then
+	assert.True(t, cancelled)
+	assert.Empty(t, widget.GetText())
+	assert.Empty(t, widget.GetErrorMessage())
+}
+
+func TestQuickAddWidget_SetErrorMessage_ShouldShowAllErrorsOnOneLine(t *testing.T) {
+	// Given
+	widget := NewQuickAddWidget(NewTheme())
+
+	// When
+	widget.SetErrorMessage("column 1: \"x\": first\ncolumn 3: \"[y]\": second")
+
+	// Then
+	assert.Equal(t, "column 1: \"x\": first; column 3: \"[y]\": second", widget.errorLabel.GetText(true))
+}
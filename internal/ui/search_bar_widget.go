@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// searchBarHeight は検索バーを表示している間の高さ
+const searchBarHeight = 1
+
+// SearchBarWidget は入力に合わせてタスクを絞り込むインクリメンタル検索バーのウィジェット
+type SearchBarWidget struct {
+	layout         *tview.Flex
+	inputField     *tview.InputField
+	countLabel     *tview.TextView
+	theme          *Theme
+	changeCallback func(string)
+	submitCallback func(string)
+	cancelCallback func()
+}
+
+// NewSearchBarWidget は新しいSearchBarWidgetを作成する
+func NewSearchBarWidget(theme *Theme) *SearchBarWidget {
+	widget := &SearchBarWidget{theme: theme}
+
+	widget.inputField = tview.NewInputField().
+		SetLabel("/").
+		SetFieldBackgroundColor(theme.GetBackgroundColor()).
+		SetLabelColor(theme.GetHighlightColor()).
+		SetChangedFunc(func(text string) {
+			if widget.changeCallback != nil {
+				widget.changeCallback(text)
+			}
+		}).
+		SetDoneFunc(func(key tcell.Key) {
+			switch key {
+			case tcell.KeyEnter:
+				widget.Submit()
+			case tcell.KeyEscape:
+				widget.Cancel()
+			}
+		})
+
+	// 一致件数の表示
+	widget.countLabel = tview.NewTextView().
+		SetTextColor(theme.GetHighlightColor()).
+		SetTextAlign(tview.AlignRight)
+	widget.countLabel.SetBackgroundColor(theme.GetBackgroundColor())
+
+	widget.layout = tview.NewFlex().
+		AddItem(widget.inputField, 0, 1, true).
+		AddItem(widget.countLabel, 14, 0, false)
+	widget.layout.SetBackgroundColor(theme.GetBackgroundColor())
+
+	return widget
+}
+
+// GetPrimitive はtview.Primitiveインターフェースを実装
+func (w *SearchBarWidget) GetPrimitive() tview.Primitive {
+	return w.layout
+}
+
+// SetText は検索語を設定する（変更時のコールバックも呼ばれる）
+func (w *SearchBarWidget) SetText(text string) {
+	w.inputField.SetText(text)
+}
+
+// GetText は検索語を取得する
+func (w *SearchBarWidget) GetText() string {
+	return w.inputField.GetText()
+}
+
+// SetMatchCount は一致件数を表示する
+func (w *SearchBarWidget) SetMatchCount(count int) {
+	switch count {
+	case 1:
+		w.countLabel.SetText("1 match")
+	default:
+		w.countLabel.SetText(fmt.Sprintf("%d matches", count))
+	}
+}
+
+// GetMatchText は一致件数の表示を取得する
+func (w *SearchBarWidget) GetMatchText() string {
+	return w.countLabel.GetText(true)
+}
+
+// Submit は検索語を確定する
+func (w *SearchBarWidget) Submit() {
+	if w.submitCallback != nil {
+		w.submitCallback(w.GetText())
+	}
+}
+
+// Cancel は検索をキャンセルする
+func (w *SearchBarWidget) Cancel() {
+	if w.cancelCallback != nil {
+		w.cancelCallback()
+	}
+}
+
+// SetChangeCallback は検索語が変更されたときのコールバックを設定する
+func (w *SearchBarWidget) SetChangeCallback(callback func(string)) {
+	w.changeCallback = callback
+}
+
+// SetSubmitCallback は検索語を確定したときのコールバックを設定する
+func (w *SearchBarWidget) SetSubmitCallback(callback func(string)) {
+	w.submitCallback = callback
+}
+
+// SetCancelCallback はキャンセル時のコールバックを設定する
+func (w *SearchBarWidget) SetCancelCallback(callback func()) {
+	w.cancelCallback = callback
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchBarWidget_New_ShouldCreateWidget(t *testing.T) {
+	// When
+	widget := NewSearchBarWidget(NewTheme())
+
+	// Then
+	assert.NotNil(t, widget)
+	assert.Implements(t, (*tview.Primitive)(nil), widget.GetPrimitive())
+}
+
+func TestSearchBarWidget_SetText_ShouldNotifyEachChange(t *testing.T) {
+	// Given
+	widget := NewSearchBarWidget(NewTheme())
+	var queries []string
+	widget.SetChangeCallback(func(query string) {
+		queries = append(queries, query)
+	})
+
+	// When
+	widget.SetText("b")
+	widget.SetText("bu")
+
+	// Then
+	assert.Equal(t, []string{"b", "bu"}, queries)
+}
+
+func TestSearchBarWidget_SetMatchCount_ShouldShowCount(t *testing.T) {
+	// Given
+	widget := NewSearchBarWidget(NewTheme())
+
+	// When & Then
+	widget.SetMatchCount(1)
+	assert.Equal(t, "1 match", widget.GetMatchText())
+	widget.SetMatchCount(3)
+	assert.Equal(t, "3 matches", widget.GetMatchText())
+}
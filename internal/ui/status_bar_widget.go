@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"github.com/rivo/tview"
+)
+
+// StatusBarWidget は画面下部にキー操作のヘルプを表示し、一時的なメッセージ（トースト）があればそれに置き換えるウィジェット
+type StatusBarWidget struct {
+	textView *tview.TextView
+	theme    *Theme
+	help     string
+	message  string
+}
+
+// NewStatusBarWidget は新しいStatusBarWidgetを作成する
+func NewStatusBarWidget(theme *Theme, help string) *StatusBarWidget {
+	widget := &StatusBarWidget{theme: theme, help: help}
+
+	widget.textView = tview.NewTextView().
+		SetTextColor(theme.GetHighlightColor())
+	widget.textView.SetBackgroundColor(theme.GetBackgroundColor())
+
+	widget.render()
+	return widget
+}
+
+// GetPrimitive はtview.Primitiveインターフェースを実装
+func (w *StatusBarWidget) GetPrimitive() tview.Primitive {
+	return w.textView
+}
+
+// SetHelp は表示するヘルプを変更する（メッセージの表示中はメッセージを消したときに表示する）
+func (w *StatusBarWidget) SetHelp(help string) {
+	w.help = help
+	w.render()
+}
+
+// ShowMessage はヘルプの代わりにメッセージを表示する
+func (w *StatusBarWidget) ShowMessage(message string) {
+	w.message = message
+	w.render()
+}
+
+// ClearMessage はメッセージを消してヘルプの表示に戻す
+func (w *StatusBarWidget) ClearMessage() {
+	w.message = ""
+	w.render()
+}
+
+// GetMessage は表示中のメッセージを取得する（ない場合は空文字列）
+func (w *StatusBarWidget) GetMessage() string {
+	return w.message
+}
+
+// GetText は表示しているテキストを取得する
+func (w *StatusBarWidget) GetText() string {
+	return w.textView.GetText(false)
+}
+
+// render はメッセージがあればメッセージを、なければヘルプを表示する
+func (w *StatusBarWidget) render() {
+	if w.message != "" {
+		w.textView.SetTextColor(w.theme.GetForegroundColor()).SetText("» " + w.message)
+		return
+	}
+	w.textView.SetTextColor(w.theme.GetHighlightColor()).SetText(w.help)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusBarWidget_New_ShouldShowHelp(t *testing.T) {
+	// When
+	widget := NewStatusBarWidget(NewTheme(), "Keys: q=Quit")
+
+	// Then
+	assert.Equal(t, "Keys: q=Quit", widget.GetText())
+	assert.Empty(t, widget.GetMessage())
+}
+
+func TestStatusBarWidget_ShowMessage_ShouldReplaceHelpUntilCleared(t *testing.T) {
+	// Given
+	widget := NewStatusBarWidget(NewTheme(), "Keys: q=Quit")
+
+	// When
+	widget.ShowMessage(`Deleted "Write docs"`)
+
+	// Then
+	assert.Equal(t, `» Deleted "Write docs"`, widget.GetText())
+	assert.Equal(t, `Deleted "Write docs"`, widget.GetMessage())
+
+	// When
+	widget.ClearMessage()
+
+	// Then
+	assert.Equal(t, "Keys: q=Quit", widget.GetText())
+}
+
+func TestStatusBarWidget_SetHelp_ShouldShowNewHelpAfterMessage(t *testing.T) {
+	// Given
+	widget := NewStatusBarWidget(NewTheme(), "Keys: q=Quit")
+	widget.ShowMessage("Saved")
+
+	// When
+	widget.SetHelp("Searching: n=Next match")
+
+	// Then - メッセージの表示中はメッセージを表示し続ける
+	assert.Equal(t, "» Saved", widget.GetText())
+
+	// When
+	widget.ClearMessage()
+
+	// Then
+	assert.Equal(t, "Searching: n=Next match", widget.GetText())
+}
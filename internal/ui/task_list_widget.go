@@ -1,25 +1,46 @@
 package ui
 
 import (
-	"sort"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"task-cli/internal/dateparse"
 	"task-cli/internal/model"
 	"task-cli/internal/service"
 
 	"github.com/rivo/tview"
 )
 
+// blockedSymbol は未完了のタスクにブロックされているタスクのステータス列に表示する記号
+const blockedSymbol = "⊘"
+
+// recurringSymbol は繰り返しタスクのタイトルに付ける記号
+const recurringSymbol = "↻"
+
 // TaskListWidget はタスクリストを表示するウィジェット
+// サブタスクは親の下にインデントして表示し、親ごとに折りたたむことができる
 type TaskListWidget struct {
 	table             *tview.Table
 	theme             *Theme
 	allTasks          []*model.Task
 	filteredTasks     []*model.Task
+	rows              []taskRow
+	collapsed         map[string]bool
+	sortOrder         service.SortOrder
+	highlight         string // 検索語（小文字）。一致した部分を強調表示する
 	selectedIndex     int
 	selectionCallback func(*model.Task)
 }
 
+// taskRow はテーブルの1行に表示するタスクと階層の情報
+type taskRow struct {
+	task        *model.Task
+	depth       int
+	hasChildren bool
+}
+
 // NewTaskListWidget は新しいTaskListWidgetを作成する
 func NewTaskListWidget(theme *Theme) *TaskListWidget {
 	table := tview.NewTable().
@@ -31,6 +52,7 @@ func NewTaskListWidget(theme *Theme) *TaskListWidget {
 		theme:         theme,
 		allTasks:      make([]*model.Task, 0),
 		filteredTasks: make([]*model.Task, 0),
+		collapsed:     make(map[string]bool),
 		selectedIndex: 0,
 	}
 
@@ -39,10 +61,10 @@ func NewTaskListWidget(theme *Theme) *TaskListWidget {
 
 	// 選択変更時のコールバック
 	table.SetSelectionChangedFunc(func(row, column int) {
-		if row > 0 && row-1 < len(widget.filteredTasks) { // ヘッダー行を除く
+		if row > 0 && row-1 < len(widget.rows) { // ヘッダー行を除く
 			widget.selectedIndex = row - 1
 			if widget.selectionCallback != nil {
-				widget.selectionCallback(widget.filteredTasks[widget.selectedIndex])
+				widget.selectionCallback(widget.rows[widget.selectedIndex].task)
 			}
 		}
 	})
@@ -68,15 +90,22 @@ func (w *TaskListWidget) SetTasks(tasks []*model.Task) {
 	w.updateTable()
 }
 
-// GetTaskCount は表示中のタスク数を返す
+// GetAllTasks はフィルターを適用する前のタスクリストを返す
+func (w *TaskListWidget) GetAllTasks() []*model.Task {
+	tasks := make([]*model.Task, len(w.allTasks))
+	copy(tasks, w.allTasks)
+	return tasks
+}
+
+// GetTaskCount は表示中のタスク数を返す（折りたたまれたサブタスクは含まない）
 func (w *TaskListWidget) GetTaskCount() int {
-	return len(w.filteredTasks)
+	return len(w.rows)
 }
 
 // GetSelectedTask は選択中のタスクを返す
 func (w *TaskListWidget) GetSelectedTask() *model.Task {
-	if w.selectedIndex >= 0 && w.selectedIndex < len(w.filteredTasks) {
-		return w.filteredTasks[w.selectedIndex]
+	if w.selectedIndex >= 0 && w.selectedIndex < len(w.rows) {
+		return w.rows[w.selectedIndex].task
 	}
 	return nil
 }
@@ -88,18 +117,18 @@ func (w *TaskListWidget) GetSelectedIndex() int {
 
 // SelectTask は指定されたインデックスのタスクを選択する
 func (w *TaskListWidget) SelectTask(index int) {
-	if index >= 0 && index < len(w.filteredTasks) {
+	if index >= 0 && index < len(w.rows) {
 		w.selectedIndex = index
 		w.table.Select(index+1, 0) // ヘッダー行を考慮して+1
 		if w.selectionCallback != nil {
-			w.selectionCallback(w.filteredTasks[index])
+			w.selectionCallback(w.rows[index].task)
 		}
 	}
 }
 
 // SelectNext は次のタスクを選択する
 func (w *TaskListWidget) SelectNext() {
-	if w.selectedIndex < len(w.filteredTasks)-1 {
+	if w.selectedIndex < len(w.rows)-1 {
 		w.SelectTask(w.selectedIndex + 1)
 	}
 }
@@ -114,15 +143,6 @@ func (w *TaskListWidget) SelectPrevious() {
 // ApplyFilter はフィルターを適用する
 func (w *TaskListWidget) ApplyFilter(filter service.TaskFilter) {
 	w.filteredTasks = w.applyFilterToTasks(w.allTasks, filter)
-	
-	// 選択インデックスを調整
-	if w.selectedIndex >= len(w.filteredTasks) {
-		w.selectedIndex = len(w.filteredTasks) - 1
-	}
-	if w.selectedIndex < 0 && len(w.filteredTasks) > 0 {
-		w.selectedIndex = 0
-	}
-	
 	w.updateTable()
 }
 
@@ -133,53 +153,299 @@ func (w *TaskListWidget) ClearFilter() {
 	w.updateTable()
 }
 
-// SortByPriority は優先度でソートする
+// SortByPriority は優先度の高い順にソートする
 func (w *TaskListWidget) SortByPriority() {
-	sort.Slice(w.filteredTasks, func(i, j int) bool {
-		return w.getPriorityOrder(w.filteredTasks[i].Priority) < w.getPriorityOrder(w.filteredTasks[j].Priority)
-	})
-	w.updateTable()
+	w.SetSort(service.SortOrder{{Field: service.SortByPriority, Descending: true}})
 }
 
-// SortByStatus はステータスでソートする
+// SortByStatus はステータス順（未着手、作業中、完了）にソートする
 func (w *TaskListWidget) SortByStatus() {
-	sort.Slice(w.filteredTasks, func(i, j int) bool {
-		return w.getStatusOrder(w.filteredTasks[i].Status) < w.getStatusOrder(w.filteredTasks[j].Status)
-	})
+	w.SetSort(service.SortOrder{{Field: service.SortByStatus}})
+}
+
+// SetSort は並び順を設定する（タスクやフィルターを設定し直しても保たれる、空の場合は設定した順に表示する）
+// 選択中のタスクは並べ替えた後も選択したままにする
+func (w *TaskListWidget) SetSort(order service.SortOrder) {
+	selected := w.GetSelectedTask()
+	w.sortOrder = append(service.SortOrder(nil), order...)
+	w.setupHeader()
+	w.updateTable()
+
+	if selected == nil {
+		return
+	}
+	for i, row := range w.rows {
+		if row.task.ID == selected.ID {
+			w.SelectTask(i)
+			return
+		}
+	}
+}
+
+// GetSort は現在の並び順を返す
+func (w *TaskListWidget) GetSort() service.SortOrder {
+	return w.sortOrder
+}
+
+// ToggleCollapsed は選択中のタスクのサブタスクの表示・非表示を切り替える
+func (w *TaskListWidget) ToggleCollapsed() {
+	if task := w.GetSelectedTask(); task != nil {
+		w.SetCollapsed(task.ID, !w.collapsed[task.ID])
+	}
+}
+
+// SetCollapsed は指定したタスクのサブタスクを折りたたむかどうかを設定する
+func (w *TaskListWidget) SetCollapsed(taskID string, collapsed bool) {
+	if collapsed {
+		w.collapsed[taskID] = true
+	} else {
+		delete(w.collapsed, taskID)
+	}
 	w.updateTable()
 }
 
-// SetSelectionChangedCallback は選択変更時のコールバックを設定する
+// IsCollapsed は指定したタスクのサブタスクが折りたたまれているかを返す
+func (w *TaskListWidget) IsCollapsed(taskID string) bool {
+	return w.collapsed[taskID]
+}
+
+// SetSelectionChangedCallback は選択変更時のコールバックを設定する（選択中のタスクがなくなった場合は nil で呼び出す）
 func (w *TaskListWidget) SetSelectionChangedCallback(callback func(*model.Task)) {
 	w.selectionCallback = callback
 }
 
+// SetHighlight は検索語に一致する部分を強調表示する（空文字列の場合は強調表示をやめる）
+func (w *TaskListWidget) SetHighlight(query string) {
+	w.highlight = strings.ToLower(query)
+	w.updateTable()
+}
+
+// GetHighlight は強調表示している検索語を返す
+func (w *TaskListWidget) GetHighlight() string {
+	return w.highlight
+}
+
+// GetMatchCount は表示中の行のうち検索語に一致する行の数を返す
+func (w *TaskListWidget) GetMatchCount() int {
+	count := 0
+	for _, row := range w.rows {
+		if w.matches(row.task) {
+			count++
+		}
+	}
+	return count
+}
+
+// SelectNextMatch は選択中の行より後で検索語に一致する最初の行を選択する（末尾からは先頭に戻る）
+// 一致する行がない場合は false を返す
+func (w *TaskListWidget) SelectNextMatch() bool {
+	return w.selectMatch(1)
+}
+
+// SelectPreviousMatch は選択中の行より前で検索語に一致する最初の行を選択する（先頭からは末尾に戻る）
+// 一致する行がない場合は false を返す
+func (w *TaskListWidget) SelectPreviousMatch() bool {
+	return w.selectMatch(-1)
+}
+
+// SelectFirstMatch は検索語に一致する最初の行を選択する
+func (w *TaskListWidget) SelectFirstMatch() bool {
+	for i, row := range w.rows {
+		if w.matches(row.task) {
+			w.SelectTask(i)
+			return true
+		}
+	}
+	return false
+}
+
+// selectMatch は選択中の行から step の方向に検索語に一致する行を探して選択する
+func (w *TaskListWidget) selectMatch(step int) bool {
+	count := len(w.rows)
+	for offset := 1; offset <= count; offset++ {
+		index := ((w.selectedIndex+step*offset)%count + count) % count
+		if w.matches(w.rows[index].task) {
+			w.SelectTask(index)
+			return true
+		}
+	}
+	return false
+}
+
+// matches はタスクのタイトルか説明が検索語を含むかを返す
+func (w *TaskListWidget) matches(task *model.Task) bool {
+	if w.highlight == "" {
+		return false
+	}
+	return strings.Contains(strings.ToLower(task.Title), w.highlight) ||
+		strings.Contains(strings.ToLower(task.Description), w.highlight)
+}
+
+// highlightText は text 中の検索語に一致する部分を反転表示するスタイルタグを付ける
+// 検索中でなければ text をそのまま返す
+func (w *TaskListWidget) highlightText(text string) string {
+	if w.highlight == "" {
+		return text
+	}
+
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// 小文字にするとバイト長が変わる文字を含む場合は位置を対応付けられないため全体を強調する
+		if strings.Contains(lower, w.highlight) {
+			return "[::r]" + tview.Escape(text) + "[::-]"
+		}
+		return tview.Escape(text)
+	}
+
+	var b strings.Builder
+	for {
+		index := strings.Index(lower, w.highlight)
+		if index < 0 {
+			b.WriteString(tview.Escape(text))
+			return b.String()
+		}
+		end := index + len(w.highlight)
+		b.WriteString(tview.Escape(text[:index]))
+		b.WriteString("[::r]" + tview.Escape(text[index:end]) + "[::-]")
+		text, lower = text[end:], lower[end:]
+	}
+}
+
 // setupHeader はテーブルヘッダーを設定する
+// 並べ替えのキーになっている列には向きを示す矢印（複数のキーの場合は優先順位も）を付ける
 func (w *TaskListWidget) setupHeader() {
-	headerStyle := tview.NewTableCell("Status").
+	headerStyle := tview.NewTableCell(w.headerLabel("Status", service.SortByStatus)).
 		SetTextColor(w.theme.GetHighlightColor()).
 		SetBackgroundColor(w.theme.GetBackgroundColor()).
 		SetSelectable(false).
 		SetAlign(tview.AlignCenter)
 
 	w.table.SetCell(0, 0, headerStyle)
-	w.table.SetCell(0, 1, tview.NewTableCell("Priority").
+	w.table.SetCell(0, 1, tview.NewTableCell(w.headerLabel("Priority", service.SortByPriority)).
 		SetTextColor(w.theme.GetHighlightColor()).
 		SetBackgroundColor(w.theme.GetBackgroundColor()).
 		SetSelectable(false).
 		SetAlign(tview.AlignCenter))
-	w.table.SetCell(0, 2, tview.NewTableCell("Title").
+	w.table.SetCell(0, 2, tview.NewTableCell(w.titleHeaderLabel()).
+		SetTextColor(w.theme.GetHighlightColor()).
+		SetBackgroundColor(w.theme.GetBackgroundColor()).
+		SetSelectable(false).
+		SetAlign(tview.AlignLeft))
+	w.table.SetCell(0, 3, tview.NewTableCell(w.headerLabel("Due", service.SortByDue)).
 		SetTextColor(w.theme.GetHighlightColor()).
 		SetBackgroundColor(w.theme.GetBackgroundColor()).
 		SetSelectable(false).
 		SetAlign(tview.AlignLeft))
-	w.table.SetCell(0, 3, tview.NewTableCell("Description").
+	w.table.SetCell(0, 4, tview.NewTableCell("Description").
 		SetTextColor(w.theme.GetHighlightColor()).
 		SetBackgroundColor(w.theme.GetBackgroundColor()).
 		SetSelectable(false).
 		SetAlign(tview.AlignLeft))
 }
 
+// headerLabel は列名に並べ替えの向きを示す印を付ける（並べ替えのキーでない場合は列名のみ）
+func (w *TaskListWidget) headerLabel(name string, field service.SortField) string {
+	for i, key := range w.sortOrder {
+		if key.Field == field {
+			return name + " " + w.sortMark(i)
+		}
+	}
+	return name
+}
+
+// titleHeaderLabel はタイトル列の見出しを返す
+// 列を持たない属性（作成日時、更新日時、タグ）による並べ替えもここに示す
+func (w *TaskListWidget) titleHeaderLabel() string {
+	label := w.headerLabel("Title", service.SortByTitle)
+	var others []string
+	for i, key := range w.sortOrder {
+		switch key.Field {
+		case service.SortByCreated, service.SortByUpdated, service.SortByTag:
+			others = append(others, string(key.Field)+" "+w.sortMark(i))
+		}
+	}
+	if len(others) > 0 {
+		label += " (" + strings.Join(others, ", ") + ")"
+	}
+	return label
+}
+
+// sortMark は i 番目の並べ替えのキーの向きを矢印で返す（複数のキーの場合は優先順位を付ける）
+func (w *TaskListWidget) sortMark(i int) string {
+	mark := "↑"
+	if w.sortOrder[i].Descending {
+		mark = "↓"
+	}
+	if len(w.sortOrder) > 1 {
+		mark += strconv.Itoa(i + 1)
+	}
+	return mark
+}
+
+// buildRows は表示対象のタスクを親子の順に並べた行を作成する
+// 親が表示対象に含まれないタスクは最上位に表示し、兄弟間は並び順に従う
+func (w *TaskListWidget) buildRows() []taskRow {
+	sorted := service.SortTasks(w.filteredTasks, w.sortOrder)
+	visible := make(map[string]bool, len(sorted))
+	for _, task := range sorted {
+		visible[task.ID] = true
+	}
+	children := make(map[string][]*model.Task)
+	var roots []*model.Task
+	for _, task := range sorted {
+		if task.ParentID != "" && visible[task.ParentID] && task.ParentID != task.ID {
+			children[task.ParentID] = append(children[task.ParentID], task)
+		} else {
+			roots = append(roots, task)
+		}
+	}
+
+	rows := make([]taskRow, 0, len(w.filteredTasks))
+	added := make(map[string]bool, len(w.filteredTasks))
+	var walk func(task *model.Task, depth int, hidden bool)
+	walk = func(task *model.Task, depth int, hidden bool) {
+		// 壊れたデータに循環があっても無限に辿らない
+		if added[task.ID] {
+			return
+		}
+		added[task.ID] = true
+		if !hidden {
+			rows = append(rows, taskRow{task: task, depth: depth, hasChildren: len(children[task.ID]) > 0})
+		}
+		for _, child := range children[task.ID] {
+			walk(child, depth+1, hidden || w.collapsed[task.ID])
+		}
+	}
+	for _, task := range roots {
+		walk(task, 0, false)
+	}
+	// 循環していて最上位から辿れないタスクも表示する
+	for _, task := range sorted {
+		walk(task, 0, false)
+	}
+	return rows
+}
+
+// formatTitle はタイトルに階層のインデント、折りたたみ記号、繰り返し記号、サブタスクの進捗を付ける
+func (w *TaskListWidget) formatTitle(row taskRow, hierarchy *model.AppData) string {
+	marker := "  "
+	if row.hasChildren {
+		marker = "▾ "
+		if w.collapsed[row.task.ID] {
+			marker = "▸ "
+		}
+	}
+
+	title := strings.Repeat("  ", row.depth) + marker + w.highlightText(row.task.Title)
+	if row.task.Recurrence != nil {
+		title += " " + recurringSymbol
+	}
+	if done, total := hierarchy.SubtaskProgress(row.task.ID); total > 0 {
+		title += fmt.Sprintf(" (%d/%d)", done, total)
+	}
+	return title
+}
+
 // updateTable はテーブルの内容を更新する
 func (w *TaskListWidget) updateTable() {
 	// 既存の行をクリア（ヘッダー以外）
@@ -188,12 +454,31 @@ func (w *TaskListWidget) updateTable() {
 		w.table.RemoveRow(i)
 	}
 
+	w.rows = w.buildRows()
+
+	// 選択インデックスを調整
+	if w.selectedIndex >= len(w.rows) {
+		w.selectedIndex = len(w.rows) - 1
+	}
+	if w.selectedIndex < 0 && len(w.rows) > 0 {
+		w.selectedIndex = 0
+	}
+
+	// 進捗とブロック状態はフィルターに関係なく全てのタスクから判定する
+	hierarchy := &model.AppData{Tasks: w.allTasks}
+	now := time.Now()
+
 	// タスク行を追加
-	for i, task := range w.filteredTasks {
+	for i, taskRow := range w.rows {
 		row := i + 1 // ヘッダー行を考慮
+		task := taskRow.task
 
-		// ステータス列
-		statusCell := tview.NewTableCell(w.getStatusSymbol(task.Status)).
+		// ステータス列（未完了のタスクにブロックされている場合はブロック記号を表示する）
+		statusSymbol := w.getStatusSymbol(task.Status)
+		if !task.IsCompleted() && hierarchy.IsBlocked(task.ID) {
+			statusSymbol = blockedSymbol
+		}
+		statusCell := tview.NewTableCell(statusSymbol).
 			SetTextColor(w.theme.GetStatusColor(task.Status)).
 			SetBackgroundColor(w.theme.GetBackgroundColor()).
 			SetAlign(tview.AlignCenter)
@@ -205,17 +490,23 @@ func (w *TaskListWidget) updateTable() {
 			SetAlign(tview.AlignCenter)
 
 		// タイトル列
-		titleCell := tview.NewTableCell(task.Title).
+		titleCell := tview.NewTableCell(w.formatTitle(taskRow, hierarchy)).
 			SetTextColor(w.theme.GetForegroundColor()).
 			SetBackgroundColor(w.theme.GetBackgroundColor()).
 			SetAlign(tview.AlignLeft)
 
+		// 期限日列（期限切れ・期限間近は色を変える）
+		dueCell := tview.NewTableCell(formatDueDate(task.DueDate, now)).
+			SetTextColor(w.theme.GetDueColor(task.DueStatus(now))).
+			SetBackgroundColor(w.theme.GetBackgroundColor()).
+			SetAlign(tview.AlignLeft)
+
 		// 説明列
 		description := task.Description
 		if len(description) > 50 {
 			description = description[:47] + "..."
 		}
-		descCell := tview.NewTableCell(description).
+		descCell := tview.NewTableCell(w.highlightText(description)).
 			SetTextColor(w.theme.GetForegroundColor()).
 			SetBackgroundColor(w.theme.GetBackgroundColor()).
 			SetAlign(tview.AlignLeft)
@@ -223,45 +514,41 @@ func (w *TaskListWidget) updateTable() {
 		w.table.SetCell(row, 0, statusCell)
 		w.table.SetCell(row, 1, priorityCell)
 		w.table.SetCell(row, 2, titleCell)
-		w.table.SetCell(row, 3, descCell)
+		w.table.SetCell(row, 3, dueCell)
+		w.table.SetCell(row, 4, descCell)
 	}
 
-	// 選択状態を復元
-	if len(w.filteredTasks) > 0 && w.selectedIndex >= 0 && w.selectedIndex < len(w.filteredTasks) {
+	// 選択状態を復元（表示する行がなくなった場合は選択がないことを nil で通知する）
+	if len(w.rows) > 0 && w.selectedIndex >= 0 && w.selectedIndex < len(w.rows) {
 		w.table.Select(w.selectedIndex+1, 0)
+	} else if w.selectionCallback != nil {
+		w.selectionCallback(nil)
 	}
 }
 
-// applyFilterToTasks はタスクリストにフィルターを適用する
-func (w *TaskListWidget) applyFilterToTasks(tasks []*model.Task, filter service.TaskFilter) []*model.Task {
-	var filtered []*model.Task
-
-	for _, task := range tasks {
-		// ステータスフィルター
-		if filter.Status != nil && task.Status != *filter.Status {
-			continue
-		}
-
-		// 優先度フィルター
-		if filter.Priority != nil && task.Priority != *filter.Priority {
-			continue
-		}
-
-		// クエリフィルター
-		if filter.Query != "" {
-			query := strings.ToLower(filter.Query)
-			title := strings.ToLower(task.Title)
-			description := strings.ToLower(task.Description)
-			
-			if !strings.Contains(title, query) && !strings.Contains(description, query) {
-				continue
-			}
-		}
+// formatDueDate は期限日を表示用に整形する（前後1日は相対的な表現にする）
+func formatDueDate(due *time.Time, now time.Time) string {
+	if due == nil {
+		return ""
+	}
 
-		filtered = append(filtered, task)
+	today := dateparse.StartOfDay(now)
+	day := dateparse.StartOfDay(due.In(now.Location()))
+	switch {
+	case day.Equal(today):
+		return "today"
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return "tomorrow"
+	case day.Equal(today.AddDate(0, 0, -1)):
+		return "yesterday"
+	default:
+		return due.Format(dateparse.Layout)
 	}
+}
 
-	return filtered
+// applyFilterToTasks はタスクリストにフィルターを適用する
+func (w *TaskListWidget) applyFilterToTasks(tasks []*model.Task, filter service.TaskFilter) []*model.Task {
+	return service.FilterTasks(tasks, filter)
 }
 
 // getStatusSymbol はステータスに対応するシンボルを取得する
@@ -291,31 +578,3 @@ func (w *TaskListWidget) getPrioritySymbol(priority model.Priority) string {
 		return "   "
 	}
 }
-
-// getPriorityOrder は優先度の並び順を取得する
-func (w *TaskListWidget) getPriorityOrder(priority model.Priority) int {
-	switch priority {
-	case model.PriorityHigh:
-		return 0
-	case model.PriorityMedium:
-		return 1
-	case model.PriorityLow:
-		return 2
-	default:
-		return 3
-	}
-}
-
-// getStatusOrder はステータスの並び順を取得する
-func (w *TaskListWidget) getStatusOrder(status model.Status) int {
-	switch status {
-	case model.StatusTodo:
-		return 0
-	case model.StatusInProgress:
-		return 1
-	case model.StatusCompleted:
-		return 2
-	default:
-		return 3
-	}
-}
\ No newline at end of file
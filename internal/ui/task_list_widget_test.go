@@ -2,11 +2,13 @@ package ui
 
 import (
 	"testing"
+	"time"
 
 	"task-cli/internal/model"
 	"task-cli/internal/service"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/rivo/tview"
 )
 
@@ -234,4 +236,268 @@ func TestTaskListWidget_SetSelectionChangedCallback_ShouldInvokeCallback(t *test
 	assert.True(t, callbackInvoked)
 	assert.NotNil(t, selectedTask)
 	assert.Equal(t, "Task 1", selectedTask.Title)
-}
\ No newline at end of file
+}
+
+func TestTaskListWidget_SetTasks_WithSubtasks_ShouldShowTreeWithProgress(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	parent, _ := model.NewTask("Parent", "", model.PriorityMedium, nil)
+	other, _ := model.NewTask("Other", "", model.PriorityMedium, nil)
+	child, _ := model.NewTask("Child", "", model.PriorityMedium, nil)
+	done, _ := model.NewTask("Done child", "", model.PriorityMedium, nil)
+	child.ParentID = parent.ID
+	done.ParentID = parent.ID
+	done.Status = model.StatusCompleted
+
+	// When - 子が親より後ろ、別のタスクを挟んでいても親の直下に表示される
+	widget.SetTasks([]*model.Task{parent, other, child, done})
+
+	// Then
+	require.Equal(t, 4, widget.GetTaskCount())
+	widget.SelectTask(1)
+	assert.Equal(t, child.ID, widget.GetSelectedTask().ID)
+	widget.SelectTask(3)
+	assert.Equal(t, other.ID, widget.GetSelectedTask().ID)
+	assert.Equal(t, "▾ Parent (1/2)", widget.table.GetCell(1, 2).Text)
+	assert.Equal(t, "    Child", widget.table.GetCell(2, 2).Text)
+}
+
+func TestTaskListWidget_ToggleCollapsed_ShouldHideAndShowSubtasks(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	parent, _ := model.NewTask("Parent", "", model.PriorityMedium, nil)
+	child, _ := model.NewTask("Child", "", model.PriorityMedium, nil)
+	grandchild, _ := model.NewTask("Grandchild", "", model.PriorityMedium, nil)
+	child.ParentID = parent.ID
+	grandchild.ParentID = child.ID
+	widget.SetTasks([]*model.Task{parent, child, grandchild})
+	widget.SelectTask(0)
+
+	// When
+	widget.ToggleCollapsed()
+
+	// Then
+	assert.True(t, widget.IsCollapsed(parent.ID))
+	assert.Equal(t, 1, widget.GetTaskCount())
+	assert.Equal(t, "▸ Parent (0/2)", widget.table.GetCell(1, 2).Text)
+
+	// When
+	widget.ToggleCollapsed()
+
+	// Then
+	assert.Equal(t, 3, widget.GetTaskCount())
+}
+
+func TestTaskListWidget_ApplyFilter_WithHiddenParent_ShouldShowSubtaskAtTopLevel(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	parent, _ := model.NewTask("Parent", "", model.PriorityMedium, nil)
+	child, _ := model.NewTask("Child", "", model.PriorityMedium, nil)
+	child.ParentID = parent.ID
+	parent.Status = model.StatusCompleted
+	widget.SetTasks([]*model.Task{parent, child})
+
+	// When
+	widget.ApplyFilter(service.TaskFilter{Status: &[]model.Status{model.StatusTodo}[0]})
+
+	// Then
+	require.Equal(t, 1, widget.GetTaskCount())
+	assert.Equal(t, "  Child", widget.table.GetCell(1, 2).Text)
+}
+
+func TestTaskListWidget_SetTasks_WithOpenBlocker_ShouldShowBlockedSymbol(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	blocker, _ := model.NewTask("Blocker", "", model.PriorityMedium, nil)
+	blocked, _ := model.NewTask("Blocked", "", model.PriorityMedium, nil)
+	blocked.BlockedBy = []string{blocker.ID}
+
+	// When
+	widget.SetTasks([]*model.Task{blocker, blocked})
+	blockedSymbolBefore := widget.table.GetCell(2, 0).Text
+	blocker.Status = model.StatusCompleted
+	widget.SetTasks([]*model.Task{blocker, blocked})
+
+	// Then
+	assert.Equal(t, blockedSymbol, blockedSymbolBefore)
+	assert.Equal(t, "◯", widget.table.GetCell(2, 0).Text)
+}
+
+func TestTaskListWidget_SetTasks_WithRecurringTask_ShouldMarkTitle(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	task, _ := model.NewTask("Weekly review", "", model.PriorityMedium, nil)
+	task.Recurrence = &model.Recurrence{Frequency: model.FrequencyWeekly}
+
+	// When
+	widget.SetTasks([]*model.Task{task})
+
+	// Then
+	assert.Equal(t, "  Weekly review "+recurringSymbol, widget.table.GetCell(1, 2).Text)
+}
+
+func TestTaskListWidget_SetTasks_WithDueDates_ShouldShowColoredDueColumn(t *testing.T) {
+	// Given
+	theme := NewTheme()
+	widget := NewTaskListWidget(theme)
+	today := time.Now()
+	overdueDate := today.AddDate(0, 0, -3)
+	tomorrow := today.AddDate(0, 0, 1)
+	overdue, _ := model.NewTask("Overdue", "", model.PriorityMedium, nil)
+	overdue.DueDate = &overdueDate
+	soon, _ := model.NewTask("Soon", "", model.PriorityMedium, nil)
+	soon.DueDate = &tomorrow
+	none, _ := model.NewTask("No due date", "Description", model.PriorityMedium, nil)
+
+	// When
+	widget.SetTasks([]*model.Task{overdue, soon, none})
+
+	// Then
+	assert.Equal(t, "Due", widget.table.GetCell(0, 3).Text)
+	assert.Equal(t, overdueDate.Format("2006-01-02"), widget.table.GetCell(1, 3).Text)
+	overdueColor, _, _ := widget.table.GetCell(1, 3).Style.Decompose()
+	assert.Equal(t, theme.GetDueColor(model.DueOverdue), overdueColor)
+	assert.Equal(t, "tomorrow", widget.table.GetCell(2, 3).Text)
+	soonColor, _, _ := widget.table.GetCell(2, 3).Style.Decompose()
+	assert.Equal(t, theme.GetDueColor(model.DueSoon), soonColor)
+	assert.Equal(t, "", widget.table.GetCell(3, 3).Text)
+	assert.Equal(t, "Description", widget.table.GetCell(3, 4).Text)
+}
+
+// newSearchFixture は検索語 "bug" に一致するタスクと一致しないタスクを含むウィジェットを作成する
+func newSearchFixture() *TaskListWidget {
+	widget := NewTaskListWidget(NewTheme())
+	widget.SetTasks([]*model.Task{
+		{ID: "1", Title: "Fix login Bug", Status: model.StatusTodo, Priority: model.PriorityHigh},
+		{ID: "2", Title: "Write docs", Status: model.StatusTodo, Priority: model.PriorityLow},
+		{ID: "3", Title: "Triage [ui] report", Description: "a bug in the list", Status: model.StatusTodo, Priority: model.PriorityMedium},
+		{ID: "4", Title: "Release", Status: model.StatusTodo, Priority: model.PriorityMedium},
+	})
+	return widget
+}
+
+func TestTaskListWidget_SetHighlight_ShouldMarkMatchesAndEscapeText(t *testing.T) {
+	// Given
+	widget := newSearchFixture()
+
+	// When
+	widget.SetHighlight("BUG")
+
+	// Then - 大文字小文字を区別せずに一致部分だけを反転表示する
+	assert.Equal(t, "  Fix login [::r]Bug[::-]", widget.table.GetCell(1, 2).Text)
+	assert.Equal(t, "  Write docs", widget.table.GetCell(2, 2).Text)
+	assert.Equal(t, "  Triage [ui[] report", widget.table.GetCell(3, 2).Text)
+	assert.Equal(t, "a [::r]bug[::-] in the list", widget.table.GetCell(3, 4).Text)
+	assert.Equal(t, 2, widget.GetMatchCount())
+
+	// When - 強調表示をやめる
+	widget.SetHighlight("")
+
+	// Then
+	assert.Equal(t, "  Fix login Bug", widget.table.GetCell(1, 2).Text)
+	assert.Zero(t, widget.GetMatchCount())
+}
+
+func TestTaskListWidget_SelectNextMatch_ShouldWrapAroundMatches(t *testing.T) {
+	// Given
+	widget := newSearchFixture()
+	widget.SetHighlight("bug")
+	require.True(t, widget.SelectFirstMatch())
+	assert.Equal(t, "1", widget.GetSelectedTask().ID)
+
+	// When & Then - 一致しない行は飛ばし、末尾の次は先頭に戻る
+	assert.True(t, widget.SelectNextMatch())
+	assert.Equal(t, "3", widget.GetSelectedTask().ID)
+	assert.True(t, widget.SelectNextMatch())
+	assert.Equal(t, "1", widget.GetSelectedTask().ID)
+
+	// When & Then - 逆方向
+	assert.True(t, widget.SelectPreviousMatch())
+	assert.Equal(t, "3", widget.GetSelectedTask().ID)
+}
+
+func TestTaskListWidget_SelectNextMatch_WithoutMatches_ShouldKeepSelection(t *testing.T) {
+	// Given
+	widget := newSearchFixture()
+	widget.SelectTask(1)
+	widget.SetHighlight("nothing like this")
+
+	// When
+	moved := widget.SelectNextMatch()
+
+	// Then
+	assert.False(t, moved)
+	assert.Equal(t, "2", widget.GetSelectedTask().ID)
+}
+
+func TestTaskListWidget_SetSort_ShouldSurviveSetTasks(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	low, _ := model.NewTask("Low", "", model.PriorityLow, nil)
+	high, _ := model.NewTask("High", "", model.PriorityHigh, nil)
+	widget.SortByPriority()
+
+	// When
+	widget.SetTasks([]*model.Task{low, high})
+
+	// Then
+	widget.SelectTask(0)
+	assert.Equal(t, high.ID, widget.GetSelectedTask().ID)
+}
+
+func TestTaskListWidget_SetSort_ShouldSortSubtasksUnderTheirParent(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	parent, _ := model.NewTask("Parent", "", model.PriorityLow, nil)
+	other, _ := model.NewTask("Other", "", model.PriorityMedium, nil)
+	b, _ := model.NewTask("B child", "", model.PriorityHigh, nil)
+	a, _ := model.NewTask("A child", "", model.PriorityHigh, nil)
+	a.ParentID, b.ParentID = parent.ID, parent.ID
+	widget.SetTasks([]*model.Task{parent, other, b, a})
+
+	// When
+	widget.SetSort(service.SortOrder{{Field: service.SortByTitle}})
+
+	// Then
+	assert.Equal(t, "  Other", widget.table.GetCell(1, 2).Text)
+	assert.Equal(t, "▾ Parent (0/2)", widget.table.GetCell(2, 2).Text)
+	assert.Equal(t, "    A child", widget.table.GetCell(3, 2).Text)
+	assert.Equal(t, "    B child", widget.table.GetCell(4, 2).Text)
+}
+
+func TestTaskListWidget_SetSort_ShouldKeepSelectedTask(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+	low, _ := model.NewTask("Low", "", model.PriorityLow, nil)
+	high, _ := model.NewTask("High", "", model.PriorityHigh, nil)
+	widget.SetTasks([]*model.Task{low, high})
+	widget.SelectTask(0)
+
+	// When
+	widget.SortByPriority()
+
+	// Then
+	assert.Equal(t, 1, widget.GetSelectedIndex())
+	assert.Equal(t, low.ID, widget.GetSelectedTask().ID)
+}
+
+func TestTaskListWidget_SetSort_ShouldMarkSortedColumnsInHeader(t *testing.T) {
+	// Given
+	widget := NewTaskListWidget(NewTheme())
+
+	// When
+	widget.SetSort(service.DefaultSortOrder())
+
+	// Then
+	assert.Equal(t, "Status", widget.table.GetCell(0, 0).Text)
+	assert.Equal(t, "Priority ↓1", widget.table.GetCell(0, 1).Text)
+	assert.Equal(t, "Title (created ↑3)", widget.table.GetCell(0, 2).Text)
+	assert.Equal(t, "Due ↑2", widget.table.GetCell(0, 3).Text)
+
+	// When - キーが1つの場合は優先順位を付けない
+	widget.SetSort(service.SortOrder{{Field: service.SortByTitle, Descending: true}})
+
+	// Then
+	assert.Equal(t, "Priority", widget.table.GetCell(0, 1).Text)
+	assert.Equal(t, "Title ↓", widget.table.GetCell(0, 2).Text)
+}
@@ -22,6 +22,7 @@ type ThemeConfig struct {
 	Selection      tcell.Color
 	StatusColors   map[model.Status]tcell.Color
 	PriorityColors map[model.Priority]tcell.Color
+	DueColors      map[model.DueStatus]tcell.Color
 }
 
 // NewTheme はデフォルトテーマを作成する
@@ -68,6 +69,14 @@ func (t *Theme) GetStatusColor(status model.Status) tcell.Color {
 	return tcell.ColorWhite // デフォルトカラー
 }
 
+// GetDueColor は期限日の状態に対応する色を取得する（未設定の場合は前景色）
+func (t *Theme) GetDueColor(status model.DueStatus) tcell.Color {
+	if color, exists := t.config.DueColors[status]; exists {
+		return color
+	}
+	return t.config.Foreground
+}
+
 // GetStatusStyle はステータスに対応するスタイルを取得する
 func (t *Theme) GetStatusStyle(status model.Status) tcell.Style {
 	color := t.GetStatusColor(status)
@@ -158,6 +167,12 @@ func getDefaultThemeConfig() ThemeConfig {
 			model.PriorityMedium: tcell.ColorYellow,
 			model.PriorityLow:    tcell.ColorGreen,
 		},
+		DueColors: map[model.DueStatus]tcell.Color{
+			model.DueOverdue: tcell.ColorRed,
+			model.DueSoon:    tcell.ColorYellow,
+			model.DueLater:   tcell.ColorWhite,
+			model.DueNone:    tcell.ColorGray,
+		},
 	}
 }
 
@@ -179,6 +194,12 @@ func getDarkThemeConfig() ThemeConfig {
 			model.PriorityMedium: tcell.ColorYellow,
 			model.PriorityLow:    tcell.ColorGreen,
 		},
+		DueColors: map[model.DueStatus]tcell.Color{
+			model.DueOverdue: tcell.ColorRed,
+			model.DueSoon:    tcell.ColorYellow,
+			model.DueLater:   tcell.ColorSilver,
+			model.DueNone:    tcell.ColorDarkGray,
+		},
 	}
 }
 
@@ -200,5 +221,11 @@ func getLightThemeConfig() ThemeConfig {
 			model.PriorityMedium: tcell.ColorOrange,
 			model.PriorityLow:    tcell.ColorDarkGreen,
 		},
+		DueColors: map[model.DueStatus]tcell.Color{
+			model.DueOverdue: tcell.ColorDarkRed,
+			model.DueSoon:    tcell.ColorOrange,
+			model.DueLater:   tcell.ColorBlack,
+			model.DueNone:    tcell.ColorGray,
+		},
 	}
 }
\ No newline at end of file
@@ -197,4 +197,40 @@ func TestTheme_LightTheme_ShouldUseLightColors(t *testing.T) {
 	// Then
 	assert.Equal(t, tcell.ColorWhite, lightTheme.GetBackgroundColor())
 	assert.NotNil(t, lightTheme)
-}
\ No newline at end of file
+}
+
+func TestTheme_GetDueColor_ShouldHighlightOverdueAndDueSoon(t *testing.T) {
+	// Given
+	theme := NewTheme()
+
+	tests := []struct {
+		name     string
+		status   model.DueStatus
+		expected tcell.Color
+	}{
+		{"overdue", model.DueOverdue, tcell.ColorRed},
+		{"due soon", model.DueSoon, tcell.ColorYellow},
+		{"later", model.DueLater, tcell.ColorWhite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When
+			color := theme.GetDueColor(tt.status)
+
+			// Then
+			assert.Equal(t, tt.expected, color)
+		})
+	}
+}
+
+func TestTheme_GetDueColor_WithoutDueColors_ShouldUseForeground(t *testing.T) {
+	// Given
+	theme := NewCustomTheme(ThemeConfig{Foreground: tcell.ColorPurple})
+
+	// When
+	color := theme.GetDueColor(model.DueOverdue)
+
+	// Then
+	assert.Equal(t, tcell.ColorPurple, color)
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"task-cli/internal/model"
+
+	"github.com/rivo/tview"
+)
+
+const (
+	// viewBarHeight は保存したビューがある場合のビューバーの高さ
+	viewBarHeight = 1
+
+	// maxViewShortcuts は数字キーで選択できるビューの数（1〜9）
+	maxViewShortcuts = 9
+)
+
+// ViewBarWidget は保存したビューを数字キーと共に並べ、選択中のビューを示すウィジェット
+type ViewBarWidget struct {
+	textView *tview.TextView
+	theme    *Theme
+	views    []model.View
+	active   int            // 選択中のビューの番号（0はすべてのタスク）
+	project  *model.Project // 選択中のプロジェクト（nil はすべてのプロジェクト）
+}
+
+// NewViewBarWidget は新しいViewBarWidgetを作成する
+func NewViewBarWidget(theme *Theme) *ViewBarWidget {
+	widget := &ViewBarWidget{theme: theme}
+
+	widget.textView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetTextColor(theme.GetForegroundColor())
+	widget.textView.SetBackgroundColor(theme.GetBackgroundColor())
+
+	widget.render()
+	return widget
+}
+
+// GetPrimitive はtview.Primitiveインターフェースを実装
+func (w *ViewBarWidget) GetPrimitive() tview.Primitive {
+	return w.textView
+}
+
+// SetViews は表示するビューを設定し、選択を「すべて」に戻す
+// 数字キーで選択できるのは先頭の9件まで
+func (w *ViewBarWidget) SetViews(views []model.View) {
+	if len(views) > maxViewShortcuts {
+		views = views[:maxViewShortcuts]
+	}
+	w.views = views
+	w.active = 0
+	w.render()
+}
+
+// GetViews は表示しているビューを取得する
+func (w *ViewBarWidget) GetViews() []model.View {
+	return w.views
+}
+
+// GetView は番号に対応するビューを返す（0や範囲外の場合は false）
+func (w *ViewBarWidget) GetView(number int) (model.View, bool) {
+	if number < 1 || number > len(w.views) {
+		return model.View{}, false
+	}
+	return w.views[number-1], true
+}
+
+// SetActive は選択中のビューの番号を設定する（0はすべてのタスク）
+func (w *ViewBarWidget) SetActive(number int) {
+	w.active = number
+	w.render()
+}
+
+// GetActive は選択中のビューの番号を取得する（0はすべてのタスク）
+func (w *ViewBarWidget) GetActive() int {
+	return w.active
+}
+
+// SetProject は選択中のプロジェクトを設定する（nil はすべてのプロジェクト）
+func (w *ViewBarWidget) SetProject(project *model.Project) {
+	w.project = project
+	w.render()
+}
+
+// GetProject は選択中のプロジェクトを取得する（nil はすべてのプロジェクト）
+func (w *ViewBarWidget) GetProject() *model.Project {
+	return w.project
+}
+
+// GetText は表示しているテキストを色指定なしで取得する
+func (w *ViewBarWidget) GetText() string {
+	return w.textView.GetText(true)
+}
+
+// render はビューの一覧を描画する（選択中のビューは反転表示する）
+// プロジェクトを選択している場合は、その名前をプロジェクトの色の印と共に先頭に表示する
+func (w *ViewBarWidget) render() {
+	prefix := ""
+	if w.project != nil {
+		marker := "●"
+		if w.project.Color != "" {
+			marker = "[" + w.project.Color + "]●[-]"
+		}
+		prefix = marker + " " + tview.Escape(w.project.Name) + "  │  "
+	}
+
+	names := []string{"All"}
+	for _, view := range w.views {
+		names = append(names, view.Name)
+	}
+
+	items := make([]string, len(names))
+	for i, name := range names {
+		item := tview.Escape(fmt.Sprintf("[%d] %s", i, name))
+		if i == w.active {
+			item = "[::r]" + item + "[::-]"
+		}
+		items[i] = item
+	}
+	w.textView.SetText(prefix + strings.Join(items, "  "))
+}
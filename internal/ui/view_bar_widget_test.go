@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"task-cli/internal/model"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewBarWidget_New_ShouldShowAllOnly(t *testing.T) {
+	// When
+	widget := NewViewBarWidget(NewTheme())
+
+	// Then
+	assert.Implements(t, (*tview.Primitive)(nil), widget.GetPrimitive())
+	assert.Equal(t, "[0] All", widget.GetText())
+	assert.Equal(t, 0, widget.GetActive())
+}
+
+func TestViewBarWidget_SetViews_ShouldNumberViews(t *testing.T) {
+	// Given
+	widget := NewViewBarWidget(NewTheme())
+
+	// When
+	widget.SetViews([]model.View{{Name: "Backend [api]"}, {Name: "Due this week"}})
+
+	// Then
+	assert.Equal(t, "[0] All  [1] Backend [api]  [2] Due this week", widget.GetText())
+	view, ok := widget.GetView(2)
+	assert.True(t, ok)
+	assert.Equal(t, "Due this week", view.Name)
+	_, ok = widget.GetView(3)
+	assert.False(t, ok)
+	_, ok = widget.GetView(0)
+	assert.False(t, ok)
+}
+
+func TestViewBarWidget_SetViews_ShouldKeepFirstNine(t *testing.T) {
+	// Given
+	widget := NewViewBarWidget(NewTheme())
+	var views []model.View
+	for i := 1; i <= 12; i++ {
+		views = append(views, model.View{Name: fmt.Sprintf("v%d", i)})
+	}
+	widget.SetActive(1)
+
+	// When
+	widget.SetViews(views)
+
+	// Then
+	assert.Len(t, widget.GetViews(), 9)
+	assert.Equal(t, 0, widget.GetActive())
+}
+
+func TestViewBarWidget_SetProject_ShouldShowProjectBeforeViews(t *testing.T) {
+	// Given
+	widget := NewViewBarWidget(NewTheme())
+	widget.SetViews([]model.View{{Name: "Urgent"}})
+
+	// When
+	widget.SetProject(&model.Project{ID: "work", Name: "Work [team]", Color: "#ff8800"})
+
+	// Then - 色の指定は表示テキストに含まれない
+	assert.Equal(t, "● Work [team]  │  [0] All  [1] Urgent", widget.GetText())
+	assert.Equal(t, "work", widget.GetProject().ID)
+
+	// When - すべてのプロジェクトに戻す
+	widget.SetProject(nil)
+
+	// Then
+	assert.Equal(t, "[0] All  [1] Urgent", widget.GetText())
+}
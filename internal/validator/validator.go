@@ -65,6 +65,18 @@ func (v *Validator) ValidateTask(task *model.Task) error {
 		return errors.New("updated_at is required")
 	}
 
+	// 繰り返しルールの検証
+	if task.Recurrence != nil {
+		if err := task.Recurrence.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// 見積もり時間の検証
+	if task.Estimate < 0 {
+		return errors.New("estimate must not be negative")
+	}
+
 	// 完了日時の検証（完了ステータスの場合）
 	if task.Status == model.StatusCompleted && task.CompletedAt == nil {
 		return errors.New("completed_at is required when status is completed")
@@ -136,6 +136,26 @@ func TestValidator_ValidateTask_WithInvalidPriority_ShouldReturnError(t *testing
 	assert.Contains(t, result.Error(), "priority")
 }
 
+func TestValidator_ValidateTask_WithInvalidRecurrence_ShouldReturnError(t *testing.T) {
+	// Given
+	validator := New()
+	task := &model.Task{
+		ID:         "test-id",
+		Title:      "Valid title",
+		Status:     model.StatusTodo,
+		Priority:   model.PriorityMedium,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		Recurrence: &model.Recurrence{Frequency: model.FrequencyMonthly, Weekdays: []time.Weekday{time.Monday}}, // 毎月に曜日は指定できない
+	}
+
+	// When
+	result := validator.ValidateTask(task)
+
+	// Then
+	assert.ErrorIs(t, result, model.ErrInvalidRecurrence)
+}
+
 func TestValidator_ValidateTask_WithTooLongDescription_ShouldReturnError(t *testing.T) {
 	// Given
 	validator := New()